@@ -12,29 +12,117 @@ const (
 	appPropertyFilenameEnv     = "APP_PROPERTY_FILE"
 )
 
+const (
+	MongoBackend  = "mongo"
+	MemoryBackend = "memory"
+
+	LogNotifier  = "log"
+	FileNotifier = "file"
+
+	NoopScanner      = "none"
+	SignatureScanner = "signature"
+)
+
 type AppConfig struct {
-	Database          Database `properties:"database"`
-	SecretJWTKey      string   `properties:"jwtKeyEnv"`
-	ReportFolder      string   `properties:"reportFolder,default=resources/reports"`
-	AttachmentsBucket Bucket   `properties:"attachmentBucket"`
+	Backend           string        `properties:"backend,default=mongo"`
+	Database          Database      `properties:"database"`
+	Server            Server        `properties:"server"`
+	SecretJWTKey      string        `properties:"jwtKeyEnv"`
+	AttachmentsBucket Bucket        `properties:"attachmentBucket"`
+	Attachments       Attachments   `properties:"attachments"`
+	Session           Session       `properties:"session"`
+	PasswordReset     PasswordReset `properties:"passwordReset"`
+	Notifier          Notifier      `properties:"notifier"`
+	Lockout           Lockout       `properties:"lockout"`
+	ReportJobs        ReportJobs    `properties:"reportJobs"`
+	ReportExport      ReportExport  `properties:"reportExport"`
+	Notifications     Notifications `properties:"notifications"`
+	PlatformAdmin     PlatformAdmin `properties:"platformAdmin"`
 }
 
 type Database struct {
 	ConnStr string `properties:"connStr,default="`
 }
 
+// Server.TrustedProxies lists the proxies (IPs or CIDRs, separated by ";") whose X-Forwarded-For header
+// is trusted for the client IP. When empty, the client IP is always the remote address.
+type Server struct {
+	TrustedProxies []string `properties:"trustedProxies,default="`
+}
+
+type Attachments struct {
+	AllowedContentTypes []string `properties:"allowedContentTypes,default="`
+	MaxFileSize         int      `properties:"maxFileSize,default=52428800"`
+	MaxTicketSize       int      `properties:"maxTicketSize,default=524288000"`
+	Scanner             string   `properties:"scanner,default=none"`
+	SignaturesFile      string   `properties:"signaturesFile,default="`
+	ThumbnailSize       int      `properties:"thumbnailSize,default=320"`
+}
+
+type Session struct {
+	AccessTokenTTL  time.Duration `properties:"accessTokenTTL,default=15m"`
+	RefreshTokenTTL time.Duration `properties:"refreshTokenTTL,default=720h"`
+}
+
+type Lockout struct {
+	MaxAttempts   int           `properties:"maxAttempts,default=5"`
+	IPMaxAttempts int           `properties:"ipMaxAttempts,default=20"`
+	BaseLockTime  time.Duration `properties:"baseLockTime,default=1m"`
+	MaxLockTime   time.Duration `properties:"maxLockTime,default=1h"`
+}
+
+type ReportJobs struct {
+	Workers   int           `properties:"workers,default=2"`
+	QueueSize int           `properties:"queueSize,default=100"`
+	Timeout   time.Duration `properties:"timeout,default=5m"`
+}
+
+type ReportExport struct {
+	Concurrency int `properties:"concurrency,default=4"`
+	MaxTickets  int `properties:"maxTickets,default=200"`
+}
+
+// PlatformAdmin is the first platform admin, created on startup when no user has its email.
+type PlatformAdmin struct {
+	TenantID    string `properties:"tenantId,default=thavanna"`
+	Email       string `properties:"email,default="`
+	PasswordEnv string `properties:"passwordEnv,default=PLATFORM_ADMIN_PASSWORD_ENV"`
+}
+
+type Notifications struct {
+	SLACheckInterval time.Duration `properties:"slaCheckInterval,default=15m"`
+}
+
+type PasswordReset struct {
+	TokenTTL time.Duration `properties:"tokenTTL,default=1h"`
+	LinkURL  string        `properties:"linkUrl,default=http://localhost:3000/reset-password"`
+}
+
+type Notifier struct {
+	Type     string `properties:"type,default=log"`
+	FilePath string `properties:"filePath,default=tmp/notifications.log"`
+}
+
 type Bucket struct {
 	Name            string        `properties:"name"`
 	Region          string        `properties:"region"`
 	Timeout         time.Duration `properties:"timeout"`
 	AWSKeyIDEnv     string        `properties:"awsKeyIdEnv"`
 	AWSSecretKeyEnv string        `properties:"awsSecretKeyEnv"`
+	LocalFolder     string        `properties:"localFolder,default=tmp/attachments"`
+	LocalBaseURL    string        `properties:"localBaseUrl,default=http://localhost:8080"`
+	UploadURLTTL    time.Duration `properties:"uploadUrlTTL,default=15m"`
+	DownloadURLTTL  time.Duration `properties:"downloadUrlTTL,default=15m"`
 }
 
 func (db AppConfig) SecretKey() string {
 	return os.Getenv(db.SecretJWTKey)
 }
 
+func (a PlatformAdmin) Password() string {
+	return os.Getenv(a.PasswordEnv)
+}
+
 func (b Bucket) AWSKeyID() string {
 	return os.Getenv(b.AWSKeyIDEnv)
 }
@@ -0,0 +1,206 @@
+package application
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const attachmentArchiveIndexName = "index.csv"
+
+var attachmentArchiveIndexHeader = []string{
+	"comment_id", "comment_type", "attachment_id", "file_name", "path", "author_id", "author", "created_at", "size", "checksum", "visibility", "status",
+}
+
+type attachmentArchiveService struct {
+	ticketRepository domain.TicketRepository
+	userRepository   domain.UserRepository
+	commentService   CommentService
+	attachmentBucket domain.AttachmentBucket
+}
+
+type AttachmentArchiveService interface {
+	GetTicket(ctx context.Context, ticketID string) (*domain.Ticket, error)
+	WriteArchive(ctx context.Context, crmTicket domain.Ticket, output io.Writer) error
+}
+
+func NewAttachmentArchiveService(
+	ticketRepository domain.TicketRepository,
+	userRepository domain.UserRepository,
+	commentService CommentService,
+	attachmentBucket domain.AttachmentBucket,
+) AttachmentArchiveService {
+	return &attachmentArchiveService{
+		ticketRepository: ticketRepository,
+		userRepository:   userRepository,
+		commentService:   commentService,
+		attachmentBucket: attachmentBucket,
+	}
+}
+
+// GetTicket returns the ticket whose attachments are archived, so a missing ticket is reported before
+// anything is streamed.
+func (s *attachmentArchiveService) GetTicket(ctx context.Context, ticketID string) (*domain.Ticket, error) {
+	if ticketID == "" {
+		return nil, domain.NewValidationError("ticketID is required", nil)
+	}
+
+	return s.ticketRepository.GetByID(ctx, ticketID)
+}
+
+// WriteArchive streams a ZIP with the files attached to the ticket's comments, in one folder per
+// comment type, copying them from the bucket one at a time. An index.csv lists every attachment with
+// its author, date and original file name; files missing from the bucket are listed there as missing.
+func (s *attachmentArchiveService) WriteArchive(ctx context.Context, crmTicket domain.Ticket, output io.Writer) error {
+	comments, err := s.commentService.GetByTicketID(ctx, crmTicket.TicketID)
+	if err != nil {
+		return err
+	}
+
+	zipWriter := zip.NewWriter(output)
+	fileNames := make(map[string]bool)
+	authors := make(map[string]string)
+	index := [][]string{attachmentArchiveIndexHeader}
+
+	for _, comment := range comments {
+		for _, attachment := range comment.Attachments {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+
+			filePath := uniqueArchiveFilePath(fileNames, string(comment.CommentType), attachment)
+			status, err := s.writeAttachment(ctx, zipWriter, filePath, attachment)
+			if err != nil {
+				return err
+			}
+			if status != "stored" {
+				filePath = ""
+			}
+
+			index = append(index, []string{
+				comment.CommentID,
+				string(comment.CommentType),
+				attachment.AttachmentID,
+				attachment.FileName,
+				filePath,
+				attachment.CreatedBy,
+				s.authorName(ctx, authors, attachment.CreatedBy),
+				attachment.CreatedAt.UTC().Format(time.RFC3339),
+				strconv.Itoa(attachment.Size),
+				attachment.Checksum,
+				string(attachment.Visibility.Narrowest(comment.Visibility)),
+				status,
+			})
+		}
+	}
+
+	indexWriter, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     attachmentArchiveIndexName,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(indexWriter)
+	if err = csvWriter.WriteAll(index); err != nil {
+		return err
+	}
+
+	return zipWriter.Close()
+}
+
+// writeAttachment copies the file from the bucket into the ZIP and returns the status listed in the
+// index for it. Images, videos and documents that are already compressed are stored as they are.
+func (s *attachmentArchiveService) writeAttachment(ctx context.Context, zipWriter *zip.Writer, filePath string, attachment domain.Attachment) (string, error) {
+	if attachment.Key == "" {
+		return "missing", nil
+	}
+
+	content, err := s.attachmentBucket.Open(ctx, attachment.Key)
+	if err != nil {
+		var customErr *domain.CustomError
+		if errors.As(err, &customErr) && customErr.IsNotFound() {
+			return "missing", nil
+		}
+		return "", err
+	}
+	defer content.Close()
+
+	header := &zip.FileHeader{
+		Name:     filePath,
+		Method:   zip.Deflate,
+		Modified: attachment.CreatedAt,
+	}
+	if isCompressedContentType(attachment.ContentType) {
+		header.Method = zip.Store
+	}
+
+	fileWriter, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = io.Copy(fileWriter, content); err != nil {
+		return "", err
+	}
+
+	return "stored", nil
+}
+
+// authorName returns the full name of the user, looking each user up once. Users that can't be found
+// are listed by ID only.
+func (s *attachmentArchiveService) authorName(ctx context.Context, authors map[string]string, userID string) string {
+	if name, ok := authors[userID]; ok {
+		return name
+	}
+
+	name := ""
+	if user, err := s.userRepository.GetByID(ctx, userID); err == nil {
+		name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+	}
+	authors[userID] = name
+
+	return name
+}
+
+// uniqueArchiveFilePath places the attachment in its comment type folder under its original name,
+// adding the attachment ID when the name is already taken in that folder.
+func uniqueArchiveFilePath(fileNames map[string]bool, commentType string, attachment domain.Attachment) string {
+	fileName := path.Base(strings.ReplaceAll(attachment.FileName, "\\", "/"))
+	if fileName == "." || fileName == "/" || fileName == ".." {
+		fileName = attachment.AttachmentID
+	}
+
+	folder := commentType
+	if folder == "" {
+		folder = "Other"
+	}
+
+	filePath := path.Join(folder, fileName)
+	if fileNames[filePath] {
+		extension := path.Ext(fileName)
+		filePath = path.Join(folder, fmt.Sprintf("%s-%s%s", strings.TrimSuffix(fileName, extension), attachment.AttachmentID, extension))
+	}
+	fileNames[filePath] = true
+
+	return filePath
+}
+
+func isCompressedContentType(contentType string) bool {
+	if strings.HasPrefix(contentType, "image/") || strings.HasPrefix(contentType, "video/") {
+		return true
+	}
+
+	return strings.HasPrefix(contentType, "application/vnd.openxmlformats-officedocument.")
+}
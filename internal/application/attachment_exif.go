@@ -0,0 +1,280 @@
+package application
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	exifTagMake                      = 0x010f
+	exifTagModel                     = 0x0110
+	exifTagOrientation               = 0x0112
+	exifTagExifIFD                   = 0x8769
+	exifTagGPSIFD                    = 0x8825
+	exifTagDateTimeOriginal          = 0x9003
+	exifTagOffsetTimeOriginal        = 0x9011
+	exifTagGPSLatitudeRef            = 0x0001
+	exifTagGPSLatitude               = 0x0002
+	exifTagGPSLongitudeRef           = 0x0003
+	exifTagGPSLongitude              = 0x0004
+	exifDateTimeLayout               = "2006:01:02 15:04:05"
+	exifOffsetTimeLayout             = "-07:00"
+	exifMaxIFDEntries                = 1024
+	exifTypeASCII             uint16 = 2
+	exifTypeShort             uint16 = 3
+	exifTypeLong              uint16 = 4
+	exifTypeRational          uint16 = 5
+)
+
+var errNoEXIF = errors.New("image has no EXIF data")
+
+// exifTypeSizes is the size in bytes of one value of each TIFF field type.
+var exifTypeSizes = map[uint16]int{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}
+
+type exifMetadata struct {
+	takenAt     *time.Time
+	latitude    *float64
+	longitude   *float64
+	cameraModel string
+	orientation int
+}
+
+type exifEntry struct {
+	fieldType uint16
+	count     int
+	value     []byte
+}
+
+type exifReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// parseJPEGEXIF reads the capture time, GPS position, camera and orientation from the EXIF segment
+// of a JPEG file. Capture times without an offset are taken as UTC.
+func parseJPEGEXIF(data []byte) (*exifMetadata, error) {
+	tiff, err := findJPEGEXIF(data)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := newEXIFReader(tiff)
+	if err != nil {
+		return nil, err
+	}
+
+	ifd0, err := reader.readIFD(int(reader.order.Uint32(tiff[4:8])))
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &exifMetadata{orientation: 1}
+	metadata.cameraModel = cameraModel(reader.ascii(ifd0[exifTagMake]), reader.ascii(ifd0[exifTagModel]))
+	if orientation, ok := reader.integer(ifd0[exifTagOrientation]); ok && orientation >= 1 && orientation <= 8 {
+		metadata.orientation = orientation
+	}
+
+	if offset, ok := reader.integer(ifd0[exifTagExifIFD]); ok {
+		if exifIFD, err := reader.readIFD(offset); err == nil {
+			metadata.takenAt = reader.dateTime(exifIFD[exifTagDateTimeOriginal], exifIFD[exifTagOffsetTimeOriginal])
+		}
+	}
+
+	if offset, ok := reader.integer(ifd0[exifTagGPSIFD]); ok {
+		if gpsIFD, err := reader.readIFD(offset); err == nil {
+			metadata.latitude = reader.coordinate(gpsIFD[exifTagGPSLatitude], gpsIFD[exifTagGPSLatitudeRef], "S")
+			metadata.longitude = reader.coordinate(gpsIFD[exifTagGPSLongitude], gpsIFD[exifTagGPSLongitudeRef], "W")
+		}
+	}
+
+	return metadata, nil
+}
+
+// findJPEGEXIF walks the JPEG markers up to the image data and returns the TIFF structure inside the
+// Exif APP1 segment.
+func findJPEGEXIF(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil, errors.New("not a JPEG file")
+	}
+
+	exifHeader := []byte("Exif\x00\x00")
+	for offset := 2; offset+4 <= len(data); {
+		if data[offset] != 0xff {
+			return nil, errors.New("invalid JPEG marker")
+		}
+
+		marker := data[offset+1]
+		if marker == 0xff {
+			offset++
+			continue
+		}
+		if marker == 0xda || marker == 0xd9 {
+			break
+		}
+
+		length := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		if length < 2 || offset+2+length > len(data) {
+			return nil, errors.New("invalid JPEG segment length")
+		}
+
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xe1 && bytes.HasPrefix(segment, exifHeader) {
+			return segment[len(exifHeader):], nil
+		}
+
+		offset += 2 + length
+	}
+
+	return nil, errNoEXIF
+}
+
+func newEXIFReader(tiff []byte) (*exifReader, error) {
+	if len(tiff) < 8 {
+		return nil, errors.New("EXIF data is too short")
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, errors.New("invalid EXIF byte order")
+	}
+
+	if order.Uint16(tiff[2:4]) != 42 {
+		return nil, errors.New("invalid EXIF header")
+	}
+
+	return &exifReader{data: tiff, order: order}, nil
+}
+
+// readIFD returns the entries of the directory at offset by tag, with their values resolved from
+// the entry itself or from where it points to.
+func (r *exifReader) readIFD(offset int) (map[uint16]exifEntry, error) {
+	if offset < 8 || offset+2 > len(r.data) {
+		return nil, errors.New("invalid EXIF directory offset")
+	}
+
+	count := int(r.order.Uint16(r.data[offset : offset+2]))
+	if count > exifMaxIFDEntries || offset+2+count*12 > len(r.data) {
+		return nil, errors.New("invalid EXIF directory size")
+	}
+
+	entries := make(map[uint16]exifEntry, count)
+	for idx := 0; idx < count; idx++ {
+		raw := r.data[offset+2+idx*12 : offset+2+(idx+1)*12]
+		fieldType := r.order.Uint16(raw[2:4])
+		typeSize, ok := exifTypeSizes[fieldType]
+		if !ok {
+			continue
+		}
+
+		valueCount := int(r.order.Uint32(raw[4:8]))
+		size := valueCount * typeSize
+		if valueCount < 0 || size < 0 || size > len(r.data) {
+			continue
+		}
+
+		value := raw[8 : 8+min(size, 4)]
+		if size > 4 {
+			valueOffset := int(r.order.Uint32(raw[8:12]))
+			if valueOffset < 0 || valueOffset+size > len(r.data) {
+				continue
+			}
+			value = r.data[valueOffset : valueOffset+size]
+		}
+
+		entries[r.order.Uint16(raw[0:2])] = exifEntry{fieldType: fieldType, count: valueCount, value: value}
+	}
+
+	return entries, nil
+}
+
+func (r *exifReader) ascii(entry exifEntry) string {
+	if entry.fieldType != exifTypeASCII {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimRight(string(entry.value), "\x00"))
+}
+
+func (r *exifReader) integer(entry exifEntry) (int, bool) {
+	switch {
+	case entry.fieldType == exifTypeShort && len(entry.value) >= 2:
+		return int(r.order.Uint16(entry.value)), true
+	case entry.fieldType == exifTypeLong && len(entry.value) >= 4:
+		return int(r.order.Uint32(entry.value)), true
+	default:
+		return 0, false
+	}
+}
+
+func (r *exifReader) rationals(entry exifEntry) []float64 {
+	if entry.fieldType != exifTypeRational {
+		return nil
+	}
+
+	values := make([]float64, 0, entry.count)
+	for idx := 0; idx+8 <= len(entry.value); idx += 8 {
+		denominator := r.order.Uint32(entry.value[idx+4 : idx+8])
+		if denominator == 0 {
+			return nil
+		}
+		values = append(values, float64(r.order.Uint32(entry.value[idx:idx+4]))/float64(denominator))
+	}
+
+	return values
+}
+
+func (r *exifReader) dateTime(dateTimeEntry, offsetEntry exifEntry) *time.Time {
+	value := r.ascii(dateTimeEntry)
+	if value == "" {
+		return nil
+	}
+
+	location := time.UTC
+	if offset, err := time.Parse(exifOffsetTimeLayout, r.ascii(offsetEntry)); err == nil {
+		location = offset.Location()
+	}
+
+	takenAt, err := time.ParseInLocation(exifDateTimeLayout, value, location)
+	if err != nil {
+		return nil
+	}
+	takenAt = takenAt.UTC()
+
+	return &takenAt
+}
+
+// coordinate converts the degrees, minutes and seconds of a GPS entry to decimal degrees, negative
+// when the reference is negativeRef (south or west).
+func (r *exifReader) coordinate(entry, refEntry exifEntry, negativeRef string) *float64 {
+	values := r.rationals(entry)
+	if len(values) != 3 {
+		return nil
+	}
+
+	coordinate := values[0] + values[1]/60 + values[2]/3600
+	if strings.EqualFold(r.ascii(refEntry), negativeRef) {
+		coordinate = -coordinate
+	}
+
+	return &coordinate
+}
+
+// cameraModel joins the maker and the model, unless the model already names the maker.
+func cameraModel(maker, model string) string {
+	if maker == "" || strings.HasPrefix(strings.ToLower(model), strings.ToLower(maker)) {
+		return model
+	}
+	if model == "" {
+		return maker
+	}
+
+	return maker + " " + model
+}
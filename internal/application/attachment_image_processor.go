@@ -0,0 +1,255 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const (
+	thumbnailJPEGQuality = 80
+	// maxThumbnailSourcePixels keeps small files that decode to huge images from using all the memory.
+	maxThumbnailSourcePixels = 50_000_000
+	// thumbnailSamples is how many source pixels per axis are averaged into each thumbnail pixel.
+	thumbnailSamples = 4
+)
+
+type attachmentImageProcessor struct {
+	attachmentBucket domain.AttachmentBucket
+	thumbnailSize    int
+}
+
+type AttachmentImageProcessor interface {
+	Process(ctx context.Context, attachment *domain.Attachment, content io.Reader) error
+	ProcessStored(ctx context.Context, attachment *domain.Attachment) error
+	DeleteThumbnails(ctx context.Context, attachments []domain.Attachment)
+}
+
+func NewAttachmentImageProcessor(attachmentBucket domain.AttachmentBucket, thumbnailSize int) AttachmentImageProcessor {
+	return &attachmentImageProcessor{
+		attachmentBucket: attachmentBucket,
+		thumbnailSize:    thumbnailSize,
+	}
+}
+
+// Process reads the EXIF metadata of JPEG photos into the attachment and stores a thumbnail of JPEG,
+// PNG and GIF images next to the original file, setting the attachment's ThumbnailKey. Other files are
+// left untouched, and images that can't be decoded are stored without a thumbnail.
+func (p *attachmentImageProcessor) Process(ctx context.Context, attachment *domain.Attachment, content io.Reader) error {
+	if !isThumbnailContentType(attachment.ContentType) {
+		return nil
+	}
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	orientation := 1
+	if attachment.ContentType == "image/jpeg" {
+		metadata, err := parseJPEGEXIF(data)
+		if err == nil {
+			attachment.TakenAt = metadata.takenAt
+			attachment.Latitude = metadata.latitude
+			attachment.Longitude = metadata.longitude
+			attachment.CameraModel = metadata.cameraModel
+			orientation = metadata.orientation
+		} else if err != errNoEXIF {
+			log.Printf("failed to read EXIF of attachment %s: %v", attachment.Key, err)
+		}
+	}
+
+	if p.thumbnailSize <= 0 {
+		return nil
+	}
+
+	thumbnail, contentType, err := p.thumbnail(data, orientation)
+	if err != nil {
+		log.Printf("failed to create thumbnail of attachment %s: %v", attachment.Key, err)
+		return nil
+	}
+
+	key := thumbnailKey(attachment.Key, contentType)
+	if err = p.attachmentBucket.Upload(ctx, key, bytes.NewReader(thumbnail), contentType); err != nil {
+		return err
+	}
+	attachment.ThumbnailKey = key
+
+	return nil
+}
+
+// ProcessStored processes an attachment whose file is already in the bucket.
+func (p *attachmentImageProcessor) ProcessStored(ctx context.Context, attachment *domain.Attachment) error {
+	if !isThumbnailContentType(attachment.ContentType) {
+		return nil
+	}
+
+	content, err := p.attachmentBucket.Open(ctx, attachment.Key)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	return p.Process(ctx, attachment, content)
+}
+
+// DeleteThumbnails removes the thumbnails stored for attachments that couldn't be saved.
+func (p *attachmentImageProcessor) DeleteThumbnails(ctx context.Context, attachments []domain.Attachment) {
+	for _, attachment := range attachments {
+		if attachment.ThumbnailKey == "" {
+			continue
+		}
+
+		if err := p.attachmentBucket.Delete(ctx, attachment.ThumbnailKey); err != nil {
+			log.Printf("failed to delete attachment thumbnail %s: %v", attachment.ThumbnailKey, err)
+		}
+	}
+}
+
+// thumbnail scales the image down to fit thumbnailSize, turned upright according to the EXIF
+// orientation. PNG and GIF images get a PNG thumbnail to keep their transparency.
+func (p *attachmentImageProcessor) thumbnail(data []byte, orientation int) ([]byte, string, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if config.Width*config.Height > maxThumbnailSourcePixels {
+		return nil, "", fmt.Errorf("image of %dx%d pixels is too large for a thumbnail", config.Width, config.Height)
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+
+	resized := resizeImage(source, p.thumbnailSize)
+	oriented := orientImage(resized, orientation)
+
+	var thumbnail bytes.Buffer
+	if format == "jpeg" {
+		if err = jpeg.Encode(&thumbnail, oriented, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+			return nil, "", err
+		}
+		return thumbnail.Bytes(), "image/jpeg", nil
+	}
+
+	if err = png.Encode(&thumbnail, oriented); err != nil {
+		return nil, "", err
+	}
+	return thumbnail.Bytes(), "image/png", nil
+}
+
+func isThumbnailContentType(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	default:
+		return false
+	}
+}
+
+// thumbnailKey stores the thumbnail next to the original file: `<id>.jpg` gets `<id>.thumb.jpg`.
+func thumbnailKey(key, contentType string) string {
+	extension := ".jpg"
+	if contentType == "image/png" {
+		extension = ".png"
+	}
+
+	return strings.TrimSuffix(key, path.Ext(key)) + ".thumb" + extension
+}
+
+// resizeImage scales the image down so its longest side is at most maxSize, averaging a grid of
+// source pixels into each pixel. Smaller images keep their size.
+func resizeImage(source image.Image, maxSize int) *image.RGBA {
+	bounds := source.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			width, height = maxSize, max(1, height*maxSize/width)
+		} else {
+			width, height = max(1, width*maxSize/height), maxSize
+		}
+	}
+
+	resized := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		yStep := max(1, (y1-y0)/thumbnailSamples)
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+			xStep := max(1, (x1-x0)/thumbnailSamples)
+
+			var r, g, b, a, samples uint64
+			for sy := y0; sy < y1; sy += yStep {
+				for sx := x0; sx < x1; sx += xStep {
+					sr, sg, sb, sa := source.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(sr), g+uint64(sg), b+uint64(sb), a+uint64(sa)
+					samples++
+				}
+			}
+
+			resized.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / samples),
+				G: uint16(g / samples),
+				B: uint16(b / samples),
+				A: uint16(a / samples),
+			})
+		}
+	}
+
+	return resized
+}
+
+// orientImage flips and rotates the image as the EXIF orientation (1 to 8) says it should be shown.
+func orientImage(source *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return source
+	}
+
+	width, height := source.Bounds().Dx(), source.Bounds().Dy()
+	outWidth, outHeight := width, height
+	if orientation >= 5 {
+		outWidth, outHeight = height, width
+	}
+
+	oriented := image.NewRGBA(image.Rect(0, 0, outWidth, outHeight))
+	for y := 0; y < outHeight; y++ {
+		for x := 0; x < outWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = width-1-x, y
+			case 3:
+				sx, sy = width-1-x, height-1-y
+			case 4:
+				sx, sy = x, height-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, height-1-x
+			case 7:
+				sx, sy = width-1-y, height-1-x
+			case 8:
+				sx, sy = width-1-y, x
+			}
+			oriented.SetRGBA(x, y, source.RGBAAt(sx, sy))
+		}
+	}
+
+	return oriented
+}
@@ -0,0 +1,256 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const sniffLength = 512
+
+type attachmentFileType struct {
+	contentType string
+	sniffed     []string
+}
+
+// attachmentFileTypes are the files accepted as attachments, by extension, with the content types
+// their first bytes can be detected as. Tenants can only narrow this list.
+var attachmentFileTypes = map[string]attachmentFileType{
+	"jpg":  {contentType: "image/jpeg", sniffed: []string{"image/jpeg"}},
+	"jpeg": {contentType: "image/jpeg", sniffed: []string{"image/jpeg"}},
+	"png":  {contentType: "image/png", sniffed: []string{"image/png"}},
+	"gif":  {contentType: "image/gif", sniffed: []string{"image/gif"}},
+	"webp": {contentType: "image/webp", sniffed: []string{"image/webp"}},
+	"heic": {contentType: "image/heic", sniffed: []string{"image/heic"}},
+	"mp4":  {contentType: "video/mp4", sniffed: []string{"video/mp4"}},
+	"mov":  {contentType: "video/quicktime", sniffed: []string{"video/quicktime", "video/mp4"}},
+	"pdf":  {contentType: "application/pdf", sniffed: []string{"application/pdf"}},
+	"txt":  {contentType: "text/plain", sniffed: []string{"text/plain"}},
+	"csv":  {contentType: "text/csv", sniffed: []string{"text/plain"}},
+	"docx": {contentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", sniffed: []string{"application/zip"}},
+	"xlsx": {contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", sniffed: []string{"application/zip"}},
+}
+
+type attachmentValidator struct {
+	tenantRepository     domain.TenantRepository
+	commentRepository    domain.CommentRepository
+	attachmentRepository domain.AttachmentRepository
+	attachmentBucket     domain.AttachmentBucket
+	malwareScanner       domain.MalwareScanner
+	defaultPolicy        domain.AttachmentPolicy
+}
+
+type AttachmentValidator interface {
+	Policy(ctx context.Context, tenantID string) (domain.AttachmentPolicy, error)
+	ValidateFile(policy domain.AttachmentPolicy, fileName string, contentType string, size int) (string, error)
+	ValidateContent(ctx context.Context, fileName string, content io.Reader) error
+	ValidateStoredFile(ctx context.Context, policy domain.AttachmentPolicy, key string, fileName string) (*domain.BucketObject, error)
+	ValidateTicketSize(ctx context.Context, policy domain.AttachmentPolicy, ticketID string, size int) error
+}
+
+func NewAttachmentValidator(
+	tenantRepository domain.TenantRepository,
+	commentRepository domain.CommentRepository,
+	attachmentRepository domain.AttachmentRepository,
+	attachmentBucket domain.AttachmentBucket,
+	malwareScanner domain.MalwareScanner,
+	defaultPolicy domain.AttachmentPolicy,
+) AttachmentValidator {
+	if len(defaultPolicy.AllowedContentTypes) == 0 {
+		defaultPolicy.AllowedContentTypes = attachmentContentTypes()
+	}
+
+	return &attachmentValidator{
+		tenantRepository:     tenantRepository,
+		commentRepository:    commentRepository,
+		attachmentRepository: attachmentRepository,
+		attachmentBucket:     attachmentBucket,
+		malwareScanner:       malwareScanner,
+		defaultPolicy:        defaultPolicy,
+	}
+}
+
+// Policy returns the tenant's attachment policy, completed with the defaults for what it doesn't set.
+func (v *attachmentValidator) Policy(ctx context.Context, tenantID string) (domain.AttachmentPolicy, error) {
+	tenant, err := v.tenantRepository.GetByID(ctx, tenantID)
+	if err != nil {
+		return domain.AttachmentPolicy{}, err
+	}
+
+	return tenant.AttachmentPolicy.WithDefaults(v.defaultPolicy), nil
+}
+
+// ValidateFile checks what is known about a file before reading it and returns the content type it's
+// stored with, which comes from its extension. The declared content type may be empty or generic.
+func (v *attachmentValidator) ValidateFile(policy domain.AttachmentPolicy, fileName string, contentType string, size int) (string, error) {
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), "."))
+	fileType, ok := attachmentFileTypes[extension]
+	if !ok {
+		return "", domain.NewValidationError("file type is not accepted", map[string]any{"file_name": fileName, "extension": extension})
+	}
+
+	declaredType, _, _ := mime.ParseMediaType(contentType)
+	if declaredType != "" && declaredType != "application/octet-stream" && declaredType != fileType.contentType {
+		return "", domain.NewValidationError("content type doesn't match the file extension", map[string]any{
+			"file_name":    fileName,
+			"content_type": declaredType,
+			"expected":     fileType.contentType,
+		})
+	}
+
+	if !policy.Allows(fileType.contentType) {
+		return "", domain.NewValidationError("file type is not allowed for this tenant", map[string]any{
+			"file_name":     fileName,
+			"content_type":  fileType.contentType,
+			"allowed_types": policy.AllowedContentTypes,
+		})
+	}
+
+	if size <= 0 {
+		return "", domain.NewValidationError("file is empty", map[string]any{"file_name": fileName})
+	}
+
+	if policy.MaxFileSize > 0 && size > policy.MaxFileSize {
+		return "", domain.NewValidationError("file is too large", map[string]any{
+			"file_name":     fileName,
+			"size":          size,
+			"max_file_size": policy.MaxFileSize,
+		})
+	}
+
+	return fileType.contentType, nil
+}
+
+// ValidateContent checks the first bytes of the file match its extension and runs the malware scanner
+// over the whole content.
+func (v *attachmentValidator) ValidateContent(ctx context.Context, fileName string, content io.Reader) error {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	head = head[:n]
+
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), "."))
+	detectedType := sniffContentType(head)
+	if !slices.Contains(attachmentFileTypes[extension].sniffed, detectedType) {
+		return domain.NewValidationError("file content doesn't match its extension", map[string]any{
+			"file_name": fileName,
+			"extension": extension,
+			"detected":  detectedType,
+		})
+	}
+
+	threat, err := v.malwareScanner.Scan(ctx, io.MultiReader(bytes.NewReader(head), content))
+	if err != nil {
+		return err
+	}
+
+	if threat != "" {
+		return domain.NewValidationError("file was blocked by the malware scanner", map[string]any{
+			"file_name": fileName,
+			"threat":    threat,
+		})
+	}
+
+	return nil
+}
+
+// ValidateStoredFile validates a file the client put in the bucket itself, using the size the bucket
+// reports instead of the one the client declared.
+func (v *attachmentValidator) ValidateStoredFile(ctx context.Context, policy domain.AttachmentPolicy, key string, fileName string) (*domain.BucketObject, error) {
+	object, err := v.attachmentBucket.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	object.ContentType, err = v.ValidateFile(policy, fileName, "", object.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := v.attachmentBucket.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	if err = v.ValidateContent(ctx, fileName, content); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// ValidateTicketSize checks the ticket's attachments stay under the policy limit once size more bytes
+// are attached to it.
+func (v *attachmentValidator) ValidateTicketSize(ctx context.Context, policy domain.AttachmentPolicy, ticketID string, size int) error {
+	if policy.MaxTicketSize <= 0 {
+		return nil
+	}
+
+	comments, err := v.commentRepository.GetByTicketID(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+
+	currentSize := 0
+	for _, comment := range comments {
+		attachments, err := v.attachmentRepository.GetByCommentID(ctx, comment.CommentID)
+		if err != nil {
+			return err
+		}
+
+		for _, attachment := range attachments {
+			currentSize += attachment.Size
+		}
+	}
+
+	if currentSize+size > policy.MaxTicketSize {
+		return domain.NewValidationError("ticket attachments would exceed the size limit", map[string]any{
+			"ticket_id":       ticketID,
+			"current_size":    currentSize,
+			"size":            size,
+			"max_ticket_size": policy.MaxTicketSize,
+		})
+	}
+
+	return nil
+}
+
+func attachmentContentTypes() []string {
+	contentTypes := make([]string, 0, len(attachmentFileTypes))
+	for _, fileType := range attachmentFileTypes {
+		if !slices.Contains(contentTypes, fileType.contentType) {
+			contentTypes = append(contentTypes, fileType.contentType)
+		}
+	}
+	slices.Sort(contentTypes)
+
+	return contentTypes
+}
+
+// sniffContentType tells apart the ISO media files by their brand, which http.DetectContentType
+// doesn't, and leaves the rest to it.
+func sniffContentType(head []byte) string {
+	if len(head) >= 12 && string(head[4:8]) == "ftyp" {
+		switch string(head[8:12]) {
+		case "heic", "heix", "heim", "heis", "mif1", "msf1":
+			return "image/heic"
+		case "qt  ":
+			return "video/quicktime"
+		default:
+			return "video/mp4"
+		}
+	}
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	return contentType
+}
@@ -0,0 +1,258 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/repository/bucket"
+	"github.com/icrxz/crm-api-core/internal/repository/memory"
+	"github.com/icrxz/crm-api-core/internal/repository/scanner"
+)
+
+const eicarTestFile = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+type attachmentTestFixture struct {
+	validator            AttachmentValidator
+	tenantRepository     domain.TenantRepository
+	commentRepository    domain.CommentRepository
+	attachmentRepository domain.AttachmentRepository
+	attachmentBucket     domain.AttachmentBucket
+	bucketFolder         string
+}
+
+func newAttachmentTestFixture(t *testing.T, defaultPolicy domain.AttachmentPolicy) attachmentTestFixture {
+	t.Helper()
+
+	bucketFolder := t.TempDir()
+	attachmentBucket, err := bucket.NewFileSystemBucket(bucketFolder, "http://localhost/files", "secret")
+	if err != nil {
+		t.Fatalf("NewFileSystemBucket unexpected error: %v", err)
+	}
+
+	malwareScanner, err := scanner.NewSignatureScanner("")
+	if err != nil {
+		t.Fatalf("NewSignatureScanner unexpected error: %v", err)
+	}
+
+	tenantRepository := memory.NewTenantRepository()
+	if _, err = tenantRepository.Create(context.Background(), domain.Tenant{TenantID: userTestTenant}); err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	commentRepository := memory.NewCommentRepository()
+	attachmentRepository := memory.NewAttachmentRepository()
+
+	return attachmentTestFixture{
+		validator:            NewAttachmentValidator(tenantRepository, commentRepository, attachmentRepository, attachmentBucket, malwareScanner, defaultPolicy),
+		tenantRepository:     tenantRepository,
+		commentRepository:    commentRepository,
+		attachmentRepository: attachmentRepository,
+		attachmentBucket:     attachmentBucket,
+		bucketFolder:         bucketFolder,
+	}
+}
+
+func pngTestFile(t *testing.T) []byte {
+	t.Helper()
+
+	var file bytes.Buffer
+	if err := png.Encode(&file, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatalf("png.Encode unexpected error: %v", err)
+	}
+
+	return file.Bytes()
+}
+
+func TestAttachmentValidatorValidateFile(t *testing.T) {
+	tests := []struct {
+		name            string
+		policy          domain.AttachmentPolicy
+		fileName        string
+		contentType     string
+		size            int
+		wantContentType string
+		wantErr         bool
+	}{
+		{name: "accepted file", fileName: "photo.png", contentType: "image/png", size: 10, wantContentType: "image/png"},
+		{name: "extension in upper case", fileName: "PHOTO.JPG", size: 10, wantContentType: "image/jpeg"},
+		{name: "generic content type", fileName: "report.pdf", contentType: "application/octet-stream", size: 10, wantContentType: "application/pdf"},
+		{name: "unknown extension", fileName: "script.exe", size: 10, wantErr: true},
+		{name: "no extension", fileName: "photo", size: 10, wantErr: true},
+		{name: "content type doesn't match the extension", fileName: "photo.png", contentType: "text/html", size: 10, wantErr: true},
+		{
+			name:     "type not allowed by the policy",
+			policy:   domain.AttachmentPolicy{AllowedContentTypes: []string{"application/pdf"}},
+			fileName: "photo.png",
+			size:     10,
+			wantErr:  true,
+		},
+		{name: "empty file", fileName: "photo.png", wantErr: true},
+		{name: "file above the size limit", policy: domain.AttachmentPolicy{MaxFileSize: 5}, fileName: "photo.png", size: 10, wantErr: true},
+		{name: "file at the size limit", policy: domain.AttachmentPolicy{MaxFileSize: 10}, fileName: "photo.png", size: 10, wantContentType: "image/png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newAttachmentTestFixture(t, domain.AttachmentPolicy{})
+			policy := tt.policy.WithDefaults(domain.AttachmentPolicy{AllowedContentTypes: attachmentContentTypes()})
+
+			contentType, err := fixture.validator.ValidateFile(policy, tt.fileName, tt.contentType, tt.size)
+			if tt.wantErr {
+				assertStatus(t, err, http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateFile unexpected error: %v", err)
+			}
+			if contentType != tt.wantContentType {
+				t.Errorf("content type = %q, want %q", contentType, tt.wantContentType)
+			}
+		})
+	}
+}
+
+func TestAttachmentValidatorValidateContent(t *testing.T) {
+	pngFile := pngTestFile(t)
+
+	tests := []struct {
+		name     string
+		fileName string
+		content  []byte
+		wantErr  bool
+	}{
+		{name: "image", fileName: "photo.png", content: pngFile},
+		{name: "text", fileName: "notes.txt", content: []byte("plain notes")},
+		{name: "csv read as text", fileName: "values.csv", content: []byte("a,b\n1,2\n")},
+		{name: "heic photo", fileName: "photo.heic", content: append([]byte("\x00\x00\x00\x18ftypheic"), make([]byte, 16)...)},
+		{name: "quicktime video", fileName: "video.mov", content: append([]byte("\x00\x00\x00\x14ftypqt  "), make([]byte, 16)...)},
+		{name: "html disguised as an image", fileName: "photo.png", content: []byte("<html><script>alert(1)</script></html>"), wantErr: true},
+		{name: "image disguised as a pdf", fileName: "report.pdf", content: pngFile, wantErr: true},
+		{name: "malware", fileName: "notes.txt", content: []byte(eicarTestFile), wantErr: true},
+		{name: "malware after the sniffed bytes", fileName: "notes.txt", content: []byte(strings.Repeat("a", 1000) + eicarTestFile), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newAttachmentTestFixture(t, domain.AttachmentPolicy{})
+
+			err := fixture.validator.ValidateContent(context.Background(), tt.fileName, bytes.NewReader(tt.content))
+			if tt.wantErr {
+				assertStatus(t, err, http.StatusBadRequest)
+			} else if err != nil {
+				t.Fatalf("ValidateContent unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAttachmentValidatorValidateStoredFile(t *testing.T) {
+	pngFile := pngTestFile(t)
+
+	tests := []struct {
+		name     string
+		policy   domain.AttachmentPolicy
+		fileName string
+		content  []byte
+		wantErr  bool
+	}{
+		{name: "valid file", fileName: "photo.png", content: pngFile},
+		{name: "stored size is above the limit", policy: domain.AttachmentPolicy{MaxFileSize: len(pngFile) - 1}, fileName: "photo.png", content: pngFile, wantErr: true},
+		{name: "stored content doesn't match the name", fileName: "photo.png", content: []byte("not an image"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newAttachmentTestFixture(t, domain.AttachmentPolicy{})
+			ctx := context.Background()
+			key := domain.AttachmentKeyPrefix(userTestTenant, "ticket") + "file"
+			if err := fixture.attachmentBucket.Upload(ctx, key, bytes.NewReader(tt.content), ""); err != nil {
+				t.Fatalf("Upload unexpected error: %v", err)
+			}
+			policy := tt.policy.WithDefaults(domain.AttachmentPolicy{AllowedContentTypes: attachmentContentTypes()})
+
+			object, err := fixture.validator.ValidateStoredFile(ctx, policy, key, tt.fileName)
+			if tt.wantErr {
+				assertStatus(t, err, http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateStoredFile unexpected error: %v", err)
+			}
+			if object.Size != len(tt.content) || object.ContentType != "image/png" {
+				t.Errorf("object = %+v, want %d bytes of image/png", object, len(tt.content))
+			}
+		})
+	}
+}
+
+func TestAttachmentValidatorValidateTicketSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  domain.AttachmentPolicy
+		size    int
+		wantErr bool
+	}{
+		{name: "no limit", size: 1000},
+		{name: "under the limit", policy: domain.AttachmentPolicy{MaxTicketSize: 100}, size: 40},
+		{name: "reaching the limit", policy: domain.AttachmentPolicy{MaxTicketSize: 100}, size: 50},
+		{name: "above the limit", policy: domain.AttachmentPolicy{MaxTicketSize: 100}, size: 51, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newAttachmentTestFixture(t, domain.AttachmentPolicy{})
+			ctx := domain.ContextWithTenantScope(context.Background(), userTestTenant)
+
+			comment, err := domain.NewComment("ticket", "with files", "author", domain.CONTENT, nil)
+			if err != nil {
+				t.Fatalf("NewComment unexpected error: %v", err)
+			}
+			comment.TenantID = userTestTenant
+			if _, err = fixture.commentRepository.Create(ctx, comment); err != nil {
+				t.Fatalf("Create unexpected error: %v", err)
+			}
+
+			attachment, err := domain.NewAttachment("photo.png", "", "png", "key", "author", 50)
+			if err != nil {
+				t.Fatalf("NewAttachment unexpected error: %v", err)
+			}
+			attachment.CommentID = comment.CommentID
+			attachment.TenantID = userTestTenant
+			if err = fixture.attachmentRepository.SaveBatch(ctx, []domain.Attachment{attachment}); err != nil {
+				t.Fatalf("SaveBatch unexpected error: %v", err)
+			}
+
+			err = fixture.validator.ValidateTicketSize(ctx, tt.policy, "ticket", tt.size)
+			if tt.wantErr {
+				assertStatus(t, err, http.StatusBadRequest)
+			} else if err != nil {
+				t.Fatalf("ValidateTicketSize unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAttachmentValidatorPolicy(t *testing.T) {
+	fixture := newAttachmentTestFixture(t, domain.AttachmentPolicy{MaxFileSize: 100, MaxTicketSize: 1000})
+	ctx := domain.ContextWithAllTenants(context.Background())
+
+	tenant := domain.Tenant{TenantID: "narrow", AttachmentPolicy: domain.AttachmentPolicy{AllowedContentTypes: []string{"application/pdf"}, MaxFileSize: 10}}
+	if _, err := fixture.tenantRepository.Create(ctx, tenant); err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	policy, err := fixture.validator.Policy(ctx, tenant.TenantID)
+	if err != nil {
+		t.Fatalf("Policy unexpected error: %v", err)
+	}
+
+	if !policy.Allows("application/pdf") || policy.Allows("image/png") || policy.MaxFileSize != 10 || policy.MaxTicketSize != 1000 {
+		t.Errorf("policy = %+v, want the tenant's types and file size with the default ticket size", policy)
+	}
+}
@@ -10,28 +10,52 @@ import (
 )
 
 type authService struct {
-	userRepository domain.UserRepository
-	jwtSecretKey   string
+	userRepository    domain.UserRepository
+	sessionRepository domain.SessionRepository
+	lockoutService    LockoutService
+	jwtSecretKey      string
+	accessTokenTTL    time.Duration
+	refreshTokenTTL   time.Duration
 }
 
 type AuthService interface {
-	Login(ctx context.Context, email, password string) (string, *domain.User, error)
+	Login(ctx context.Context, email, password, ipAddress string) (*domain.AuthTokens, *domain.User, error)
+	Refresh(ctx context.Context, refreshToken string) (*domain.AuthTokens, error)
 	Logout(ctx context.Context) error
-	CreateToken(userID string) (string, error)
+	LogoutAll(ctx context.Context, userID string) error
+	CreateToken(user domain.User, sessionID string) (string, error)
 	VerifyToken(tokenString string) (jwt.MapClaims, error)
-	VerifyUserSession(ctx context.Context, userID string) error
+	VerifyUserSession(ctx context.Context, userID, sessionID string) (*domain.User, error)
 }
 
-func NewAuthService(userRepository domain.UserRepository, jwtSecretKey string) AuthService {
+func NewAuthService(
+	userRepository domain.UserRepository,
+	sessionRepository domain.SessionRepository,
+	lockoutService LockoutService,
+	jwtSecretKey string,
+	accessTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+) AuthService {
 	return &authService{
-		userRepository: userRepository,
-		jwtSecretKey:   jwtSecretKey,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		lockoutService:    lockoutService,
+		jwtSecretKey:      jwtSecretKey,
+		accessTokenTTL:    accessTokenTTL,
+		refreshTokenTTL:   refreshTokenTTL,
 	}
 }
 
-func (a *authService) Login(ctx context.Context, email, password string) (string, *domain.User, error) {
+func (a *authService) Login(ctx context.Context, email, password, ipAddress string) (*domain.AuthTokens, *domain.User, error) {
+	// the caller's tenant is only known once the user is found
+	ctx = domain.ContextWithAllTenants(ctx)
+
 	if email == "" || password == "" {
-		return "", nil, domain.NewValidationError("email and password are required", nil)
+		return nil, nil, domain.NewValidationError("email and password are required", nil)
+	}
+
+	if err := a.lockoutService.CheckLogin(ctx, email, ipAddress); err != nil {
+		return nil, nil, err
 	}
 
 	userEmailFilter := domain.UserFilters{
@@ -42,40 +66,135 @@ func (a *authService) Login(ctx context.Context, email, password string) (string
 
 	users, err := a.userRepository.Search(ctx, userEmailFilter)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 
-	if len(users) <= 0 {
-		return "", nil, domain.NewValidationError("no user found", nil)
+	if len(users.Result) <= 0 {
+		domain.CompareDummyPassword(password)
+		return nil, nil, a.loginFailed(ctx, email, ipAddress, nil)
 	}
-	loggedUser := users[0]
+	loggedUser := users.Result[0]
 
 	if !loggedUser.ComparePassword(password) {
-		return "", nil, domain.NewValidationError("password is incorrect", nil)
+		return nil, nil, a.loginFailed(ctx, email, ipAddress, &loggedUser)
+	}
+
+	if !loggedUser.Active {
+		return nil, nil, domain.NewUnauthorizedError("user is not active")
+	}
+
+	if err = a.lockoutService.RegisterSuccess(ctx, email, ipAddress); err != nil {
+		return nil, nil, err
+	}
+
+	session, refreshToken, err := domain.NewSession(loggedUser.UserID, a.refreshTokenTTL)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	createdToken, err := a.CreateToken(loggedUser.UserID)
+	if _, err = a.sessionRepository.Create(ctx, session); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := a.createAuthTokens(loggedUser, session.SessionID, refreshToken)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 
 	err = a.userRepository.Update(ctx, loggedUser)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
+	}
+
+	return tokens, &loggedUser, nil
+}
+
+func (a *authService) Refresh(ctx context.Context, refreshToken string) (*domain.AuthTokens, error) {
+	ctx = domain.ContextWithAllTenants(ctx)
+
+	sessionID, secret, err := domain.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := a.sessionRepository.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, domain.NewUnauthorizedError("invalid refresh token")
+	}
+
+	if !session.IsActive() {
+		return nil, domain.NewUnauthorizedError("session is no longer active")
+	}
+
+	if !session.MatchesRefreshSecret(secret) {
+		// an already rotated refresh token is being reused, so the session can't be trusted anymore
+		session.Revoke()
+		if err = a.sessionRepository.Update(ctx, *session); err != nil {
+			return nil, err
+		}
+		return nil, domain.NewUnauthorizedError("invalid refresh token")
+	}
+
+	user, err := a.userRepository.GetByID(ctx, session.UserID)
+	if err != nil {
+		return nil, domain.NewUnauthorizedError("invalid refresh token")
+	}
+
+	if !user.Active {
+		return nil, domain.NewUnauthorizedError("user is not active")
+	}
+
+	newRefreshToken, err := session.Rotate(a.refreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.sessionRepository.Update(ctx, *session); err != nil {
+		return nil, err
 	}
 
-	return createdToken, &loggedUser, nil
+	return a.createAuthTokens(*user, session.SessionID, newRefreshToken)
 }
 
-// Logout implements AuthService.
 func (a *authService) Logout(ctx context.Context) error {
-	panic("unimplemented")
+	sessionID := domain.SessionIDFromContext(ctx)
+	if sessionID == "" {
+		return domain.NewUnauthorizedError("no active session")
+	}
+
+	session, err := a.sessionRepository.GetByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	session.Revoke()
+
+	return a.sessionRepository.Update(ctx, *session)
+}
+
+func (a *authService) LogoutAll(ctx context.Context, userID string) error {
+	if userID == "" {
+		return domain.NewValidationError("userID is required", nil)
+	}
+
+	user, err := a.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkCanManageUser(ctx, *user); err != nil {
+		return err
+	}
+
+	return a.sessionRepository.RevokeByUserID(ctx, userID, time.Now().UTC())
 }
 
-func (a *authService) CreateToken(userID string) (string, error) {
+func (a *authService) CreateToken(user domain.User, sessionID string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(12 * time.Hour).Unix(),
+		"user_id":    user.UserID,
+		"tenant_id":  user.TenantID,
+		"session_id": sessionID,
+		"exp":        time.Now().Add(a.accessTokenTTL).Unix(),
 	})
 
 	tokenString, err := token.SignedString([]byte(a.jwtSecretKey))
@@ -105,14 +224,52 @@ func (a *authService) VerifyToken(tokenString string) (jwt.MapClaims, error) {
 	return nil, fmt.Errorf("invalid token")
 }
 
-func (a *authService) VerifyUserSession(ctx context.Context, userID string) error {
-	fmt.Println("userID", userID)
+func (a *authService) VerifyUserSession(ctx context.Context, userID, sessionID string) (*domain.User, error) {
+	ctx = domain.ContextWithAllTenants(ctx)
+
+	if sessionID == "" {
+		return nil, domain.NewUnauthorizedError("session not found")
+	}
+
+	session, err := a.sessionRepository.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, domain.NewUnauthorizedError("session not found")
+	}
+
+	if session.UserID != userID || !session.IsActive() {
+		return nil, domain.NewUnauthorizedError("session is no longer active")
+	}
 
 	user, err := a.userRepository.GetByID(ctx, userID)
 	if err != nil {
+		return nil, err
+	}
+
+	if !user.Active {
+		return nil, domain.NewUnauthorizedError("user is not active")
+	}
+
+	return user, nil
+}
+
+// loginFailed gives the same answer whether the account exists or the password is wrong.
+func (a *authService) loginFailed(ctx context.Context, email, ipAddress string, user *domain.User) error {
+	if err := a.lockoutService.RegisterFailure(ctx, email, ipAddress, user); err != nil {
 		return err
 	}
-	fmt.Println(user)
 
-	return nil
+	return domain.NewUnauthorizedError("invalid email or password")
+}
+
+func (a *authService) createAuthTokens(user domain.User, sessionID, refreshToken string) (*domain.AuthTokens, error) {
+	accessToken, err := a.CreateToken(user, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().UTC().Add(a.accessTokenTTL),
+	}, nil
 }
@@ -0,0 +1,175 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/repository/memory"
+)
+
+func newAuthTestService(userRepository domain.UserRepository, sessionRepository domain.SessionRepository) AuthService {
+	lockoutPolicy := domain.LockoutPolicy{MaxAttempts: 5, BaseLockTime: time.Minute, MaxLockTime: time.Hour}
+	lockoutService := NewLockoutService(
+		userRepository,
+		memory.NewLoginAttemptRepository(),
+		memory.NewUserLockEventRepository(),
+		lockoutPolicy,
+		lockoutPolicy,
+	)
+
+	return NewAuthService(userRepository, sessionRepository, lockoutService, "secret", time.Minute, time.Hour)
+}
+
+// loginTestUser logs the user created with createTestUser in and returns its tokens and session.
+func loginTestUser(t *testing.T, service AuthService, user domain.User) (*domain.AuthTokens, string) {
+	t.Helper()
+
+	tokens, _, err := service.Login(context.Background(), user.Email, "password", "10.0.0.1")
+	if err != nil {
+		t.Fatalf("Login unexpected error: %v", err)
+	}
+
+	claims, err := service.VerifyToken(tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("VerifyToken unexpected error: %v", err)
+	}
+	sessionID, _ := claims["session_id"].(string)
+
+	return tokens, sessionID
+}
+
+func TestAuthServiceRefresh(t *testing.T) {
+	tests := []struct {
+		name         string
+		refreshToken func(tokens domain.AuthTokens, rotated string) string
+		deactivate   bool
+		wantRevoked  bool
+		wantStatus   int
+	}{
+		{
+			name:         "rotated token",
+			refreshToken: func(tokens domain.AuthTokens, rotated string) string { return rotated },
+		},
+		{
+			name:         "reused token revokes the session",
+			refreshToken: func(tokens domain.AuthTokens, rotated string) string { return tokens.RefreshToken },
+			wantRevoked:  true,
+			wantStatus:   http.StatusUnauthorized,
+		},
+		{
+			name:         "malformed token",
+			refreshToken: func(tokens domain.AuthTokens, rotated string) string { return "not-a-token" },
+			wantStatus:   http.StatusUnauthorized,
+		},
+		{
+			name:         "unknown session",
+			refreshToken: func(tokens domain.AuthTokens, rotated string) string { return "unknown." + rotated },
+			wantStatus:   http.StatusUnauthorized,
+		},
+		{
+			name:         "inactive user",
+			refreshToken: func(tokens domain.AuthTokens, rotated string) string { return rotated },
+			deactivate:   true,
+			wantStatus:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepository := memory.NewUserRepository()
+			sessionRepository := memory.NewSessionRepository()
+			service := newAuthTestService(userRepository, sessionRepository)
+			user := createTestUser(t, userRepository, "user@example.com", domain.OPERATOR)
+			tokens, sessionID := loginTestUser(t, service, user)
+
+			rotated, err := service.Refresh(context.Background(), tokens.RefreshToken)
+			if err != nil {
+				t.Fatalf("first Refresh unexpected error: %v", err)
+			}
+
+			if tt.deactivate {
+				user.Active = false
+				if err = userRepository.Update(domain.ContextWithAllTenants(context.Background()), user); err != nil {
+					t.Fatalf("Update unexpected error: %v", err)
+				}
+			}
+
+			_, err = service.Refresh(context.Background(), tt.refreshToken(*tokens, rotated.RefreshToken))
+			assertStatus(t, err, tt.wantStatus)
+
+			_, err = service.VerifyUserSession(context.Background(), user.UserID, sessionID)
+			if revoked := err != nil && !tt.deactivate; revoked != tt.wantRevoked {
+				t.Errorf("session revoked = %v, want %v", revoked, tt.wantRevoked)
+			}
+		})
+	}
+}
+
+func TestAuthServiceLogout(t *testing.T) {
+	userRepository := memory.NewUserRepository()
+	sessionRepository := memory.NewSessionRepository()
+	service := newAuthTestService(userRepository, sessionRepository)
+	user := createTestUser(t, userRepository, "user@example.com", domain.OPERATOR)
+	tokens, sessionID := loginTestUser(t, service, user)
+
+	if err := service.Logout(domain.ContextWithSessionID(context.Background(), sessionID)); err != nil {
+		t.Fatalf("Logout unexpected error: %v", err)
+	}
+
+	_, err := service.VerifyUserSession(context.Background(), user.UserID, sessionID)
+	assertStatus(t, err, http.StatusUnauthorized)
+
+	_, err = service.Refresh(context.Background(), tokens.RefreshToken)
+	assertStatus(t, err, http.StatusUnauthorized)
+}
+
+func TestAuthServiceLogoutAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		callerRole domain.UserRole
+		self       bool
+		targetRole domain.UserRole
+		wantStatus int
+	}{
+		{name: "operator revokes its own sessions", callerRole: domain.OPERATOR, self: true, targetRole: domain.OPERATOR},
+		{name: "admin revokes an operator's sessions", callerRole: domain.ADMIN, targetRole: domain.OPERATOR},
+		{name: "operator can't revoke another operator's sessions", callerRole: domain.OPERATOR, targetRole: domain.OPERATOR, wantStatus: http.StatusForbidden},
+		{name: "admin can't revoke a platform admin's sessions", callerRole: domain.ADMIN, targetRole: domain.THAVANNA_ADMIN, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepository := memory.NewUserRepository()
+			sessionRepository := memory.NewSessionRepository()
+			service := newAuthTestService(userRepository, sessionRepository)
+			target := createTestUser(t, userRepository, "target@example.com", tt.targetRole)
+
+			session, _, err := domain.NewSession(target.UserID, time.Hour)
+			if err != nil {
+				t.Fatalf("NewSession unexpected error: %v", err)
+			}
+			if _, err = sessionRepository.Create(context.Background(), session); err != nil {
+				t.Fatalf("Create unexpected error: %v", err)
+			}
+
+			callerID := "caller"
+			if tt.self {
+				callerID = target.UserID
+			}
+
+			err = service.LogoutAll(callerContext(callerID, tt.callerRole), target.UserID)
+			assertStatus(t, err, tt.wantStatus)
+
+			saved, err := sessionRepository.GetByID(context.Background(), session.SessionID)
+			if err != nil {
+				t.Fatalf("GetByID unexpected error: %v", err)
+			}
+			if revoked := !saved.IsActive(); revoked != (tt.wantStatus == 0) {
+				t.Errorf("session revoked = %v, want %v", revoked, tt.wantStatus == 0)
+			}
+		})
+	}
+}
@@ -2,43 +2,200 @@ package application
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/icrxz/crm-api-core/internal/domain"
 )
 
 type commentService struct {
-	commentRepository    domain.CommentRepository
-	attachmentRepository domain.AttachmentRepository
-	attachmentBucket     domain.AttachmentBucket
+	ticketRepository          domain.TicketRepository
+	userRepository            domain.UserRepository
+	commentRepository         domain.CommentRepository
+	commentRevisionRepository domain.CommentRevisionRepository
+	attachmentRepository      domain.AttachmentRepository
+	attachmentBucket          domain.AttachmentBucket
+	attachmentValidator       AttachmentValidator
+	imageProcessor            AttachmentImageProcessor
+	userNotifier              domain.UserNotifier
+	notificationService       NotificationService
+	downloadURLTTL            time.Duration
 }
 
 type CommentService interface {
 	Create(ctx context.Context, comment domain.Comment) (string, error)
+	CreateWithFiles(ctx context.Context, comment domain.Comment, uploads []domain.AttachmentUpload) (string, error)
 	GetByID(ctx context.Context, commentID string) (*domain.Comment, error)
 	GetByTicketID(ctx context.Context, ticketID string) ([]domain.Comment, error)
+	Update(ctx context.Context, commentID string, update domain.CommentUpdate) (*domain.Comment, error)
+	Delete(ctx context.Context, commentID string) error
+	GetRevisions(ctx context.Context, commentID string) ([]domain.CommentRevision, error)
 }
 
 func NewCommentService(
+	ticketRepository domain.TicketRepository,
+	userRepository domain.UserRepository,
 	commentRepository domain.CommentRepository,
+	commentRevisionRepository domain.CommentRevisionRepository,
 	attachmentRepository domain.AttachmentRepository,
 	attachmentBucket domain.AttachmentBucket,
+	attachmentValidator AttachmentValidator,
+	imageProcessor AttachmentImageProcessor,
+	userNotifier domain.UserNotifier,
+	notificationService NotificationService,
+	downloadURLTTL time.Duration,
 ) CommentService {
 	return &commentService{
-		commentRepository:    commentRepository,
-		attachmentRepository: attachmentRepository,
-		attachmentBucket:     attachmentBucket,
+		ticketRepository:          ticketRepository,
+		userRepository:            userRepository,
+		commentRepository:         commentRepository,
+		commentRevisionRepository: commentRevisionRepository,
+		attachmentRepository:      attachmentRepository,
+		attachmentBucket:          attachmentBucket,
+		attachmentValidator:       attachmentValidator,
+		imageProcessor:            imageProcessor,
+		userNotifier:              userNotifier,
+		notificationService:       notificationService,
+		downloadURLTTL:            downloadURLTTL,
 	}
 }
 
+// Create saves a comment whose attachments were already stored in the bucket. Their keys must be
+// inside the ticket's folder, so a comment can't reference files of another ticket or tenant, and
+// the files are validated with the size the bucket reports.
 func (s *commentService) Create(ctx context.Context, comment domain.Comment) (string, error) {
+	crmTicket, err := s.ticketRepository.GetByID(ctx, comment.TicketID)
+	if err != nil {
+		return "", err
+	}
+	comment.TenantID = crmTicket.TenantID
+
+	if err = s.prepare(ctx, &comment); err != nil {
+		return "", err
+	}
+
+	if len(comment.Attachments) == 0 {
+		return s.save(ctx, comment)
+	}
+
+	policy, err := s.attachmentValidator.Policy(ctx, crmTicket.TenantID)
+	if err != nil {
+		return "", err
+	}
+
+	keyPrefix := domain.AttachmentKeyPrefix(crmTicket.TenantID, crmTicket.TicketID)
+	addedSize := 0
+	for idx, attachment := range comment.Attachments {
+		if !strings.HasPrefix(attachment.Key, keyPrefix) || strings.Contains(attachment.Key, "..") {
+			return "", domain.NewValidationError("attachment key doesn't belong to this ticket", map[string]any{"key": attachment.Key})
+		}
+
+		object, err := s.attachmentValidator.ValidateStoredFile(ctx, policy, attachment.Key, attachment.FileName)
+		if err != nil {
+			return "", err
+		}
+
+		comment.Attachments[idx].Size = object.Size
+		comment.Attachments[idx].ContentType = object.ContentType
+		comment.Attachments[idx].FileExtension = strings.ToLower(strings.TrimPrefix(filepath.Ext(attachment.FileName), "."))
+		addedSize += object.Size
+	}
+
+	if err = s.attachmentValidator.ValidateTicketSize(ctx, policy, crmTicket.TicketID, addedSize); err != nil {
+		return "", err
+	}
+
+	for idx := range comment.Attachments {
+		if err = s.imageProcessor.ProcessStored(ctx, &comment.Attachments[idx]); err != nil {
+			s.imageProcessor.DeleteThumbnails(ctx, comment.Attachments)
+			return "", err
+		}
+	}
+
+	commentID, err := s.save(ctx, comment)
+	if err != nil {
+		s.imageProcessor.DeleteThumbnails(ctx, comment.Attachments)
+	}
+
+	return commentID, err
+}
+
+// CreateWithFiles validates the uploaded files, stores them under the ticket's folder, along with the
+// thumbnails of images, and saves the comment with them as attachments. Size and checksum are computed
+// from the files, and the stored files are removed again when the comment can't be saved.
+func (s *commentService) CreateWithFiles(ctx context.Context, comment domain.Comment, uploads []domain.AttachmentUpload) (string, error) {
+	crmTicket, err := s.ticketRepository.GetByID(ctx, comment.TicketID)
+	if err != nil {
+		return "", err
+	}
+	comment.TenantID = crmTicket.TenantID
+
+	if err = s.prepare(ctx, &comment); err != nil {
+		return "", err
+	}
+
+	policy, err := s.attachmentValidator.Policy(ctx, crmTicket.TenantID)
+	if err != nil {
+		return "", err
+	}
+
+	addedSize := 0
+	for idx := range uploads {
+		size, err := s.validateUpload(ctx, policy, &uploads[idx])
+		if err != nil {
+			return "", err
+		}
+		addedSize += size
+	}
+
+	if err = s.attachmentValidator.ValidateTicketSize(ctx, policy, crmTicket.TicketID, addedSize); err != nil {
+		return "", err
+	}
+
+	comment.Attachments = make([]domain.Attachment, 0, len(uploads))
+	for _, upload := range uploads {
+		attachment, err := s.uploadAttachment(ctx, *crmTicket, comment.CreatedBy, upload)
+		if err != nil {
+			if attachment.Key != "" {
+				comment.Attachments = append(comment.Attachments, attachment)
+			}
+			s.deleteAttachmentFiles(ctx, comment.Attachments)
+			return "", err
+		}
+
+		comment.Attachments = append(comment.Attachments, attachment)
+	}
+
+	commentID, err := s.save(ctx, comment)
+	if err != nil {
+		s.deleteAttachmentFiles(ctx, comment.Attachments)
+	}
+
+	return commentID, err
+}
+
+func (s *commentService) save(ctx context.Context, comment domain.Comment) (string, error) {
 	commentID, err := s.commentRepository.Create(ctx, comment)
 	if err != nil {
 		return "", err
 	}
 
 	if comment.Attachments != nil && len(comment.Attachments) > 0 {
-		for idx := range comment.Attachments {
+		for idx, attachment := range comment.Attachments {
+			if attachment.Visibility == "" {
+				attachment.Visibility = comment.Visibility
+			}
+
 			comment.Attachments[idx].CommentID = commentID
+			comment.Attachments[idx].TenantID = comment.TenantID
+			comment.Attachments[idx].Visibility = attachment.Visibility.Narrowest(comment.Visibility)
 		}
 
 		err = s.attachmentRepository.SaveBatch(ctx, comment.Attachments)
@@ -47,9 +204,179 @@ func (s *commentService) Create(ctx context.Context, comment domain.Comment) (st
 		}
 	}
 
+	comment.CommentID = commentID
+	s.notifyMentions(ctx, comment, comment.Mentions)
+
 	return commentID, nil
 }
 
+// prepare checks the comment replies to a comment of the same ticket and resolves its mentions to the
+// IDs of the tenant's users.
+func (s *commentService) prepare(ctx context.Context, comment *domain.Comment) error {
+	// the author is the authenticated caller, as it's who may edit and delete the comment later
+	author := domain.UserIDFromContext(ctx)
+	comment.CreatedBy = author
+	comment.UpdatedBy = author
+	for idx := range comment.Attachments {
+		comment.Attachments[idx].CreatedBy = author
+	}
+
+	if comment.ParentCommentID != "" {
+		parent, err := s.commentRepository.GetByID(ctx, comment.ParentCommentID)
+		if err != nil {
+			var customErr *domain.CustomError
+			if errors.As(err, &customErr) && customErr.IsNotFound() {
+				return domain.NewValidationError("parent comment not found", map[string]any{"parent_comment_id": comment.ParentCommentID})
+			}
+			return err
+		}
+
+		if parent.TicketID != comment.TicketID || parent.IsDeleted() {
+			return domain.NewValidationError("parent comment doesn't belong to this ticket", map[string]any{"parent_comment_id": comment.ParentCommentID})
+		}
+	}
+
+	mentions, err := s.resolveMentions(ctx, comment.TenantID, comment.Content)
+	if err != nil {
+		return err
+	}
+	comment.Mentions = mentions
+
+	return nil
+}
+
+// resolveMentions returns the IDs of the active users of the tenant mentioned in the content. Mentions
+// of usernames that don't exist are left as plain text.
+func (s *commentService) resolveMentions(ctx context.Context, tenantID string, content string) ([]string, error) {
+	usernames := domain.MentionedUsernames(content)
+	if len(usernames) == 0 {
+		return []string{}, nil
+	}
+
+	active := true
+	users, err := s.userRepository.Search(ctx, domain.UserFilters{
+		TenantID: []string{tenantID},
+		Username: usernames,
+		Active:   &active,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mentions := make([]string, 0, len(users.Result))
+	for _, username := range usernames {
+		for _, user := range users.Result {
+			if user.Username == username {
+				mentions = append(mentions, user.UserID)
+			}
+		}
+	}
+
+	return mentions, nil
+}
+
+// notifyMentions tells the mentioned users about the comment, in their inbox and through the notifier,
+// except its author. The comment is already saved, so failures are only logged.
+func (s *commentService) notifyMentions(ctx context.Context, comment domain.Comment, userIDs []string) {
+	for _, userID := range userIDs {
+		if userID == comment.UpdatedBy {
+			continue
+		}
+		s.notificationService.NotifyMention(ctx, comment, userID)
+
+		user, err := s.userRepository.GetByID(ctx, userID)
+		if err != nil {
+			log.Printf("failed to get mentioned user %s: %v", userID, err)
+			continue
+		}
+
+		if err = s.userNotifier.NotifyMention(ctx, *user, comment); err != nil {
+			log.Printf("failed to notify user %s of mention in comment %s: %v", userID, comment.CommentID, err)
+		}
+	}
+}
+
+// validateUpload checks the file against the policy and sets the content type it's stored with.
+func (s *commentService) validateUpload(ctx context.Context, policy domain.AttachmentPolicy, upload *domain.AttachmentUpload) (int, error) {
+	size, err := upload.File.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = upload.File.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	contentType, err := s.attachmentValidator.ValidateFile(policy, upload.FileName, upload.ContentType, int(size))
+	if err != nil {
+		return 0, err
+	}
+
+	if err = s.attachmentValidator.ValidateContent(ctx, upload.FileName, upload.File); err != nil {
+		return 0, err
+	}
+
+	if _, err = upload.File.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	upload.ContentType = contentType
+
+	return int(size), nil
+}
+
+func (s *commentService) uploadAttachment(ctx context.Context, crmTicket domain.Ticket, author string, upload domain.AttachmentUpload) (domain.Attachment, error) {
+	checksum := sha256.New()
+	size, err := io.Copy(checksum, upload.File)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	if _, err = upload.File.Seek(0, io.SeekStart); err != nil {
+		return domain.Attachment{}, err
+	}
+
+	fileExtension := strings.ToLower(filepath.Ext(upload.FileName))
+	attachment, err := domain.NewAttachment(
+		filepath.Base(upload.FileName),
+		"",
+		strings.TrimPrefix(fileExtension, "."),
+		"",
+		author,
+		int(size),
+	)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	attachment.TenantID = crmTicket.TenantID
+	attachment.Key = domain.AttachmentKeyPrefix(crmTicket.TenantID, crmTicket.TicketID) + attachment.AttachmentID + fileExtension
+	attachment.Checksum = hex.EncodeToString(checksum.Sum(nil))
+	attachment.ContentType = upload.ContentType
+
+	if err = s.attachmentBucket.Upload(ctx, attachment.Key, upload.File, attachment.ContentType); err != nil {
+		return domain.Attachment{}, err
+	}
+
+	if _, err = upload.File.Seek(0, io.SeekStart); err != nil {
+		return attachment, err
+	}
+
+	if err = s.imageProcessor.Process(ctx, &attachment, upload.File); err != nil {
+		return attachment, err
+	}
+
+	return attachment, nil
+}
+
+func (s *commentService) deleteAttachmentFiles(ctx context.Context, attachments []domain.Attachment) {
+	for _, attachment := range attachments {
+		if err := s.attachmentBucket.Delete(ctx, attachment.Key); err != nil {
+			log.Printf("failed to delete attachment file %s: %v", attachment.Key, err)
+		}
+	}
+	s.imageProcessor.DeleteThumbnails(ctx, attachments)
+}
+
 func (s *commentService) GetByID(ctx context.Context, commentID string) (*domain.Comment, error) {
 	if commentID == "" {
 		return nil, domain.NewValidationError("commentID is required", nil)
@@ -60,11 +387,19 @@ func (s *commentService) GetByID(ctx context.Context, commentID string) (*domain
 		return nil, err
 	}
 
+	if comment.IsDeleted() {
+		return nil, domain.NewNotFoundError("no comment found with this id", map[string]any{"comment_id": commentID})
+	}
+
 	comment.Attachments, err = s.attachmentRepository.GetByCommentID(ctx, commentID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = signAttachmentURLs(ctx, s.attachmentBucket, comment.Attachments, s.downloadURLTTL); err != nil {
+		return nil, err
+	}
+
 	return comment, nil
 }
 
@@ -84,8 +419,142 @@ func (s *commentService) GetByTicketID(ctx context.Context, ticketID string) ([]
 			return nil, err
 		}
 
+		if err = signAttachmentURLs(ctx, s.attachmentBucket, attachments, s.downloadURLTTL); err != nil {
+			return nil, err
+		}
+
 		comments[idx].Attachments = attachments
 	}
 
 	return comments, nil
 }
+
+// Update edits the content or the visibility of the comment, keeping its current version as a revision.
+// Only the author or users allowed to moderate comments can edit it.
+func (s *commentService) Update(ctx context.Context, commentID string, update domain.CommentUpdate) (*domain.Comment, error) {
+	comment, err := s.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = canModifyComment(ctx, *comment); err != nil {
+		return nil, err
+	}
+
+	revision, err := domain.NewCommentRevision(*comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = comment.Edit(update, domain.UserIDFromContext(ctx)); err != nil {
+		return nil, err
+	}
+
+	previousMentions := comment.Mentions
+	if comment.Mentions, err = s.resolveMentions(ctx, comment.TenantID, comment.Content); err != nil {
+		return nil, err
+	}
+
+	if _, err = s.commentRevisionRepository.Create(ctx, revision); err != nil {
+		return nil, err
+	}
+
+	if err = s.commentRepository.Update(ctx, *comment); err != nil {
+		return nil, err
+	}
+
+	newMentions := make([]string, 0, len(comment.Mentions))
+	for _, userID := range comment.Mentions {
+		if !slices.Contains(previousMentions, userID) {
+			newMentions = append(newMentions, userID)
+		}
+	}
+	s.notifyMentions(ctx, *comment, newMentions)
+
+	return comment, nil
+}
+
+// Delete hides the comment from the ticket and its reports. The comment, its attachments and its
+// revisions are kept.
+func (s *commentService) Delete(ctx context.Context, commentID string) error {
+	if commentID == "" {
+		return domain.NewValidationError("commentID is required", nil)
+	}
+
+	comment, err := s.commentRepository.GetByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	if err = canModifyComment(ctx, *comment); err != nil {
+		return err
+	}
+
+	if err = comment.Delete(domain.UserIDFromContext(ctx)); err != nil {
+		return err
+	}
+
+	return s.commentRepository.Update(ctx, *comment)
+}
+
+// GetRevisions returns every version of the comment's content, from the first to the current one.
+// Revisions of deleted comments are only shown to who could have deleted them.
+func (s *commentService) GetRevisions(ctx context.Context, commentID string) ([]domain.CommentRevision, error) {
+	if commentID == "" {
+		return nil, domain.NewValidationError("commentID is required", nil)
+	}
+
+	comment, err := s.commentRepository.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.IsDeleted() && canModifyComment(ctx, *comment) != nil {
+		return nil, domain.NewNotFoundError("no comment found with this id", map[string]any{"comment_id": commentID})
+	}
+
+	revisions, err := s.commentRevisionRepository.GetByCommentID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(revisions, comment.CurrentRevision()), nil
+}
+
+func canModifyComment(ctx context.Context, comment domain.Comment) error {
+	userID := domain.UserIDFromContext(ctx)
+	role := domain.UserRoleFromContext(ctx)
+	if comment.CreatedBy != userID && !role.Can(domain.COMMENT_RESOURCE, domain.MODERATE_ACTION) {
+		return domain.NewForbiddenError("only the author or an admin can change this comment", map[string]any{"comment_id": comment.CommentID})
+	}
+
+	return nil
+}
+
+// signAttachmentURLs replaces the URL of the attachments stored in the bucket, and of their thumbnails,
+// with a short-lived presigned one. Attachments without a key keep the URL they were created with.
+func signAttachmentURLs(ctx context.Context, attachmentBucket domain.AttachmentBucket, attachments []domain.Attachment, expiresIn time.Duration) error {
+	for idx, attachment := range attachments {
+		if attachment.Key == "" {
+			continue
+		}
+
+		attachmentURL, err := attachmentBucket.PresignDownload(ctx, attachment.Key, expiresIn)
+		if err != nil {
+			return err
+		}
+		attachments[idx].AttachmentURL = attachmentURL
+
+		if attachment.ThumbnailKey == "" {
+			continue
+		}
+
+		thumbnailURL, err := attachmentBucket.PresignDownload(ctx, attachment.ThumbnailKey, expiresIn)
+		if err != nil {
+			return err
+		}
+		attachments[idx].ThumbnailURL = thumbnailURL
+	}
+
+	return nil
+}
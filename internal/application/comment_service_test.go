@@ -0,0 +1,292 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/repository/memory"
+)
+
+const commentTestAuthor = "author"
+
+func newCommentTestService(t *testing.T) (CommentService, domain.CommentRepository, string) {
+	t.Helper()
+
+	ticketRepository := memory.NewTicketRepository()
+	crmTicket := domain.Ticket{TicketID: "ticket", TenantID: userTestTenant, Status: domain.NEW}
+	if _, err := ticketRepository.Create(domain.ContextWithAllTenants(context.Background()), crmTicket); err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	commentRepository := memory.NewCommentRepository()
+	service := NewCommentService(
+		ticketRepository,
+		memory.NewUserRepository(),
+		commentRepository,
+		memory.NewCommentRevisionRepository(),
+		memory.NewAttachmentRepository(),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		time.Minute,
+	)
+
+	comment, err := domain.NewComment(crmTicket.TicketID, "first version", "someone-else", domain.CONTENT, nil)
+	if err != nil {
+		t.Fatalf("NewComment unexpected error: %v", err)
+	}
+
+	commentID, err := service.Create(callerContext(commentTestAuthor, domain.OPERATOR), comment)
+	if err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	return service, commentRepository, commentID
+}
+
+func TestCommentServiceCreateTakesTheAuthorFromTheCaller(t *testing.T) {
+	_, commentRepository, commentID := newCommentTestService(t)
+
+	comment, err := commentRepository.GetByID(domain.ContextWithAllTenants(context.Background()), commentID)
+	if err != nil {
+		t.Fatalf("GetByID unexpected error: %v", err)
+	}
+
+	if comment.CreatedBy != commentTestAuthor || comment.UpdatedBy != commentTestAuthor {
+		t.Errorf("comment created by %q and updated by %q, want %q", comment.CreatedBy, comment.UpdatedBy, commentTestAuthor)
+	}
+}
+
+func TestCommentServiceModify(t *testing.T) {
+	content := "second version"
+
+	tests := []struct {
+		name       string
+		callerID   string
+		callerRole domain.UserRole
+		wantStatus int
+	}{
+		{name: "author", callerID: commentTestAuthor, callerRole: domain.OPERATOR},
+		{name: "another operator", callerID: "other", callerRole: domain.OPERATOR, wantStatus: http.StatusForbidden},
+		{name: "admin moderating", callerID: "admin", callerRole: domain.ADMIN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" edits", func(t *testing.T) {
+			service, _, commentID := newCommentTestService(t)
+
+			_, err := service.Update(callerContext(tt.callerID, tt.callerRole), commentID, domain.CommentUpdate{Content: &content})
+			assertStatus(t, err, tt.wantStatus)
+
+			revisions, err := service.GetRevisions(callerContext(commentTestAuthor, domain.OPERATOR), commentID)
+			if err != nil {
+				t.Fatalf("GetRevisions unexpected error: %v", err)
+			}
+
+			wantRevisions := 2
+			if tt.wantStatus != 0 {
+				wantRevisions = 1
+			}
+			if len(revisions) != wantRevisions {
+				t.Errorf("got %d revisions, want %d", len(revisions), wantRevisions)
+			}
+		})
+
+		t.Run(tt.name+" deletes", func(t *testing.T) {
+			service, _, commentID := newCommentTestService(t)
+
+			err := service.Delete(callerContext(tt.callerID, tt.callerRole), commentID)
+			assertStatus(t, err, tt.wantStatus)
+
+			_, err = service.GetByID(callerContext(commentTestAuthor, domain.OPERATOR), commentID)
+			if deleted := err != nil; deleted != (tt.wantStatus == 0) {
+				t.Errorf("comment deleted = %v, want %v", deleted, tt.wantStatus == 0)
+			}
+		})
+	}
+}
+
+func newCommentUploadTestService(t *testing.T) (CommentService, attachmentTestFixture) {
+	t.Helper()
+
+	fixture := newAttachmentTestFixture(t, domain.AttachmentPolicy{})
+	ticketRepository := memory.NewTicketRepository()
+	crmTicket := domain.Ticket{TicketID: "ticket", TenantID: userTestTenant, Status: domain.NEW}
+	if _, err := ticketRepository.Create(domain.ContextWithAllTenants(context.Background()), crmTicket); err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	service := NewCommentService(
+		ticketRepository,
+		memory.NewUserRepository(),
+		fixture.commentRepository,
+		memory.NewCommentRevisionRepository(),
+		fixture.attachmentRepository,
+		fixture.attachmentBucket,
+		fixture.validator,
+		NewAttachmentImageProcessor(fixture.attachmentBucket, 16),
+		nil,
+		nil,
+		time.Minute,
+	)
+
+	return service, fixture
+}
+
+func countStoredFiles(t *testing.T, folder string) int {
+	t.Helper()
+
+	count := 0
+	err := filepath.WalkDir(folder, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			count++
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WalkDir unexpected error: %v", err)
+	}
+
+	return count
+}
+
+func TestCommentServiceCreateWithFiles(t *testing.T) {
+	pngFile := pngTestFile(t)
+	checksum := sha256.Sum256(pngFile)
+
+	tests := []struct {
+		name       string
+		files      map[string][]byte
+		wantStatus int
+		wantFiles  int
+	}{
+		// the image is stored with its thumbnail
+		{name: "image", files: map[string][]byte{"photo.png": pngFile}, wantFiles: 2},
+		{name: "invalid file among valid ones", files: map[string][]byte{"photo.png": pngFile, "notes.pdf": []byte("not a pdf")}, wantStatus: http.StatusBadRequest},
+		{name: "file type not accepted", files: map[string][]byte{"script.sh": []byte("#!/bin/sh")}, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, fixture := newCommentUploadTestService(t)
+			ctx := callerContext(commentTestAuthor, domain.OPERATOR)
+
+			uploads := make([]domain.AttachmentUpload, 0, len(tt.files))
+			for fileName, content := range tt.files {
+				uploads = append(uploads, domain.AttachmentUpload{FileName: fileName, File: bytes.NewReader(content)})
+			}
+
+			comment, err := domain.NewComment("ticket", "with files", "", domain.CONTENT, nil)
+			if err != nil {
+				t.Fatalf("NewComment unexpected error: %v", err)
+			}
+
+			commentID, err := service.CreateWithFiles(ctx, comment, uploads)
+			assertStatus(t, err, tt.wantStatus)
+
+			if stored := countStoredFiles(t, fixture.bucketFolder); stored != tt.wantFiles {
+				t.Errorf("got %d stored files, want %d", stored, tt.wantFiles)
+			}
+			if tt.wantStatus != 0 {
+				return
+			}
+
+			attachments, err := fixture.attachmentRepository.GetByCommentID(ctx, commentID)
+			if err != nil {
+				t.Fatalf("GetByCommentID unexpected error: %v", err)
+			}
+			if len(attachments) != 1 {
+				t.Fatalf("got %d attachments, want 1", len(attachments))
+			}
+
+			attachment := attachments[0]
+			if !strings.HasPrefix(attachment.Key, domain.AttachmentKeyPrefix(userTestTenant, "ticket")) {
+				t.Errorf("key %q is outside of the ticket's folder", attachment.Key)
+			}
+			if attachment.Size != len(pngFile) || attachment.Checksum != hex.EncodeToString(checksum[:]) || attachment.ContentType != "image/png" {
+				t.Errorf("attachment = %+v, want %d bytes of image/png", attachment, len(pngFile))
+			}
+			if attachment.CreatedBy != commentTestAuthor || attachment.ThumbnailKey == "" {
+				t.Errorf("attachment created by %q with thumbnail %q, want %q with a thumbnail", attachment.CreatedBy, attachment.ThumbnailKey, commentTestAuthor)
+			}
+		})
+	}
+}
+
+func TestCommentServiceCreateWithStoredFiles(t *testing.T) {
+	pngFile := pngTestFile(t)
+	ticketFolder := domain.AttachmentKeyPrefix(userTestTenant, "ticket")
+
+	tests := []struct {
+		name       string
+		storedKey  string
+		key        string
+		wantStatus int
+	}{
+		{name: "file in the ticket's folder", storedKey: ticketFolder + "photo.png", key: ticketFolder + "photo.png"},
+		{
+			name:       "file of another ticket",
+			storedKey:  domain.AttachmentKeyPrefix(userTestTenant, "other") + "photo.png",
+			key:        domain.AttachmentKeyPrefix(userTestTenant, "other") + "photo.png",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "file of another tenant",
+			storedKey:  domain.AttachmentKeyPrefix("other", "ticket") + "photo.png",
+			key:        domain.AttachmentKeyPrefix("other", "ticket") + "photo.png",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "path leaving the ticket's folder",
+			storedKey:  domain.AttachmentKeyPrefix("other", "ticket") + "photo.png",
+			key:        ticketFolder + "../../../other/tickets/ticket/photo.png",
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "file never uploaded", storedKey: ticketFolder + "photo.png", key: ticketFolder + "missing.png", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, fixture := newCommentUploadTestService(t)
+			ctx := callerContext(commentTestAuthor, domain.OPERATOR)
+			if err := fixture.attachmentBucket.Upload(ctx, tt.storedKey, bytes.NewReader(pngFile), "image/png"); err != nil {
+				t.Fatalf("Upload unexpected error: %v", err)
+			}
+
+			// the declared size is ignored in favour of the stored one
+			attachment, err := domain.NewAttachment("photo.png", "", "png", tt.key, "", 1)
+			if err != nil {
+				t.Fatalf("NewAttachment unexpected error: %v", err)
+			}
+			comment, err := domain.NewComment("ticket", "with files", "", domain.CONTENT, []domain.Attachment{attachment})
+			if err != nil {
+				t.Fatalf("NewComment unexpected error: %v", err)
+			}
+
+			commentID, err := service.Create(ctx, comment)
+			assertStatus(t, err, tt.wantStatus)
+			if tt.wantStatus != 0 {
+				return
+			}
+
+			attachments, err := fixture.attachmentRepository.GetByCommentID(ctx, commentID)
+			if err != nil {
+				t.Fatalf("GetByCommentID unexpected error: %v", err)
+			}
+			if len(attachments) != 1 || attachments[0].Size != len(pngFile) {
+				t.Errorf("attachments = %+v, want one of %d bytes", attachments, len(pngFile))
+			}
+		})
+	}
+}
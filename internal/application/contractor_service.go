@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"slices"
 
 	"github.com/icrxz/crm-api-core/internal/domain"
 )
@@ -53,6 +54,12 @@ func (s *tenantService) Update(ctx context.Context, tenantID string, updateTenan
 		return domain.NewValidationError("tenantID cannot be empty", nil)
 	}
 
+	if updateTenant.AttachmentPolicy != nil {
+		if err := validateAttachmentPolicy(*updateTenant.AttachmentPolicy); err != nil {
+			return err
+		}
+	}
+
 	tenant, err := s.GetByID(ctx, tenantID)
 	if err != nil {
 		return err
@@ -62,3 +69,22 @@ func (s *tenantService) Update(ctx context.Context, tenantID string, updateTenan
 
 	return s.tenantRepository.Update(ctx, *tenant)
 }
+
+// validateAttachmentPolicy only lets tenants narrow the content types accepted by the API.
+func validateAttachmentPolicy(policy domain.AttachmentPolicy) error {
+	if policy.MaxFileSize < 0 || policy.MaxTicketSize < 0 {
+		return domain.NewValidationError("attachment size limits cannot be negative", nil)
+	}
+
+	contentTypes := attachmentContentTypes()
+	for _, contentType := range policy.AllowedContentTypes {
+		if !slices.Contains(contentTypes, contentType) {
+			return domain.NewValidationError("unsupported attachment content type", map[string]any{
+				"content_type":  contentType,
+				"allowed_types": contentTypes,
+			})
+		}
+	}
+
+	return nil
+}
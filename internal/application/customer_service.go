@@ -25,6 +25,12 @@ func NewCustomerService(customerRepository domain.CustomerRepository) CustomerSe
 }
 
 func (s *customerService) Create(ctx context.Context, customer domain.Customer) (string, error) {
+	tenantID, err := domain.ResolveTenantID(ctx, customer.TenantID)
+	if err != nil {
+		return "", err
+	}
+	customer.TenantID = tenantID
+
 	return s.customerRepository.Create(ctx, customer)
 }
 
@@ -0,0 +1,184 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type lockoutService struct {
+	userRepository          domain.UserRepository
+	loginAttemptRepository  domain.LoginAttemptRepository
+	userLockEventRepository domain.UserLockEventRepository
+	accountPolicy           domain.LockoutPolicy
+	ipPolicy                domain.LockoutPolicy
+}
+
+type LockoutService interface {
+	CheckLogin(ctx context.Context, email, ipAddress string) error
+	RegisterFailure(ctx context.Context, email, ipAddress string, user *domain.User) error
+	RegisterSuccess(ctx context.Context, email, ipAddress string) error
+	Unlock(ctx context.Context, userID, author string) error
+	GetLockEvents(ctx context.Context, userID string) ([]domain.UserLockEvent, error)
+}
+
+func NewLockoutService(
+	userRepository domain.UserRepository,
+	loginAttemptRepository domain.LoginAttemptRepository,
+	userLockEventRepository domain.UserLockEventRepository,
+	accountPolicy domain.LockoutPolicy,
+	ipPolicy domain.LockoutPolicy,
+) LockoutService {
+	return &lockoutService{
+		userRepository:          userRepository,
+		loginAttemptRepository:  loginAttemptRepository,
+		userLockEventRepository: userLockEventRepository,
+		accountPolicy:           accountPolicy,
+		ipPolicy:                ipPolicy,
+	}
+}
+
+func (s *lockoutService) CheckLogin(ctx context.Context, email, ipAddress string) error {
+	for _, key := range s.attemptKeys(email, ipAddress) {
+		attempt, err := s.getAttempt(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if attempt.IsLocked() {
+			retryAfter := time.Until(*attempt.LockedUntil).Seconds()
+			return domain.NewTooManyRequestsError("too many failed login attempts, try again later", map[string]any{
+				"retry_after": int(math.Ceil(retryAfter)),
+			})
+		}
+	}
+
+	return nil
+}
+
+// RegisterFailure counts the failure for both the account and the IP. The user is nil when
+// the email doesn't belong to any account, which is still counted so both cases look the same.
+func (s *lockoutService) RegisterFailure(ctx context.Context, email, ipAddress string, user *domain.User) error {
+	lockedUntil, err := s.registerFailure(ctx, domain.AccountAttemptKey(normalizeEmail(email)), s.accountPolicy)
+	if err != nil {
+		return err
+	}
+
+	if lockedUntil != nil && user != nil {
+		lockEvent, err := domain.NewUserLockEvent(*user, domain.USER_LOCKED, "too many failed login attempts", ipAddress, lockedUntil, "")
+		if err != nil {
+			return err
+		}
+
+		if _, err = s.userLockEventRepository.Create(ctx, lockEvent); err != nil {
+			return err
+		}
+	}
+
+	if ipAddress == "" {
+		return nil
+	}
+
+	_, err = s.registerFailure(ctx, domain.IPAttemptKey(ipAddress), s.ipPolicy)
+	return err
+}
+
+func (s *lockoutService) RegisterSuccess(ctx context.Context, email, ipAddress string) error {
+	if err := s.loginAttemptRepository.Delete(ctx, domain.AccountAttemptKey(normalizeEmail(email))); err != nil {
+		return err
+	}
+
+	if ipAddress == "" {
+		return nil
+	}
+
+	// a shared IP keeps its lock history, only the pending failures are forgiven
+	return s.loginAttemptRepository.ResetFailures(ctx, domain.IPAttemptKey(ipAddress))
+}
+
+func (s *lockoutService) Unlock(ctx context.Context, userID, author string) error {
+	if userID == "" {
+		return domain.NewValidationError("userID cannot be empty", nil)
+	}
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.loginAttemptRepository.Delete(ctx, domain.AccountAttemptKey(normalizeEmail(user.Email))); err != nil {
+		return err
+	}
+
+	unlockEvent, err := domain.NewUserLockEvent(*user, domain.USER_UNLOCKED, "unlocked by an administrator", "", nil, author)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.userLockEventRepository.Create(ctx, unlockEvent)
+	return err
+}
+
+func (s *lockoutService) GetLockEvents(ctx context.Context, userID string) ([]domain.UserLockEvent, error) {
+	if userID == "" {
+		return nil, domain.NewValidationError("userID cannot be empty", nil)
+	}
+
+	if _, err := s.userRepository.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.userLockEventRepository.GetByUserID(ctx, userID)
+}
+
+func (s *lockoutService) attemptKeys(email, ipAddress string) []string {
+	keys := []string{domain.AccountAttemptKey(normalizeEmail(email))}
+	if ipAddress != "" {
+		keys = append(keys, domain.IPAttemptKey(ipAddress))
+	}
+	return keys
+}
+
+// registerFailure counts the failure and locks the key once it reaches the policy's maximum, returning
+// when the lock ends. Each lock doubles the previous lock time until the policy's maximum is reached.
+func (s *lockoutService) registerFailure(ctx context.Context, key string, policy domain.LockoutPolicy) (*time.Time, error) {
+	now := time.Now().UTC()
+	attempt, err := s.loginAttemptRepository.IncrementFailures(ctx, key, now, policy.AttemptExpiry(now))
+	if err != nil {
+		return nil, err
+	}
+
+	if attempt.Failures < policy.MaxAttempts {
+		return nil, nil
+	}
+
+	lockedUntil := now.Add(policy.LockTime(attempt.LockCount))
+	locked, err := s.loginAttemptRepository.Lock(ctx, key, policy.MaxAttempts, lockedUntil)
+	if err != nil || !locked {
+		return nil, err
+	}
+
+	return &lockedUntil, nil
+}
+
+func (s *lockoutService) getAttempt(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	attempt, err := s.loginAttemptRepository.Get(ctx, key)
+	if err != nil {
+		var customErr *domain.CustomError
+		if errors.As(err, &customErr) && customErr.IsNotFound() {
+			newAttempt := domain.NewLoginAttempt(key)
+			return &newAttempt, nil
+		}
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,212 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/repository/memory"
+)
+
+const (
+	lockoutTestEmail = "user@example.com"
+	lockoutTestIP    = "10.0.0.1"
+)
+
+type lockoutStep struct {
+	email   string
+	ip      string
+	success bool
+}
+
+func failures(count int, email, ip string) []lockoutStep {
+	steps := make([]lockoutStep, 0, count)
+	for i := 0; i < count; i++ {
+		steps = append(steps, lockoutStep{email: email, ip: ip})
+	}
+	return steps
+}
+
+func newLockoutTestService(t *testing.T) (LockoutService, domain.UserLockEventRepository, domain.User) {
+	t.Helper()
+
+	userRepository := memory.NewUserRepository()
+	user, err := domain.NewUser("tenant", "Test", "User", lockoutTestEmail, "password", "", "user", domain.OPERATOR, 0)
+	if err != nil {
+		t.Fatalf("NewUser unexpected error: %v", err)
+	}
+	if _, err = userRepository.Create(context.Background(), user); err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	lockEventRepository := memory.NewUserLockEventRepository()
+	service := NewLockoutService(
+		userRepository,
+		memory.NewLoginAttemptRepository(),
+		lockEventRepository,
+		domain.LockoutPolicy{MaxAttempts: 3, BaseLockTime: time.Minute, MaxLockTime: 4 * time.Minute},
+		domain.LockoutPolicy{MaxAttempts: 5, BaseLockTime: time.Minute, MaxLockTime: 4 * time.Minute},
+	)
+
+	return service, lockEventRepository, user
+}
+
+func TestLockoutService(t *testing.T) {
+	tests := []struct {
+		name           string
+		steps          []lockoutStep
+		checkEmail     string
+		checkIP        string
+		wantLocked     bool
+		wantLockEvents []time.Duration
+	}{
+		{
+			name:       "no failures",
+			checkEmail: lockoutTestEmail,
+			checkIP:    lockoutTestIP,
+		},
+		{
+			name:       "failures below the account limit",
+			steps:      failures(2, lockoutTestEmail, lockoutTestIP),
+			checkEmail: lockoutTestEmail,
+			checkIP:    lockoutTestIP,
+		},
+		{
+			name:           "reaching the account limit locks the account",
+			steps:          failures(3, lockoutTestEmail, lockoutTestIP),
+			checkEmail:     lockoutTestEmail,
+			checkIP:        "10.0.0.2",
+			wantLocked:     true,
+			wantLockEvents: []time.Duration{time.Minute},
+		},
+		{
+			name:           "emails are compared case-insensitively",
+			steps:          failures(3, "  USER@example.com", lockoutTestIP),
+			checkEmail:     lockoutTestEmail,
+			checkIP:        "10.0.0.2",
+			wantLocked:     true,
+			wantLockEvents: []time.Duration{time.Minute},
+		},
+		{
+			name:           "every new lock doubles the lock time",
+			steps:          failures(6, lockoutTestEmail, ""),
+			checkEmail:     lockoutTestEmail,
+			wantLocked:     true,
+			wantLockEvents: []time.Duration{time.Minute, 2 * time.Minute},
+		},
+		{
+			name:       "unknown emails are locked too",
+			steps:      failures(3, "nobody@example.com", ""),
+			checkEmail: "nobody@example.com",
+			wantLocked: true,
+		},
+		{
+			name:       "a success forgives the account failures",
+			steps:      append(append(failures(2, lockoutTestEmail, lockoutTestIP), lockoutStep{email: lockoutTestEmail, ip: lockoutTestIP, success: true}), failures(2, lockoutTestEmail, lockoutTestIP)...),
+			checkEmail: lockoutTestEmail,
+			checkIP:    lockoutTestIP,
+		},
+		{
+			name: "reaching the IP limit locks every login from the IP",
+			steps: []lockoutStep{
+				{email: "a@example.com", ip: lockoutTestIP},
+				{email: "b@example.com", ip: lockoutTestIP},
+				{email: "c@example.com", ip: lockoutTestIP},
+				{email: "d@example.com", ip: lockoutTestIP},
+				{email: "e@example.com", ip: lockoutTestIP},
+			},
+			checkEmail: lockoutTestEmail,
+			checkIP:    lockoutTestIP,
+			wantLocked: true,
+		},
+		{
+			name: "the IP limit doesn't lock other IPs",
+			steps: []lockoutStep{
+				{email: "a@example.com", ip: lockoutTestIP},
+				{email: "b@example.com", ip: lockoutTestIP},
+				{email: "c@example.com", ip: lockoutTestIP},
+				{email: "d@example.com", ip: lockoutTestIP},
+				{email: "e@example.com", ip: lockoutTestIP},
+			},
+			checkEmail: lockoutTestEmail,
+			checkIP:    "10.0.0.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := domain.ContextWithAllTenants(context.Background())
+			service, lockEventRepository, user := newLockoutTestService(t)
+
+			for _, step := range tt.steps {
+				var err error
+				if step.success {
+					err = service.RegisterSuccess(ctx, step.email, step.ip)
+				} else {
+					var stepUser *domain.User
+					if normalizeEmail(step.email) == user.Email {
+						stepUser = &user
+					}
+					err = service.RegisterFailure(ctx, step.email, step.ip, stepUser)
+				}
+				if err != nil {
+					t.Fatalf("step %+v unexpected error: %v", step, err)
+				}
+			}
+
+			err := service.CheckLogin(ctx, tt.checkEmail, tt.checkIP)
+			if tt.wantLocked {
+				var customErr *domain.CustomError
+				if !errors.As(err, &customErr) || customErr.StatusCode() != http.StatusTooManyRequests {
+					t.Fatalf("CheckLogin error = %v, want too many requests", err)
+				}
+			} else if err != nil {
+				t.Fatalf("CheckLogin unexpected error: %v", err)
+			}
+
+			lockEvents, err := lockEventRepository.GetByUserID(ctx, user.UserID)
+			if err != nil {
+				t.Fatalf("GetByUserID unexpected error: %v", err)
+			}
+			if len(lockEvents) != len(tt.wantLockEvents) {
+				t.Fatalf("got %d lock events, want %d", len(lockEvents), len(tt.wantLockEvents))
+			}
+			for i, lockEvent := range lockEvents {
+				lockTime := lockEvent.LockedUntil.Sub(lockEvent.CreatedAt).Round(time.Second)
+				if lockEvent.Type != domain.USER_LOCKED || lockTime != tt.wantLockEvents[i] {
+					t.Errorf("lock event %d = %s for %s, want %s for %s", i, lockEvent.Type, lockTime, domain.USER_LOCKED, tt.wantLockEvents[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLockoutServiceUnlock(t *testing.T) {
+	ctx := domain.ContextWithAllTenants(context.Background())
+	service, lockEventRepository, user := newLockoutTestService(t)
+
+	for i := 0; i < 3; i++ {
+		if err := service.RegisterFailure(ctx, lockoutTestEmail, "", &user); err != nil {
+			t.Fatalf("RegisterFailure unexpected error: %v", err)
+		}
+	}
+
+	if err := service.Unlock(ctx, user.UserID, "admin"); err != nil {
+		t.Fatalf("Unlock unexpected error: %v", err)
+	}
+
+	if err := service.CheckLogin(ctx, lockoutTestEmail, ""); err != nil {
+		t.Errorf("CheckLogin after unlock unexpected error: %v", err)
+	}
+
+	lockEvents, err := lockEventRepository.GetByUserID(ctx, user.UserID)
+	if err != nil {
+		t.Fatalf("GetByUserID unexpected error: %v", err)
+	}
+	if len(lockEvents) != 2 || lockEvents[1].Type != domain.USER_UNLOCKED || lockEvents[1].CreatedBy != "admin" {
+		t.Errorf("lock events = %+v, want a lock followed by an unlock by admin", lockEvents)
+	}
+}
@@ -0,0 +1,218 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+// slaCheckPageSize is how many overdue tickets are loaded at a time by the SLA check.
+const slaCheckPageSize = 100
+
+type notificationService struct {
+	notificationRepository domain.NotificationRepository
+	ticketRepository       domain.TicketRepository
+	slaCheckInterval       time.Duration
+}
+
+type NotificationService interface {
+	NotifyTicketChange(ctx context.Context, before, after domain.Ticket)
+	NotifyMention(ctx context.Context, comment domain.Comment, userID string)
+	GetByUserID(ctx context.Context, filters domain.NotificationFilters) (domain.PagingResult[domain.Notification], error)
+	MarkRead(ctx context.Context, userID string, notificationIDs []string) (int, error)
+	CheckSLABreaches(ctx context.Context) error
+	Start(ctx context.Context)
+}
+
+func NewNotificationService(
+	notificationRepository domain.NotificationRepository,
+	ticketRepository domain.TicketRepository,
+	slaCheckInterval time.Duration,
+) NotificationService {
+	return &notificationService{
+		notificationRepository: notificationRepository,
+		ticketRepository:       ticketRepository,
+		slaCheckInterval:       slaCheckInterval,
+	}
+}
+
+// NotifyTicketChange tells the ticket's owner it was assigned to them, that a lead was set on it or
+// that its status changed, unless they made the change themselves. The ticket is already saved, so
+// failures are only logged.
+func (s *notificationService) NotifyTicketChange(ctx context.Context, before, after domain.Ticket) {
+	if after.OwnerID == "" {
+		return
+	}
+
+	author := domain.UserIDFromContext(ctx)
+	if author == "" {
+		author = after.UpdatedBy
+	}
+	if after.OwnerID == author {
+		return
+	}
+
+	reference := ticketReference(after)
+	if after.OwnerID != before.OwnerID {
+		s.notify(ctx, after, domain.TICKET_ASSIGNED_NOTIFICATION, fmt.Sprintf("Ticket %s was assigned to you", reference), author)
+	} else if after.Status != before.Status {
+		s.notify(ctx, after, domain.STATUS_CHANGED_NOTIFICATION, fmt.Sprintf("Ticket %s changed from %s to %s", reference, before.Status, after.Status), author)
+	}
+
+	if after.LeadID != "" && after.LeadID != before.LeadID {
+		s.notify(ctx, after, domain.LEAD_ASSIGNED_NOTIFICATION, fmt.Sprintf("A lead was set on ticket %s", reference), author)
+	}
+}
+
+// NotifyMention tells the user they were mentioned in the comment. Failures are only logged.
+func (s *notificationService) NotifyMention(ctx context.Context, comment domain.Comment, userID string) {
+	notification, err := domain.NewNotification(
+		comment.TenantID,
+		userID,
+		domain.MENTION_NOTIFICATION,
+		comment.TicketID,
+		"You were mentioned in a comment",
+		comment.UpdatedBy,
+	)
+	if err != nil {
+		log.Printf("failed to create mention notification for user %s: %v", userID, err)
+		return
+	}
+	notification.CommentID = comment.CommentID
+
+	if _, err = s.notificationRepository.Create(ctx, notification); err != nil {
+		log.Printf("failed to save mention notification for user %s: %v", userID, err)
+	}
+}
+
+func (s *notificationService) GetByUserID(ctx context.Context, filters domain.NotificationFilters) (domain.PagingResult[domain.Notification], error) {
+	if filters.UserID == "" {
+		return domain.PagingResult[domain.Notification]{}, domain.NewValidationError("user_id is required", nil)
+	}
+
+	return s.notificationRepository.Search(ctx, filters)
+}
+
+// MarkRead marks the user's notifications as read and returns how many were unread. Without IDs, every
+// notification of the user is marked.
+func (s *notificationService) MarkRead(ctx context.Context, userID string, notificationIDs []string) (int, error) {
+	if userID == "" {
+		return 0, domain.NewValidationError("user_id is required", nil)
+	}
+
+	return s.notificationRepository.MarkRead(ctx, userID, notificationIDs, time.Now().UTC())
+}
+
+// CheckSLABreaches notifies the owners of open tickets past their due date. Each owner is notified once
+// per ticket, so tickets that stay overdue aren't reported again on every check.
+func (s *notificationService) CheckSLABreaches(ctx context.Context) error {
+	now := time.Now().UTC()
+	statuses := make([]string, 0)
+	for _, status := range domain.OpenTicketStatuses() {
+		statuses = append(statuses, string(status))
+	}
+
+	for offset := 0; ; offset += slaCheckPageSize {
+		overdueTickets, err := s.ticketRepository.Search(ctx, domain.TicketFilters{
+			Status:    statuses,
+			DueBefore: &now,
+			PagingFilter: domain.PagingFilter{
+				Limit:         slaCheckPageSize,
+				Offset:        offset,
+				SortBy:        "created_at",
+				SortDirection: domain.ASC,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		// one ticket failing doesn't keep the owners of the others from being notified
+		for _, crmTicket := range overdueTickets.Result {
+			if err = s.notifySLABreach(ctx, crmTicket, now); err != nil {
+				log.Printf("failed to check SLA breach of ticket %s: %v", crmTicket.TicketID, err)
+			}
+		}
+
+		if offset+slaCheckPageSize >= overdueTickets.Paging.Total {
+			return nil
+		}
+	}
+}
+
+// Start checks for SLA breaches every slaCheckInterval until the context is done. A zero interval
+// disables the check.
+func (s *notificationService) Start(ctx context.Context) {
+	if s.slaCheckInterval <= 0 {
+		return
+	}
+
+	// the check covers the tickets of every tenant
+	ctx = domain.ContextWithAllTenants(ctx)
+
+	go func() {
+		ticker := time.NewTicker(s.slaCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.CheckSLABreaches(ctx); err != nil {
+				log.Printf("failed to check SLA breaches: %v", err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *notificationService) notifySLABreach(ctx context.Context, crmTicket domain.Ticket, now time.Time) error {
+	if crmTicket.OwnerID == "" || !crmTicket.IsOverdue(now) {
+		return nil
+	}
+
+	notified, err := s.notificationRepository.Search(ctx, domain.NotificationFilters{
+		UserID:       crmTicket.OwnerID,
+		TicketID:     []string{crmTicket.TicketID},
+		Type:         []string{string(domain.SLA_BREACHED_NOTIFICATION)},
+		PagingFilter: domain.PagingFilter{Limit: 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	if notified.Paging.Total > 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Ticket %s is past its due date of %s", ticketReference(crmTicket), crmTicket.DueDate.UTC().Format(time.DateOnly))
+	s.notify(ctx, crmTicket, domain.SLA_BREACHED_NOTIFICATION, message, "")
+
+	return nil
+}
+
+func (s *notificationService) notify(ctx context.Context, crmTicket domain.Ticket, notificationType domain.NotificationType, message, author string) {
+	notification, err := domain.NewNotification(crmTicket.TenantID, crmTicket.OwnerID, notificationType, crmTicket.TicketID, message, author)
+	if err != nil {
+		log.Printf("failed to create %s notification for ticket %s: %v", notificationType, crmTicket.TicketID, err)
+		return
+	}
+
+	if _, err = s.notificationRepository.Create(ctx, notification); err != nil {
+		log.Printf("failed to save %s notification for ticket %s: %v", notificationType, crmTicket.TicketID, err)
+	}
+}
+
+// ticketReference names the ticket by the reference its tenant knows it by, when it has one.
+func ticketReference(crmTicket domain.Ticket) string {
+	if crmTicket.ExternalReference != "" {
+		return crmTicket.ExternalReference
+	}
+
+	return crmTicket.TicketID
+}
@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type passwordService struct {
+	userRepository          domain.UserRepository
+	passwordResetRepository domain.PasswordResetRepository
+	sessionRepository       domain.SessionRepository
+	userNotifier            domain.UserNotifier
+	resetTokenTTL           time.Duration
+	resetLinkURL            string
+}
+
+type PasswordService interface {
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, resetToken, newPassword string) error
+	ChangePassword(ctx context.Context, userID, oldPassword, newPassword string) error
+}
+
+func NewPasswordService(
+	userRepository domain.UserRepository,
+	passwordResetRepository domain.PasswordResetRepository,
+	sessionRepository domain.SessionRepository,
+	userNotifier domain.UserNotifier,
+	resetTokenTTL time.Duration,
+	resetLinkURL string,
+) PasswordService {
+	return &passwordService{
+		userRepository:          userRepository,
+		passwordResetRepository: passwordResetRepository,
+		sessionRepository:       sessionRepository,
+		userNotifier:            userNotifier,
+		resetTokenTTL:           resetTokenTTL,
+		resetLinkURL:            resetLinkURL,
+	}
+}
+
+// ForgotPassword never tells the caller whether the email belongs to an account.
+func (s *passwordService) ForgotPassword(ctx context.Context, email string) error {
+	// the caller isn't authenticated, so the user is looked up across tenants
+	ctx = domain.ContextWithAllTenants(ctx)
+
+	if email == "" {
+		return domain.NewValidationError("email is required", nil)
+	}
+
+	users, err := s.userRepository.Search(ctx, domain.UserFilters{Email: []string{email}})
+	if err != nil {
+		return err
+	}
+
+	if len(users.Result) == 0 || !users.Result[0].Active {
+		return nil
+	}
+	user := users.Result[0]
+
+	resetToken, token, err := domain.NewPasswordResetToken(user.UserID, s.resetTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.passwordResetRepository.Create(ctx, resetToken); err != nil {
+		return err
+	}
+
+	resetLink, err := s.buildResetLink(token)
+	if err != nil {
+		return err
+	}
+
+	return s.userNotifier.NotifyPasswordReset(ctx, user, resetLink, resetToken.ExpiresAt)
+}
+
+func (s *passwordService) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	ctx = domain.ContextWithAllTenants(ctx)
+
+	tokenID, secret, err := domain.ParsePasswordResetToken(resetToken)
+	if err != nil {
+		return err
+	}
+
+	storedToken, err := s.passwordResetRepository.GetByID(ctx, tokenID)
+	if err != nil {
+		var customErr *domain.CustomError
+		if errors.As(err, &customErr) && customErr.IsNotFound() {
+			return domain.NewValidationError("invalid or expired reset token", nil)
+		}
+		return err
+	}
+
+	if !storedToken.IsUsable() || !storedToken.MatchesSecret(secret) {
+		return domain.NewValidationError("invalid or expired reset token", nil)
+	}
+
+	user, err := s.userRepository.GetByID(ctx, storedToken.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err = user.ChangePassword(newPassword); err != nil {
+		return err
+	}
+
+	// consuming the token first means only one of concurrent resets with the same token goes through
+	marked, err := s.passwordResetRepository.MarkUsed(ctx, storedToken.TokenID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return domain.NewValidationError("invalid or expired reset token", nil)
+	}
+
+	if err = s.userRepository.Update(ctx, *user); err != nil {
+		return err
+	}
+
+	return s.sessionRepository.RevokeByUserID(ctx, user.UserID, time.Now().UTC())
+}
+
+func (s *passwordService) ChangePassword(ctx context.Context, userID, oldPassword, newPassword string) error {
+	if oldPassword == "" || newPassword == "" {
+		return domain.NewValidationError("old and new passwords are required", nil)
+	}
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.ComparePassword(oldPassword) {
+		return domain.NewValidationError("password is incorrect", nil)
+	}
+
+	if oldPassword == newPassword {
+		return domain.NewValidationError("new password must be different from the current one", nil)
+	}
+
+	if err = user.ChangePassword(newPassword); err != nil {
+		return err
+	}
+
+	user.UpdatedBy = userID
+
+	return s.userRepository.Update(ctx, *user)
+}
+
+func (s *passwordService) buildResetLink(token string) (string, error) {
+	resetLink, err := url.Parse(s.resetLinkURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := resetLink.Query()
+	query.Set("token", token)
+	resetLink.RawQuery = query.Encode()
+
+	return resetLink.String(), nil
+}
@@ -0,0 +1,251 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/repository/memory"
+)
+
+const resetTestPassword = "new-password"
+
+type resetLinkNotifier struct {
+	resetLinks []string
+}
+
+func (n *resetLinkNotifier) NotifyPasswordReset(ctx context.Context, user domain.User, resetLink string, expiresAt time.Time) error {
+	n.resetLinks = append(n.resetLinks, resetLink)
+	return nil
+}
+
+func (n *resetLinkNotifier) NotifyMention(ctx context.Context, user domain.User, comment domain.Comment) error {
+	return nil
+}
+
+type passwordTestFixture struct {
+	service           PasswordService
+	userRepository    domain.UserRepository
+	sessionRepository domain.SessionRepository
+	notifier          *resetLinkNotifier
+	user              domain.User
+}
+
+func newPasswordTestFixture(t *testing.T) passwordTestFixture {
+	t.Helper()
+
+	userRepository := memory.NewUserRepository()
+	sessionRepository := memory.NewSessionRepository()
+	notifier := &resetLinkNotifier{}
+	service := NewPasswordService(
+		userRepository,
+		memory.NewPasswordResetRepository(),
+		sessionRepository,
+		notifier,
+		time.Hour,
+		"http://localhost/reset-password",
+	)
+
+	return passwordTestFixture{
+		service:           service,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		notifier:          notifier,
+		user:              createTestUser(t, userRepository, "user@example.com", domain.OPERATOR),
+	}
+}
+
+// requestReset asks for a reset link for the fixture's user and returns the token sent in it.
+func (f passwordTestFixture) requestReset(t *testing.T) string {
+	t.Helper()
+
+	if err := f.service.ForgotPassword(context.Background(), f.user.Email); err != nil {
+		t.Fatalf("ForgotPassword unexpected error: %v", err)
+	}
+	if len(f.notifier.resetLinks) == 0 {
+		t.Fatal("no reset link was sent")
+	}
+
+	resetLink, err := url.Parse(f.notifier.resetLinks[len(f.notifier.resetLinks)-1])
+	if err != nil {
+		t.Fatalf("reset link unexpected error: %v", err)
+	}
+
+	return resetLink.Query().Get("token")
+}
+
+func (f passwordTestFixture) savedUser(t *testing.T) *domain.User {
+	t.Helper()
+
+	user, err := f.userRepository.GetByID(domain.ContextWithAllTenants(context.Background()), f.user.UserID)
+	if err != nil {
+		t.Fatalf("GetByID unexpected error: %v", err)
+	}
+
+	return user
+}
+
+func TestPasswordServiceForgotPasswordWithUnknownEmail(t *testing.T) {
+	fixture := newPasswordTestFixture(t)
+
+	if err := fixture.service.ForgotPassword(context.Background(), "nobody@example.com"); err != nil {
+		t.Fatalf("ForgotPassword unexpected error: %v", err)
+	}
+
+	if len(fixture.notifier.resetLinks) != 0 {
+		t.Errorf("got %d reset links, want none", len(fixture.notifier.resetLinks))
+	}
+}
+
+func TestPasswordServiceResetPassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       func(token string) string
+		password    string
+		reuse       bool
+		wantStatus  int
+		wantChanged bool
+	}{
+		{
+			name:        "valid token",
+			token:       func(token string) string { return token },
+			password:    resetTestPassword,
+			wantChanged: true,
+		},
+		{
+			name:       "token used twice",
+			token:      func(token string) string { return token },
+			password:   resetTestPassword,
+			reuse:      true,
+			wantStatus: http.StatusBadRequest,
+			// the first use went through
+			wantChanged: true,
+		},
+		{
+			name:       "wrong secret",
+			token:      func(token string) string { return token + "x" },
+			password:   resetTestPassword,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "malformed token",
+			token:      func(token string) string { return "not-a-token" },
+			password:   resetTestPassword,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "password too short",
+			token:      func(token string) string { return token },
+			password:   "short",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newPasswordTestFixture(t)
+			session, _, err := domain.NewSession(fixture.user.UserID, time.Hour)
+			if err != nil {
+				t.Fatalf("NewSession unexpected error: %v", err)
+			}
+			if _, err = fixture.sessionRepository.Create(context.Background(), session); err != nil {
+				t.Fatalf("Create unexpected error: %v", err)
+			}
+
+			token := tt.token(fixture.requestReset(t))
+			if tt.reuse {
+				if err = fixture.service.ResetPassword(context.Background(), token, tt.password); err != nil {
+					t.Fatalf("first ResetPassword unexpected error: %v", err)
+				}
+			}
+
+			err = fixture.service.ResetPassword(context.Background(), token, tt.password)
+			assertStatus(t, err, tt.wantStatus)
+
+			if changed := fixture.savedUser(t).ComparePassword(tt.password); changed != tt.wantChanged {
+				t.Errorf("password changed = %v, want %v", changed, tt.wantChanged)
+			}
+
+			savedSession, err := fixture.sessionRepository.GetByID(context.Background(), session.SessionID)
+			if err != nil {
+				t.Fatalf("GetByID unexpected error: %v", err)
+			}
+			if revoked := !savedSession.IsActive(); revoked != tt.wantChanged {
+				t.Errorf("session revoked = %v, want %v", revoked, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestPasswordServiceResetPasswordKeepsTheTokenOnInvalidPasswords(t *testing.T) {
+	fixture := newPasswordTestFixture(t)
+	token := fixture.requestReset(t)
+
+	if err := fixture.service.ResetPassword(context.Background(), token, "short"); err == nil {
+		t.Fatal("ResetPassword with a short password didn't fail")
+	}
+
+	if err := fixture.service.ResetPassword(context.Background(), token, resetTestPassword); err != nil {
+		t.Fatalf("ResetPassword unexpected error: %v", err)
+	}
+}
+
+func TestPasswordServiceResetPasswordConcurrently(t *testing.T) {
+	fixture := newPasswordTestFixture(t)
+	token := fixture.requestReset(t)
+
+	var (
+		wg        sync.WaitGroup
+		mutex     sync.Mutex
+		successes int
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fixture.service.ResetPassword(context.Background(), token, resetTestPassword); err == nil {
+				mutex.Lock()
+				successes++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if successes != 1 {
+		t.Errorf("got %d successful resets, want 1", successes)
+	}
+}
+
+func TestPasswordServiceChangePassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldPassword string
+		newPassword string
+		wantStatus  int
+	}{
+		{name: "valid change", oldPassword: "password", newPassword: resetTestPassword},
+		{name: "wrong current password", oldPassword: "wrong-password", newPassword: resetTestPassword, wantStatus: http.StatusBadRequest},
+		{name: "same password", oldPassword: "password", newPassword: "password", wantStatus: http.StatusBadRequest},
+		{name: "missing new password", oldPassword: "password", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newPasswordTestFixture(t)
+			ctx := callerContext(fixture.user.UserID, fixture.user.Role)
+
+			err := fixture.service.ChangePassword(ctx, fixture.user.UserID, tt.oldPassword, tt.newPassword)
+			assertStatus(t, err, tt.wantStatus)
+
+			saved := fixture.savedUser(t)
+			if changed := !saved.ComparePassword("password"); changed != (tt.wantStatus == 0) {
+				t.Errorf("password changed = %v, want %v", changed, tt.wantStatus == 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+)
+
+const (
+	docxDocumentRels = "word/_rels/document.xml.rels"
+	docxContentTypes = "[Content_Types].xml"
+
+	emuPerPixel       = 9525
+	maxImageWidthEMU  = 5486400 // 6 inches
+	maxImageHeightEMU = 4572000 // 5 inches
+	imageDocPrIDBase  = 10000
+)
+
+var imageContentTypes = map[string]string{
+	"png":  "image/png",
+	"jpeg": "image/jpeg",
+	"gif":  "image/gif",
+}
+
+type reportImage struct {
+	index          int
+	relationshipID string
+	mediaName      string
+	extension      string
+	data           []byte
+	width          int64
+	height         int64
+	caption        string
+}
+
+// newReportImage returns false when the file isn't an image we can embed, so other kinds of
+// attachments are simply left out of the document.
+func newReportImage(index int, data []byte, caption string) (reportImage, bool) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width == 0 || config.Height == 0 {
+		return reportImage{}, false
+	}
+
+	if _, ok := imageContentTypes[format]; !ok {
+		return reportImage{}, false
+	}
+
+	width, height := fitImage(int64(config.Width)*emuPerPixel, int64(config.Height)*emuPerPixel)
+
+	return reportImage{
+		index:          index,
+		relationshipID: fmt.Sprintf("rIdReportImage%d", index),
+		mediaName:      fmt.Sprintf("word/media/report_image_%d.%s", index, format),
+		extension:      format,
+		data:           data,
+		width:          width,
+		height:         height,
+		caption:        caption,
+	}, true
+}
+
+func fitImage(width, height int64) (int64, int64) {
+	if width > maxImageWidthEMU {
+		height = height * maxImageWidthEMU / width
+		width = maxImageWidthEMU
+	}
+	if height > maxImageHeightEMU {
+		width = width * maxImageHeightEMU / height
+		height = maxImageHeightEMU
+	}
+	return width, height
+}
+
+func imageParagraphs(images []reportImage) string {
+	var paragraphs strings.Builder
+	for _, img := range images {
+		docPrID := imageDocPrIDBase + img.index
+		fmt.Fprintf(&paragraphs,
+			`<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:drawing>`+
+				`<wp:inline xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" distT="0" distB="0" distL="0" distR="0">`+
+				`<wp:extent cx="%d" cy="%d"/><wp:docPr id="%d" name="%s"/>`+
+				`<a:graphic xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/picture">`+
+				`<pic:pic xmlns:pic="http://schemas.openxmlformats.org/drawingml/2006/picture">`+
+				`<pic:nvPicPr><pic:cNvPr id="%d" name="%s"/><pic:cNvPicPr/></pic:nvPicPr>`+
+				`<pic:blipFill><a:blip r:embed="%s"/><a:stretch><a:fillRect/></a:stretch></pic:blipFill>`+
+				`<pic:spPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></pic:spPr>`+
+				`</pic:pic></a:graphicData></a:graphic></wp:inline></w:drawing></w:r></w:p>`,
+			img.width, img.height, docPrID, img.relationshipID,
+			docPrID, img.relationshipID,
+			img.relationshipID,
+			img.width, img.height,
+		)
+		fmt.Fprintf(&paragraphs,
+			`<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:rPr><w:i/></w:rPr><w:t xml:space="preserve">%s</w:t></w:r></w:p>`,
+			escapeXML(img.caption),
+		)
+	}
+	return paragraphs.String()
+}
+
+// replacePlaceholderParagraph swaps every paragraph holding the placeholder for the given
+// paragraphs, since drawings can't live inside the text run the placeholder was written in.
+func replacePlaceholderParagraph(content, placeholder, paragraphs string) string {
+	offset := 0
+	for {
+		found := strings.Index(content[offset:], placeholder)
+		if found < 0 {
+			return content
+		}
+		placeholderIndex := offset + found
+
+		start := max(strings.LastIndex(content[:placeholderIndex], "<w:p>"), strings.LastIndex(content[:placeholderIndex], "<w:p "))
+		end := strings.Index(content[placeholderIndex:], "</w:p>")
+		if start < 0 || end < 0 {
+			content = content[:placeholderIndex] + content[placeholderIndex+len(placeholder):]
+			offset = placeholderIndex
+			continue
+		}
+		end += placeholderIndex + len("</w:p>")
+
+		content = content[:start] + paragraphs + content[end:]
+		offset = start + len(paragraphs)
+	}
+}
+
+// addImagesToDocx copies the document adding the image parts, their relationships and the
+// content types they need.
+func addImagesToDocx(document []byte, images []reportImage, output io.Writer) error {
+	reader, err := zip.NewReader(bytes.NewReader(document), int64(len(document)))
+	if err != nil {
+		return err
+	}
+
+	writer := zip.NewWriter(output)
+	for _, file := range reader.File {
+		content, err := readZipFile(file)
+		if err != nil {
+			return err
+		}
+
+		switch file.Name {
+		case docxDocumentRels:
+			content = addImageRelationships(content, images)
+		case docxContentTypes:
+			content = addImageContentTypes(content, images)
+		}
+
+		if err = writeZipFile(writer, file.Name, content); err != nil {
+			return err
+		}
+	}
+
+	for _, img := range images {
+		if err = writeZipFile(writer, img.mediaName, img.data); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+func addImageRelationships(rels []byte, images []reportImage) []byte {
+	var relationships strings.Builder
+	for _, img := range images {
+		fmt.Fprintf(&relationships,
+			`<Relationship Id="%s" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="%s"/>`,
+			img.relationshipID, strings.TrimPrefix(img.mediaName, "word/"),
+		)
+	}
+
+	return bytes.Replace(rels, []byte("</Relationships>"), []byte(relationships.String()+"</Relationships>"), 1)
+}
+
+func addImageContentTypes(contentTypes []byte, images []reportImage) []byte {
+	var defaults strings.Builder
+	added := make(map[string]bool)
+	for _, img := range images {
+		if added[img.extension] || bytes.Contains(bytes.ToLower(contentTypes), []byte(fmt.Sprintf(`extension="%s"`, img.extension))) {
+			continue
+		}
+		added[img.extension] = true
+		fmt.Fprintf(&defaults, `<Default Extension="%s" ContentType="%s"/>`, img.extension, imageContentTypes[img.extension])
+	}
+
+	return bytes.Replace(contentTypes, []byte("</Types>"), []byte(defaults.String()+"</Types>"), 1)
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	readCloser, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer readCloser.Close()
+
+	return io.ReadAll(readCloser)
+}
+
+func writeZipFile(writer *zip.Writer, name string, content []byte) error {
+	fileWriter, err := writer.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = fileWriter.Write(content)
+	return err
+}
+
+func escapeXML(text string) string {
+	var escaped bytes.Buffer
+	_ = xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
@@ -0,0 +1,173 @@
+package application
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"golang.org/x/sync/errgroup"
+)
+
+const reportExportManifestName = "manifest.json"
+
+type reportExportService struct {
+	ticketRepository domain.TicketRepository
+	reportService    ReportService
+	concurrency      int
+	maxTickets       int
+}
+
+type ReportExportService interface {
+	SearchTickets(ctx context.Context, filters domain.TicketFilters) ([]domain.Ticket, error)
+	WriteExport(ctx context.Context, tickets []domain.Ticket, format domain.ReportFormat, output io.Writer) error
+}
+
+type reportExportManifest struct {
+	GeneratedAt time.Time           `json:"generated_at"`
+	Format      string              `json:"format"`
+	Exported    []reportExportEntry `json:"exported"`
+	Skipped     []reportExportEntry `json:"skipped"`
+}
+
+type reportExportEntry struct {
+	TicketID          string `json:"ticket_id"`
+	ExternalReference string `json:"external_reference"`
+	FileName          string `json:"file_name,omitempty"`
+	Reason            string `json:"reason,omitempty"`
+}
+
+func NewReportExportService(
+	ticketRepository domain.TicketRepository,
+	reportService ReportService,
+	concurrency int,
+	maxTickets int,
+) ReportExportService {
+	return &reportExportService{
+		ticketRepository: ticketRepository,
+		reportService:    reportService,
+		concurrency:      max(concurrency, 1),
+		maxTickets:       maxTickets,
+	}
+}
+
+// SearchTickets returns the tickets matching the filters, defaulting to the ones waiting on a report
+// or a payment. It refuses exports bigger than the configured limit before anything is generated.
+func (s *reportExportService) SearchTickets(ctx context.Context, filters domain.TicketFilters) ([]domain.Ticket, error) {
+	if filters.CreatedFrom != nil && filters.CreatedTo != nil && !filters.CreatedFrom.Before(*filters.CreatedTo) {
+		return nil, domain.NewValidationError("created_from must be before created_to", nil)
+	}
+
+	if len(filters.Status) == 0 {
+		filters.Status = []string{string(domain.REPORT), string(domain.PAYMENT)}
+	}
+
+	filters.PagingFilter = domain.PagingFilter{
+		Limit:         s.maxTickets,
+		SortBy:        "created_at",
+		SortDirection: domain.ASC,
+	}
+
+	crmTickets, err := s.ticketRepository.Search(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if crmTickets.Paging.Total > s.maxTickets {
+		return nil, domain.NewValidationError("too many tickets to export, narrow the filters", map[string]any{
+			"total":       crmTickets.Paging.Total,
+			"max_tickets": s.maxTickets,
+		})
+	}
+
+	if len(crmTickets.Result) == 0 {
+		return nil, domain.NewNotFoundError("no tickets found for these filters", nil)
+	}
+
+	return crmTickets.Result, nil
+}
+
+// WriteExport streams a ZIP with one report per ticket, generating at most `concurrency` reports at a
+// time. Tickets that can't be reported don't fail the export; they are listed in the manifest instead.
+func (s *reportExportService) WriteExport(ctx context.Context, tickets []domain.Ticket, format domain.ReportFormat, output io.Writer) error {
+	zipWriter := zip.NewWriter(output)
+	entries := make([]reportExportEntry, len(tickets))
+	fileNames := make(map[string]bool)
+	var zipMutex sync.Mutex
+
+	wg, groupCtx := errgroup.WithContext(ctx)
+	wg.SetLimit(s.concurrency)
+	for i, crmTicket := range tickets {
+		wg.Go(func() error {
+			entries[i] = reportExportEntry{
+				TicketID:          crmTicket.TicketID,
+				ExternalReference: crmTicket.ExternalReference,
+			}
+
+			if err := validateReportTicket(crmTicket); err != nil {
+				entries[i].Reason = err.Error()
+				return nil
+			}
+
+			content, fileName, err := s.reportService.GenerateReport(groupCtx, crmTicket, format)
+			if err != nil {
+				if groupCtx.Err() != nil {
+					return groupCtx.Err()
+				}
+				entries[i].Reason = err.Error()
+				return nil
+			}
+
+			zipMutex.Lock()
+			defer zipMutex.Unlock()
+
+			entries[i].FileName = uniqueExportFileName(fileNames, fileName, crmTicket.TicketID)
+			return writeZipFile(zipWriter, entries[i].FileName, content)
+		})
+	}
+
+	if err := wg.Wait(); err != nil {
+		return err
+	}
+
+	manifest := reportExportManifest{
+		GeneratedAt: time.Now().UTC(),
+		Format:      string(format),
+		Exported:    make([]reportExportEntry, 0, len(entries)),
+		Skipped:     make([]reportExportEntry, 0),
+	}
+	for _, entry := range entries {
+		if entry.Reason != "" {
+			manifest.Skipped = append(manifest.Skipped, entry)
+			continue
+		}
+		manifest.Exported = append(manifest.Exported, entry)
+	}
+
+	manifestContent, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = writeZipFile(zipWriter, reportExportManifestName, manifestContent); err != nil {
+		return err
+	}
+
+	return zipWriter.Close()
+}
+
+func uniqueExportFileName(fileNames map[string]bool, fileName, ticketID string) string {
+	if fileNames[fileName] {
+		extension := filepath.Ext(fileName)
+		fileName = fmt.Sprintf("%s-%s%s", strings.TrimSuffix(fileName, extension), ticketID, extension)
+	}
+	fileNames[fileName] = true
+
+	return fileName
+}
@@ -0,0 +1,252 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type reportJobService struct {
+	reportJobRepository domain.ReportJobRepository
+	ticketRepository    domain.TicketRepository
+	reportService       ReportService
+	attachmentBucket    domain.AttachmentBucket
+	queue               chan string
+	workers             int
+	timeout             time.Duration
+	versionMutex        sync.Mutex
+}
+
+type ReportJobService interface {
+	Enqueue(ctx context.Context, ticketID string, format domain.ReportFormat, author string) (*domain.ReportJob, error)
+	GetByID(ctx context.Context, jobID string) (*domain.ReportJob, error)
+	SearchByTicketID(ctx context.Context, ticketID string, paging domain.PagingFilter) (domain.PagingResult[domain.ReportJob], error)
+	Download(ctx context.Context, jobID string) (*domain.ReportJob, []byte, error)
+	Start(ctx context.Context)
+}
+
+func NewReportJobService(
+	reportJobRepository domain.ReportJobRepository,
+	ticketRepository domain.TicketRepository,
+	reportService ReportService,
+	attachmentBucket domain.AttachmentBucket,
+	workers int,
+	queueSize int,
+	timeout time.Duration,
+) ReportJobService {
+	return &reportJobService{
+		reportJobRepository: reportJobRepository,
+		ticketRepository:    ticketRepository,
+		reportService:       reportService,
+		attachmentBucket:    attachmentBucket,
+		queue:               make(chan string, queueSize),
+		workers:             max(workers, 1),
+		timeout:             timeout,
+	}
+}
+
+// Enqueue registers a report job for the ticket and hands it to the workers, so the caller doesn't
+// wait for the attachments to be downloaded and the file to be rendered.
+func (s *reportJobService) Enqueue(ctx context.Context, ticketID string, format domain.ReportFormat, author string) (*domain.ReportJob, error) {
+	if ticketID == "" {
+		return nil, domain.NewValidationError("ticket_id is required", nil)
+	}
+
+	crmTicket, err := s.ticketRepository.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateReportTicket(*crmTicket); err != nil {
+		return nil, err
+	}
+
+	job, err := domain.NewReportJob(crmTicket.TenantID, crmTicket.TicketID, format, author)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.reportJobRepository.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.queue <- job.JobID:
+	default:
+		job.Fail("report queue is full")
+		if err = s.reportJobRepository.Update(ctx, job); err != nil {
+			return nil, err
+		}
+		return nil, domain.NewTooManyRequestsError("report queue is full, try again later", map[string]any{"job_id": job.JobID})
+	}
+
+	return &job, nil
+}
+
+func (s *reportJobService) GetByID(ctx context.Context, jobID string) (*domain.ReportJob, error) {
+	if jobID == "" {
+		return nil, domain.NewValidationError("jobID cannot be empty", nil)
+	}
+
+	return s.reportJobRepository.GetByID(ctx, jobID)
+}
+
+func (s *reportJobService) SearchByTicketID(ctx context.Context, ticketID string, paging domain.PagingFilter) (domain.PagingResult[domain.ReportJob], error) {
+	if ticketID == "" {
+		return domain.PagingResult[domain.ReportJob]{}, domain.NewValidationError("ticket_id is required", nil)
+	}
+
+	return s.reportJobRepository.Search(ctx, domain.ReportJobFilters{
+		TicketID:     []string{ticketID},
+		PagingFilter: paging,
+	})
+}
+
+func (s *reportJobService) Download(ctx context.Context, jobID string) (*domain.ReportJob, []byte, error) {
+	job, err := s.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if job.Status != domain.REPORT_JOB_COMPLETED {
+		return nil, nil, domain.NewConflictError("report is not ready yet", map[string]any{"job_id": jobID, "status": job.Status})
+	}
+
+	file, err := s.attachmentBucket.Download(ctx, job.ArtifactKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return job, file, nil
+}
+
+// Start launches the workers and puts back in the queue the jobs that were left unfinished by a
+// previous run.
+func (s *reportJobService) Start(ctx context.Context) {
+	// workers pick up the jobs of every tenant
+	ctx = domain.ContextWithAllTenants(ctx)
+
+	for i := 0; i < s.workers; i++ {
+		go s.work(ctx)
+	}
+
+	unfinishedJobs, err := s.reportJobRepository.Search(ctx, domain.ReportJobFilters{
+		Status: []string{string(domain.REPORT_JOB_PENDING), string(domain.REPORT_JOB_RUNNING)},
+		PagingFilter: domain.PagingFilter{
+			SortBy:        "created_at",
+			SortDirection: domain.ASC,
+		},
+	})
+	if err != nil {
+		log.Printf("failed to load unfinished report jobs: %v", err)
+		return
+	}
+
+	go func() {
+		for _, job := range unfinishedJobs.Result {
+			select {
+			case s.queue <- job.JobID:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *reportJobService) work(ctx context.Context) {
+	for {
+		select {
+		case jobID := <-s.queue:
+			s.process(ctx, jobID)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *reportJobService) process(ctx context.Context, jobID string) {
+	job, err := s.reportJobRepository.GetByID(ctx, jobID)
+	if err != nil {
+		log.Printf("failed to load report job %s: %v", jobID, err)
+		return
+	}
+
+	if job.IsFinished() {
+		return
+	}
+
+	// from here on the worker only reaches the tenant the job was enqueued for
+	ctx = domain.ContextWithTenantScope(ctx, job.TenantID)
+
+	job.Start()
+	if err = s.reportJobRepository.Update(ctx, *job); err != nil {
+		log.Printf("failed to start report job %s: %v", jobID, err)
+		return
+	}
+
+	if err = s.generate(ctx, job); err == nil {
+		return
+	}
+
+	job.Fail(err.Error())
+	if err = s.reportJobRepository.Update(ctx, *job); err != nil {
+		log.Printf("failed to save report job %s: %v", jobID, err)
+	}
+}
+
+func (s *reportJobService) generate(ctx context.Context, job *domain.ReportJob) error {
+	jobCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	crmTicket, err := s.ticketRepository.GetByID(jobCtx, job.TicketID)
+	if err != nil {
+		return err
+	}
+
+	content, fileName, err := s.reportService.GenerateReport(jobCtx, *crmTicket, job.Format)
+	if err != nil {
+		return err
+	}
+
+	// versions are numbered per ticket, so two jobs of the same ticket can't finish at the same time
+	s.versionMutex.Lock()
+	defer s.versionMutex.Unlock()
+
+	version, err := s.nextVersion(jobCtx, job.TicketID)
+	if err != nil {
+		return err
+	}
+
+	job.Complete(version, fileName, len(content))
+
+	if err = s.attachmentBucket.Upload(jobCtx, job.ArtifactKey, bytes.NewReader(content), job.Format.ContentType()); err != nil {
+		return err
+	}
+
+	return s.reportJobRepository.Update(jobCtx, *job)
+}
+
+func (s *reportJobService) nextVersion(ctx context.Context, ticketID string) (int, error) {
+	latestJobs, err := s.reportJobRepository.Search(ctx, domain.ReportJobFilters{
+		TicketID: []string{ticketID},
+		Status:   []string{string(domain.REPORT_JOB_COMPLETED)},
+		PagingFilter: domain.PagingFilter{
+			Limit:         1,
+			SortBy:        "version",
+			SortDirection: domain.DESC,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(latestJobs.Result) == 0 {
+		return 1, nil
+	}
+
+	return latestJobs.Result[0].Version + 1, nil
+}
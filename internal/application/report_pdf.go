@@ -0,0 +1,221 @@
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const (
+	pdfFontFamily  = "Helvetica"
+	pdfLineHeight  = 6.0
+	pdfLabelWidth  = 40.0
+	pdfMargin      = 15.0
+	emuPerMM       = 36000
+	pdfJPEGQuality = 85
+)
+
+var pdfImageTypes = map[string]string{
+	"png":  "PNG",
+	"jpeg": "JPG",
+	"gif":  "GIF",
+}
+
+var pdfTimelineSections = []struct {
+	title       string
+	commentType domain.CommentType
+}{
+	{title: "Content", commentType: domain.CONTENT},
+	{title: "Comments", commentType: domain.COMMENT},
+	{title: "Resolution", commentType: domain.RESOLUTION},
+}
+
+type reportPDF struct {
+	pdf       *fpdf.Fpdf
+	translate func(string) string
+}
+
+// renderReportPDF lays out the same data used by the DOCX templates in a fixed format, so every
+// tenant gets a PDF report without having to upload anything.
+func renderReportPDF(reportData ReportData, commentImages map[string][]reportImage, output io.Writer) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin+10, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin+5)
+	pdf.AliasNbPages("")
+	pdf.SetTitle(fmt.Sprintf("Report %s", reportData.CrmTicket.ExternalReference), true)
+
+	doc := &reportPDF{
+		pdf:       pdf,
+		translate: pdf.UnicodeTranslatorFromDescriptor(""),
+	}
+
+	generatedAt := time.Now().Format(dateTimeReportLayout)
+	pdf.SetHeaderFunc(func() {
+		pdf.SetY(pdfMargin)
+		pdf.SetFont(pdfFontFamily, "B", 11)
+		pdf.CellFormat(0, pdfLineHeight, doc.translate(reportData.Tenant.CompanyName), "", 0, "L", false, 0, "")
+		pdf.SetX(pdfMargin)
+		pdf.SetFont(pdfFontFamily, "", 9)
+		pdf.CellFormat(0, pdfLineHeight, doc.translate(fmt.Sprintf("Claim %s", reportData.CrmTicket.ExternalReference)), "", 1, "R", false, 0, "")
+		pageWidth, _ := pdf.GetPageSize()
+		pdf.Line(pdfMargin, pdf.GetY()+1, pageWidth-pdfMargin, pdf.GetY()+1)
+		pdf.Ln(4)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin)
+		pdf.SetFont(pdfFontFamily, "I", 8)
+		pdf.CellFormat(0, pdfLineHeight, doc.translate(fmt.Sprintf("Generated at %s", generatedAt)), "", 0, "L", false, 0, "")
+		pdf.SetX(pdfMargin)
+		pdf.CellFormat(0, pdfLineHeight, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+	pdf.SetFont(pdfFontFamily, "B", 16)
+	pdf.CellFormat(0, 10, doc.translate(reportData.CrmTicket.Subject), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	doc.block("Ticket", [][2]string{
+		{"Claim", reportData.CrmTicket.ExternalReference},
+		{"Status", string(reportData.CrmTicket.Status)},
+		{"Date", time.Now().Format(dateReportLayout)},
+		{"Target date", formatOptionalDate(reportData.CrmTicket.TargetDate)},
+	})
+	doc.block("Customer", [][2]string{
+		{"Name", fmt.Sprintf("%s %s", reportData.Customer.FirstName, reportData.Customer.LastName)},
+		{"Document", ParseDocument(reportData.Customer.Document)},
+		{"Address", reportData.Customer.ShippingAddress.Address},
+		{"Zip code", reportData.Customer.ShippingAddress.ZipCode},
+	})
+	doc.block("Product", [][2]string{
+		{"Product", reportData.Product.Name},
+		{"Brand", reportData.Product.Brand},
+		{"Model", reportData.Product.Model},
+		{"Serial number", reportData.Product.SerialNumber},
+	})
+	doc.block("Lead", [][2]string{
+		{"Name", fmt.Sprintf("%s %s", reportData.Lead.FirstName, reportData.Lead.LastName)},
+		{"Document", ParseDocument(reportData.Lead.Document)},
+	})
+
+	for _, section := range pdfTimelineSections {
+		doc.timeline(section.title, section.commentType, reportData.Comments, commentImages)
+	}
+
+	return pdf.Output(output)
+}
+
+func (d *reportPDF) block(title string, rows [][2]string) {
+	d.sectionTitle(title)
+	for _, row := range rows {
+		d.pdf.SetFont(pdfFontFamily, "B", 10)
+		d.pdf.CellFormat(pdfLabelWidth, pdfLineHeight, d.translate(row[0]), "", 0, "L", false, 0, "")
+		d.pdf.SetFont(pdfFontFamily, "", 10)
+		d.pdf.MultiCell(0, pdfLineHeight, d.translate(row[1]), "", "L", false)
+	}
+	d.pdf.Ln(3)
+}
+
+func (d *reportPDF) timeline(title string, commentType domain.CommentType, comments []domain.Comment, commentImages map[string][]reportImage) {
+	d.sectionTitle(title)
+
+	hasEntries := false
+	for _, comment := range comments {
+		if comment.CommentType != commentType {
+			continue
+		}
+		hasEntries = true
+
+		d.pdf.SetFont(pdfFontFamily, "B", 9)
+		d.pdf.CellFormat(0, pdfLineHeight, comment.CreatedAt.Format(dateTimeReportLayout), "", 1, "L", false, 0, "")
+		d.pdf.SetFont(pdfFontFamily, "", 10)
+		d.pdf.MultiCell(0, pdfLineHeight, d.translate(comment.Content), "", "L", false)
+
+		for _, img := range commentImages[comment.CommentID] {
+			d.image(img)
+		}
+		d.pdf.Ln(2)
+	}
+
+	if !hasEntries {
+		d.pdf.SetFont(pdfFontFamily, "I", 10)
+		d.pdf.CellFormat(0, pdfLineHeight, "-", "", 1, "L", false, 0, "")
+	}
+	d.pdf.Ln(3)
+}
+
+func (d *reportPDF) sectionTitle(title string) {
+	d.pdf.SetFont(pdfFontFamily, "B", 12)
+	d.pdf.SetFillColor(235, 235, 235)
+	d.pdf.CellFormat(0, pdfLineHeight+1, d.translate(title), "", 1, "L", true, 0, "")
+	d.pdf.Ln(1)
+}
+
+func (d *reportPDF) image(img reportImage) {
+	if !d.registerImage(img) {
+		return
+	}
+
+	pageWidth, pageHeight := d.pdf.GetPageSize()
+	_, _, _, bottomMargin := d.pdf.GetMargins()
+	contentWidth := pageWidth - 2*pdfMargin
+
+	width := float64(img.width) / emuPerMM
+	height := float64(img.height) / emuPerMM
+	if width > contentWidth {
+		height = height * contentWidth / width
+		width = contentWidth
+	}
+
+	if d.pdf.GetY()+height+pdfLineHeight > pageHeight-bottomMargin {
+		d.pdf.AddPage()
+	}
+
+	y := d.pdf.GetY() + 2
+	d.pdf.ImageOptions(img.relationshipID, pdfMargin+(contentWidth-width)/2, y, width, height, false, fpdf.ImageOptions{}, 0, "")
+	d.pdf.SetY(y + height + 1)
+
+	d.pdf.SetFont(pdfFontFamily, "I", 8)
+	d.pdf.CellFormat(0, pdfLineHeight, d.translate(img.caption), "", 1, "C", false, 0, "")
+}
+
+// registerImage falls back to a re-encoded JPEG for image variants the PDF library can't read
+// directly, such as 16-bit or interlaced PNGs.
+func (d *reportPDF) registerImage(img reportImage) bool {
+	options := fpdf.ImageOptions{ImageType: pdfImageTypes[img.extension]}
+	d.pdf.RegisterImageOptionsReader(img.relationshipID, options, bytes.NewReader(img.data))
+	if d.pdf.Ok() {
+		return true
+	}
+	d.pdf.ClearError()
+
+	decoded, _, err := image.Decode(bytes.NewReader(img.data))
+	if err != nil {
+		return false
+	}
+
+	var converted bytes.Buffer
+	if err = jpeg.Encode(&converted, decoded, &jpeg.Options{Quality: pdfJPEGQuality}); err != nil {
+		return false
+	}
+
+	d.pdf.RegisterImageOptionsReader(img.relationshipID, fpdf.ImageOptions{ImageType: "JPG"}, &converted)
+	if d.pdf.Ok() {
+		return true
+	}
+	d.pdf.ClearError()
+
+	return false
+}
+
+func formatOptionalDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(dateReportLayout)
+}
@@ -0,0 +1,314 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const (
+	docxDocument          = "word/document.xml"
+	wordprocessingMLSpace = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+)
+
+var placeholderPattern = regexp.MustCompile(`\$[a-z_]+`)
+
+// ReportPlaceholder documents a variable templates can use and the ReportData field it's filled from.
+// Image placeholders take the whole paragraph they are written in.
+type ReportPlaceholder struct {
+	Name        string
+	Description string
+	Source      string
+	Image       bool
+	commentType domain.CommentType
+	value       func(reportData ReportData) string
+}
+
+type ReportTemplateValidation struct {
+	Placeholders []string
+	Unknown      []string
+	Missing      []string
+	Split        []string
+	Syntax       []string
+}
+
+var reportPlaceholders = []ReportPlaceholder{
+	{
+		Name:        "$claim",
+		Description: "Claim number of the ticket",
+		Source:      "CrmTicket.ExternalReference",
+		value:       func(r ReportData) string { return r.CrmTicket.ExternalReference },
+	},
+	{
+		Name:        "$actual_date",
+		Description: "Date the report was generated",
+		Source:      "generation time",
+		value:       func(r ReportData) string { return time.Now().Format(dateReportLayout) },
+	},
+	{
+		Name:        "$client",
+		Description: "Customer full name",
+		Source:      "Customer.FirstName, Customer.LastName",
+		value:       func(r ReportData) string { return fmt.Sprintf("%s %s", r.Customer.FirstName, r.Customer.LastName) },
+	},
+	{
+		Name:        "$document",
+		Description: "Customer document, formatted as CPF or CNPJ",
+		Source:      "Customer.Document",
+		value:       func(r ReportData) string { return ParseDocument(r.Customer.Document) },
+	},
+	{
+		Name:        "$address",
+		Description: "Customer shipping address",
+		Source:      "Customer.ShippingAddress.Address",
+		value:       func(r ReportData) string { return r.Customer.ShippingAddress.Address },
+	},
+	{
+		Name:        "$zip_code",
+		Description: "Customer shipping zip code",
+		Source:      "Customer.ShippingAddress.ZipCode",
+		value:       func(r ReportData) string { return r.Customer.ShippingAddress.ZipCode },
+	},
+	{
+		Name:        "$summary",
+		Description: "Ticket subject",
+		Source:      "CrmTicket.Subject",
+		value:       func(r ReportData) string { return r.CrmTicket.Subject },
+	},
+	{
+		Name:        "$target_date",
+		Description: "Date the lead is expected to visit the customer, empty when not scheduled",
+		Source:      "CrmTicket.TargetDate",
+		value:       func(r ReportData) string { return formatOptionalDate(r.CrmTicket.TargetDate) },
+	},
+	{
+		Name:        "$lead",
+		Description: "Lead full name",
+		Source:      "Lead.FirstName, Lead.LastName",
+		value:       func(r ReportData) string { return fmt.Sprintf("%s %s", r.Lead.FirstName, r.Lead.LastName) },
+	},
+	{
+		Name:        "$product",
+		Description: "Product name",
+		Source:      "Product.Name",
+		value:       func(r ReportData) string { return r.Product.Name },
+	},
+	{
+		Name:        "$brand",
+		Description: "Product brand",
+		Source:      "Product.Brand",
+		value:       func(r ReportData) string { return r.Product.Brand },
+	},
+	{
+		Name:        "$serial_number",
+		Description: "Product serial number",
+		Source:      "Product.SerialNumber",
+		value:       func(r ReportData) string { return r.Product.SerialNumber },
+	},
+	{
+		Name:        "$content",
+		Description: "Content comments, one per line with their date",
+		Source:      "Comments (Content)",
+		commentType: domain.CONTENT,
+	},
+	{
+		Name:        "$comments",
+		Description: "Regular comments, one per line with their date",
+		Source:      "Comments (Comment)",
+		commentType: domain.COMMENT,
+	},
+	{
+		Name:        "$resolution",
+		Description: "Resolution comments, one per line with their date",
+		Source:      "Comments (Resolution)",
+		commentType: domain.RESOLUTION,
+	},
+	{
+		Name:        "$image_content",
+		Description: "Images attached to content comments, with captions",
+		Source:      "Comments (Content).Attachments",
+		Image:       true,
+		commentType: domain.CONTENT,
+	},
+	{
+		Name:        "$image_comment",
+		Description: "Images attached to regular comments, with captions",
+		Source:      "Comments (Comment).Attachments",
+		Image:       true,
+		commentType: domain.COMMENT,
+	},
+	{
+		Name:        "$image_resolution",
+		Description: "Images attached to resolution comments, with captions",
+		Source:      "Comments (Resolution).Attachments",
+		Image:       true,
+		commentType: domain.RESOLUTION,
+	},
+}
+
+func ReportPlaceholders() []ReportPlaceholder {
+	return slices.Clone(reportPlaceholders)
+}
+
+func (p ReportPlaceholder) text(reportData ReportData) string {
+	if p.value != nil {
+		return p.value(reportData)
+	}
+
+	lines := make([]string, 0)
+	for _, comment := range reportData.Comments {
+		if comment.CommentType == p.commentType {
+			lines = append(lines, fmt.Sprintf("%s - %s", comment.CreatedAt.Format(dateTimeReportLayout), comment.Content))
+		}
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+func (p ReportPlaceholder) images(reportData ReportData, commentImages map[string][]reportImage) []reportImage {
+	images := make([]reportImage, 0)
+	for _, comment := range reportData.Comments {
+		if comment.CommentType == p.commentType {
+			images = append(images, commentImages[comment.CommentID]...)
+		}
+	}
+	return images
+}
+
+func (v ReportTemplateValidation) Valid() bool {
+	return len(v.Unknown) == 0 && len(v.Split) == 0 && len(v.Syntax) == 0
+}
+
+// validateTemplatePlaceholders compares the placeholders written in the template with the registry.
+// Word may break a placeholder into several runs when part of it is edited or formatted, which keeps
+// it readable but impossible to replace, so those are reported as split. Templates written with the
+// template language are checked for syntax and unknown fields, and aren't expected to use every
+// $ placeholder.
+func validateTemplatePlaceholders(template []byte) (ReportTemplateValidation, error) {
+	document, err := readDocxDocument(template)
+	if err != nil {
+		return ReportTemplateValidation{}, err
+	}
+
+	paragraphs, err := docxParagraphs(document)
+	if err != nil {
+		return ReportTemplateValidation{}, err
+	}
+
+	validation := ReportTemplateValidation{
+		Placeholders: make([]string, 0),
+		Unknown:      make([]string, 0),
+		Missing:      make([]string, 0),
+		Split:        make([]string, 0),
+		Syntax:       make([]string, 0),
+	}
+	usesTemplateLanguage := false
+	for _, paragraph := range paragraphs {
+		for _, name := range placeholderPattern.FindAllString(paragraph, -1) {
+			if !slices.Contains(validation.Placeholders, name) {
+				validation.Placeholders = append(validation.Placeholders, name)
+			}
+		}
+		for _, tag := range templateTagPattern.FindAllString(paragraph, -1) {
+			usesTemplateLanguage = true
+			if !slices.Contains(validation.Placeholders, tag) {
+				validation.Placeholders = append(validation.Placeholders, tag)
+			}
+		}
+	}
+
+	for _, name := range validation.Placeholders {
+		if !strings.HasPrefix(name, "$") {
+			continue
+		}
+
+		if !slices.ContainsFunc(reportPlaceholders, func(p ReportPlaceholder) bool { return p.Name == name }) {
+			validation.Unknown = append(validation.Unknown, name)
+			continue
+		}
+
+		if !bytes.Contains(document, []byte(name)) {
+			validation.Split = append(validation.Split, name)
+		}
+	}
+
+	if usesTemplateLanguage {
+		unknownTags, err := validateReportTemplateLanguage(string(document))
+		if err != nil {
+			validation.Syntax = append(validation.Syntax, err.Error())
+		}
+		validation.Unknown = append(validation.Unknown, unknownTags...)
+
+		return validation, nil
+	}
+
+	for _, placeholder := range reportPlaceholders {
+		if !slices.Contains(validation.Placeholders, placeholder.Name) {
+			validation.Missing = append(validation.Missing, placeholder.Name)
+		}
+	}
+
+	return validation, nil
+}
+
+func readDocxDocument(template []byte) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(template), int64(len(template)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range reader.File {
+		if file.Name == docxDocument {
+			return readZipFile(file)
+		}
+	}
+
+	return nil, domain.NewValidationError("report template has no document body", nil)
+}
+
+// docxParagraphs returns the text of each paragraph as Word displays it, joining its runs.
+func docxParagraphs(document []byte) ([]string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(document))
+	paragraphs := make([]string, 0)
+	var paragraph strings.Builder
+	inText := false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			inText = element.Name.Space == wordprocessingMLSpace && element.Name.Local == "t"
+		case xml.EndElement:
+			if element.Name.Space != wordprocessingMLSpace {
+				continue
+			}
+			switch element.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				paragraphs = append(paragraphs, paragraph.String())
+				paragraph.Reset()
+			}
+		case xml.CharData:
+			if inText {
+				paragraph.Write(element)
+			}
+		}
+	}
+
+	return paragraphs, nil
+}
@@ -5,7 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"strings"
+	"slices"
 	"time"
 
 	"github.com/icrxz/crm-api-core/internal/domain"
@@ -17,64 +17,69 @@ const (
 	dateReportLayout     = "02/Jan/2006"
 	dateTimeReportLayout = "02/Jan/2006 15:04"
 	timestampLayout      = "02_01_2006_15_04_05_0000"
+	// reportAudience is who reports are generated for; internal notes never go into them.
+	reportAudience = domain.TENANT_VISIBILITY
 )
 
-var tenantsTemplates = map[string]string{
-	"sample": "sample_template.docx",
-}
-
 type ContentWithAttachment struct {
 	Content    string
 	Attachment [][]byte
 }
 
 type reportService struct {
-	reportFolder     string
-	ticketService    TicketService
-	productService   ProductService
-	customerService  CustomerService
-	commentService   CommentService
-	leadService      LeadService
-	tenantService    TenantService
-	attachmentBucket domain.AttachmentBucket
+	reportTemplateService ReportTemplateService
+	ticketService         TicketService
+	productService        ProductService
+	customerService       CustomerService
+	commentService        CommentService
+	leadService           LeadService
+	tenantService         TenantService
+	transactionService    TransactionService
+	attachmentBucket      domain.AttachmentBucket
 }
 
 type ReportService interface {
-	GenerateReport(ctx context.Context, crmTicket domain.Ticket) ([]byte, string, error)
+	GenerateReport(ctx context.Context, crmTicket domain.Ticket, format domain.ReportFormat) ([]byte, string, error)
+	PreviewReport(ctx context.Context, reportID, ticketID string) ([]byte, string, error)
 }
 
 type ReportData struct {
-	CrmTicket domain.Ticket
-	Customer  domain.Customer
-	Product   domain.Product
-	Lead      domain.Lead
-	Tenant    domain.Tenant
-	Comments  []domain.Comment
+	CrmTicket    domain.Ticket
+	Customer     domain.Customer
+	Product      domain.Product
+	Lead         domain.Lead
+	Tenant       domain.Tenant
+	Comments     []domain.Comment
+	Transactions []domain.Transaction
 }
 
 func NewReportService(
-	reportFolder string,
+	reportTemplateService ReportTemplateService,
 	ticketService TicketService,
 	productService ProductService,
 	customerService CustomerService,
 	commentService CommentService,
 	leadService LeadService,
 	tenantService TenantService,
+	transactionService TransactionService,
 	attachmentBucket domain.AttachmentBucket,
 ) ReportService {
 	return &reportService{
-		reportFolder:     reportFolder,
-		ticketService:    ticketService,
-		productService:   productService,
-		customerService:  customerService,
-		commentService:   commentService,
-		leadService:      leadService,
-		tenantService:    tenantService,
-		attachmentBucket: attachmentBucket,
+		reportTemplateService: reportTemplateService,
+		ticketService:         ticketService,
+		productService:        productService,
+		customerService:       customerService,
+		commentService:        commentService,
+		leadService:           leadService,
+		tenantService:         tenantService,
+		transactionService:    transactionService,
+		attachmentBucket:      attachmentBucket,
 	}
 }
 
-func (s *reportService) GenerateReport(ctx context.Context, crmTicket domain.Ticket) ([]byte, string, error) {
+// GenerateReport renders the ticket report in the requested format and returns it with its file name.
+// DOCX reports are filled from the tenant's active template while PDF reports use a fixed layout.
+func (s *reportService) GenerateReport(ctx context.Context, crmTicket domain.Ticket, format domain.ReportFormat) ([]byte, string, error) {
 	var memoryDoc bytes.Buffer
 
 	reportData, err := s.getReportData(ctx, crmTicket)
@@ -82,17 +87,73 @@ func (s *reportService) GenerateReport(ctx context.Context, crmTicket domain.Tic
 		return nil, "", err
 	}
 
-	filename, hasTemplate := tenantsTemplates[reportData.Tenant.CompanyName]
-	if !hasTemplate {
-		return nil, "", fmt.Errorf("no template found for tenant %s", reportData.Tenant.CompanyName)
+	commentImages, err := s.loadCommentImages(ctx, reportData.Comments)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch format {
+	case domain.PDF_FORMAT:
+		err = renderReportPDF(*reportData, commentImages, &memoryDoc)
+	default:
+		var template []byte
+		_, template, err = s.reportTemplateService.GetActiveTemplate(ctx, crmTicket.TenantID)
+		if err != nil {
+			return nil, "", err
+		}
+		err = s.readReportTemplate(*reportData, commentImages, template, &memoryDoc)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename := fmt.Sprintf("%s-%s-%s%s", reportData.Tenant.CompanyName, crmTicket.ExternalReference, time.Now().Format(timestampLayout), format.Extension())
+
+	return memoryDoc.Bytes(), filename, nil
+}
+
+// PreviewReport renders the given template version against a ticket, whatever its status, so a
+// template can be checked before it is activated.
+func (s *reportService) PreviewReport(ctx context.Context, reportID, ticketID string) ([]byte, string, error) {
+	if ticketID == "" {
+		return nil, "", domain.NewValidationError("ticket_id is required", nil)
+	}
+
+	crmTicket, err := s.ticketService.GetTicketByID(ctx, ticketID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	report, template, err := s.reportTemplateService.Download(ctx, reportID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if report.TenantID != crmTicket.TenantID {
+		return nil, "", domain.NewValidationError("ticket and report template belong to different tenants", map[string]any{
+			"ticket_id": ticketID,
+			"report_id": reportID,
+		})
+	}
+
+	reportData, err := s.getReportData(ctx, *crmTicket)
+	if err != nil {
+		return nil, "", err
 	}
 
-	err = s.readReportTemplate(ctx, *reportData, filename, &memoryDoc)
+	commentImages, err := s.loadCommentImages(ctx, reportData.Comments)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return memoryDoc.Bytes(), fmt.Sprintf("%s-%s-%s", reportData.Tenant.CompanyName, crmTicket.ExternalReference, time.Now().Format(timestampLayout)), nil
+	var memoryDoc bytes.Buffer
+	if err = s.readReportTemplate(*reportData, commentImages, template, &memoryDoc); err != nil {
+		return nil, "", err
+	}
+
+	filename := fmt.Sprintf("preview-%s-v%d-%s%s", report.ReportName, report.Version, crmTicket.ExternalReference, domain.DOCX_FORMAT.Extension())
+
+	return memoryDoc.Bytes(), filename, nil
 }
 
 func (s *reportService) getReportData(ctx context.Context, crmTicket domain.Ticket) (*ReportData, error) {
@@ -124,16 +185,33 @@ func (s *reportService) getReportData(ctx context.Context, crmTicket domain.Tick
 		if err != nil {
 			return err
 		}
-		reportData.Comments = comments
+		reportData.Comments = domain.VisibleComments(comments, reportAudience)
 		return nil
 	})
 
+	if crmTicket.LeadID != "" {
+		wg.Go(func() error {
+			lead, err := s.leadService.GetByID(newCtx, crmTicket.LeadID)
+			if err != nil {
+				return err
+			}
+			reportData.Lead = *lead
+			return nil
+		})
+	}
+
 	wg.Go(func() error {
-		lead, err := s.leadService.GetByID(newCtx, crmTicket.LeadID)
+		transactions, err := s.transactionService.SearchTransactions(newCtx, domain.TransactionFilters{
+			TicketIDs: []string{crmTicket.TicketID},
+			PagingFilter: domain.PagingFilter{
+				SortBy:        "created_at",
+				SortDirection: domain.ASC,
+			},
+		})
 		if err != nil {
 			return err
 		}
-		reportData.Lead = *lead
+		reportData.Transactions = transactions.Result
 		return nil
 	})
 
@@ -153,9 +231,8 @@ func (s *reportService) getReportData(ctx context.Context, crmTicket domain.Tick
 	return reportData, nil
 }
 
-func (s *reportService) readReportTemplate(ctx context.Context, reportData ReportData, filename string, memDoc io.Writer) error {
-	filePath := fmt.Sprintf("%s/%s", s.reportFolder, filename)
-	file, err := docx.ReadDocxFile(filePath)
+func (s *reportService) readReportTemplate(reportData ReportData, commentImages map[string][]reportImage, template []byte, memDoc io.Writer) error {
+	file, err := docx.ReadDocxFromMemory(bytes.NewReader(template), int64(len(template)))
 	if err != nil {
 		return err
 	}
@@ -163,122 +240,71 @@ func (s *reportService) readReportTemplate(ctx context.Context, reportData Repor
 	docEdit := file.Editable()
 	defer file.Close()
 
-	err = docEdit.Replace("$claim", reportData.CrmTicket.ExternalReference, -1)
+	content, renderer, err := renderReportTemplate(docEdit.GetContent(), reportTemplateContext(reportData, commentImages))
 	if err != nil {
 		return err
 	}
+	docEdit.SetContent(content)
 
-	err = docEdit.Replace("$actual_date", time.Now().Format(dateReportLayout), -1)
-	if err != nil {
-		return err
-	}
+	for _, placeholder := range reportPlaceholders {
+		if placeholder.Image {
+			continue
+		}
 
-	err = docEdit.Replace("$client", fmt.Sprintf("%s %s", reportData.Customer.FirstName, reportData.Customer.LastName), -1)
-	if err != nil {
-		return err
+		if err = docEdit.Replace(placeholder.Name, placeholder.text(reportData), -1); err != nil {
+			return err
+		}
 	}
 
-	err = docEdit.Replace("$brand", reportData.Product.Brand, -1)
-	if err != nil {
-		return err
+	images := renderer.placedImages()
+	documentContent := docEdit.GetContent()
+	for _, marker := range renderer.imageMarker {
+		documentContent = replacePlaceholderParagraph(documentContent, marker, imageParagraphs(renderer.images[marker]))
 	}
+	for _, placeholder := range reportPlaceholders {
+		if !placeholder.Image {
+			continue
+		}
 
-	err = docEdit.Replace("$summary", reportData.CrmTicket.Subject, -1)
-	if err != nil {
-		return err
+		sectionImages := placeholder.images(reportData, commentImages)
+		documentContent = replacePlaceholderParagraph(documentContent, placeholder.Name, imageParagraphs(sectionImages))
+		images = appendMissingImages(images, sectionImages)
 	}
-	err = docEdit.Replace("$lead", fmt.Sprintf("%s %s", reportData.Lead.FirstName, reportData.Lead.LastName), -1)
-	if err != nil {
-		return err
-	}
-	err = docEdit.Replace("$target_date", reportData.CrmTicket.TargetDate.Format(dateReportLayout), -1)
-	if err != nil {
-		return err
-	}
-	err = docEdit.Replace("$document", ParseDocument(reportData.Customer.Document), -1)
-	if err != nil {
-		return err
-	}
-	err = docEdit.Replace("$address", reportData.Customer.ShippingAddress.Address, -1)
-	if err != nil {
-		return err
-	}
-	err = docEdit.Replace("$zip_code", reportData.Customer.ShippingAddress.ZipCode, -1)
-	if err != nil {
-		return err
-	}
-	err = docEdit.Replace("$product", reportData.Product.Name, -1)
-	if err != nil {
-		return err
-	}
-	err = docEdit.Replace("$serial_number", reportData.Product.SerialNumber, -1)
-	if err != nil {
+	docEdit.SetContent(documentContent)
+
+	var document bytes.Buffer
+	if err = docEdit.Write(&document); err != nil {
 		return err
 	}
 
-	content := make([]string, 0)
-	contentAttachments := make([][]byte, 0)
-	comments := make([]string, 0)
-	commentsAttachments := make([][]byte, 0)
-	resolution := make([]string, 0)
-	resolutionAttachments := make([][]byte, 0)
-	for _, comment := range reportData.Comments {
-		switch comment.CommentType {
-		case domain.CONTENT:
-			contentAttachments, err = s.downloadFiles(ctx, comment.Attachments)
-			if err != nil {
-				return err
-			}
-			content = append(content, fmt.Sprintf("%s - %s", comment.CreatedAt.Format(dateTimeReportLayout), comment.Content))
-		case domain.RESOLUTION:
-			resolutionAttachments, err = s.downloadFiles(ctx, comment.Attachments)
-			if err != nil {
-				return err
-			}
-			resolution = append(resolution, fmt.Sprintf("%s - %s", comment.CreatedAt.Format(dateTimeReportLayout), comment.Content))
-		case domain.COMMENT:
-			commentsAttachments, err = s.downloadFiles(ctx, comment.Attachments)
-			if err != nil {
-				return err
-			}
-			comments = append(comments, fmt.Sprintf("%s - %s", comment.CreatedAt.Format(dateTimeReportLayout), comment.Content))
-		}
-	}
+	return addImagesToDocx(document.Bytes(), images, memDoc)
+}
 
-	err = docEdit.Replace("$content", strings.Join(content, "\r\n"), -1)
-	if err != nil {
-		return err
-	}
-	if len(contentAttachments) > 0 {
-		err = docEdit.Replace("$image_content", string(contentAttachments[0]), -1)
-		if err != nil {
-			return err
+// loadCommentImages downloads the attachments of the comments that go into reports and keeps the
+// ones that are images, keyed by comment ID and numbered in the order they appear.
+func (s *reportService) loadCommentImages(ctx context.Context, comments []domain.Comment) (map[string][]reportImage, error) {
+	commentImages := make(map[string][]reportImage)
+	imageCount := 0
+	for _, comment := range comments {
+		if !slices.Contains(reportCommentTypes, comment.CommentType) {
+			continue
 		}
-	}
 
-	err = docEdit.Replace("$comments", strings.Join(comments, "\r\n"), -1)
-	if err != nil {
-		return err
-	}
-	if len(commentsAttachments) > 0 {
-		err = docEdit.Replace("$image_comment", string(commentsAttachments[0]), -1)
+		files, err := s.downloadFiles(ctx, comment.Attachments)
 		if err != nil {
-			return err
+			return nil, err
 		}
-	}
 
-	err = docEdit.Replace("$resolution", strings.Join(resolution, "\r\n"), -1)
-	if err != nil {
-		return err
-	}
-	if len(resolutionAttachments) > 0 {
-		err = docEdit.Replace("$image_resolution", string(resolutionAttachments[0]), -1)
-		if err != nil {
-			return err
+		for i, file := range files {
+			caption := fmt.Sprintf("%s - %s", comment.Attachments[i].FileName, comment.CreatedAt.Format(dateTimeReportLayout))
+			if img, ok := newReportImage(imageCount+1, file, caption); ok {
+				imageCount++
+				commentImages[comment.CommentID] = append(commentImages[comment.CommentID], img)
+			}
 		}
 	}
 
-	return docEdit.Write(memDoc)
+	return commentImages, nil
 }
 
 func (s *reportService) downloadFiles(ctx context.Context, files []domain.Attachment) ([][]byte, error) {
@@ -293,3 +319,12 @@ func (s *reportService) downloadFiles(ctx context.Context, files []domain.Attach
 
 	return downloadedFiles, nil
 }
+
+func appendMissingImages(images []reportImage, newImages []reportImage) []reportImage {
+	for _, img := range newImages {
+		if !slices.ContainsFunc(images, func(existing reportImage) bool { return existing.index == img.index }) {
+			images = append(images, img)
+		}
+	}
+	return images
+}
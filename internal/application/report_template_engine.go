@@ -0,0 +1,610 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+// Report templates can use a small template language on top of the $ placeholders:
+//
+//	{{ticket.claim}}                     value from the template context
+//	{{ticket.target_date | date}}        value formatted by a filter
+//	{{#if lead}} ... {{else}} ... {{/if}} optional blocks
+//	{{#each comments}} ... {{/each}}     repeated blocks, where fields of the item are in scope
+//
+// Block tags alone in their paragraph take the whole paragraph, and alone in a table row take the
+// whole row. A {{#each}} opening in the first cell of a row and closing in the last one repeats the row.
+
+type templateNodeKind int
+
+const (
+	templateTextNode templateNodeKind = iota
+	templateValueNode
+	templateIfNode
+	templateEachNode
+)
+
+const templateImagesMarker = "$__report_images_%d"
+
+var (
+	templateTagPattern       = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+	templateRunTextPattern   = regexp.MustCompile(`(?s)<w:t(?:\s[^>]*)?>(.*?)</w:t>`)
+	templateParagraphPattern = regexp.MustCompile(`(?s)<w:p(?:\s[^>]*[^/])?>.*?</w:p>`)
+	templateRowPattern       = regexp.MustCompile(`(?s)<w:tr(?:\s[^>]*[^/])?>.*?</w:tr>`)
+)
+
+var templateFilters = map[string]func(value any) any{
+	"date":     func(value any) any { return formatTemplateTime(value, dateReportLayout) },
+	"datetime": func(value any) any { return formatTemplateTime(value, dateTimeReportLayout) },
+	"currency": func(value any) any {
+		if number, ok := value.(float64); ok {
+			return formatCurrency(number)
+		}
+		return value
+	},
+	"document": func(value any) any {
+		if document, ok := value.(string); ok {
+			return ParseDocument(document)
+		}
+		return value
+	},
+	"upper": func(value any) any {
+		if text, ok := value.(string); ok {
+			return strings.ToUpper(text)
+		}
+		return value
+	},
+	"lower": func(value any) any {
+		if text, ok := value.(string); ok {
+			return strings.ToLower(text)
+		}
+		return value
+	},
+}
+
+var reportCommentTypes = []domain.CommentType{domain.CONTENT, domain.COMMENT, domain.RESOLUTION}
+
+type templateNode struct {
+	kind         templateNodeKind
+	text         string
+	expression   string
+	children     []*templateNode
+	elseChildren []*templateNode
+	inElse       bool
+}
+
+type templateScope struct {
+	values map[string]any
+	parent *templateScope
+}
+
+type templateRenderer struct {
+	validating  bool
+	unknown     []string
+	images      map[string][]reportImage
+	imageMarker []string
+}
+
+// renderReportTemplate runs the template language over the document XML. The returned renderer
+// holds the images placed by the template, keyed by the marker written where they go.
+func renderReportTemplate(document string, context map[string]any) (string, *templateRenderer, error) {
+	nodes, err := parseReportTemplate(document)
+	if err != nil {
+		return "", nil, domain.NewValidationError("invalid report template", map[string]any{"error": err.Error()})
+	}
+
+	renderer := &templateRenderer{images: make(map[string][]reportImage)}
+	var output strings.Builder
+	renderer.render(nodes, &templateScope{values: context}, &output)
+
+	return output.String(), renderer, nil
+}
+
+// validateReportTemplateLanguage renders the template against sample data, visiting every branch,
+// and returns the tags that don't resolve to anything.
+func validateReportTemplateLanguage(document string) ([]string, error) {
+	nodes, err := parseReportTemplate(document)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer := &templateRenderer{validating: true, images: make(map[string][]reportImage)}
+	var output strings.Builder
+	renderer.render(nodes, &templateScope{values: reportTemplateContext(sampleReportData(), nil)}, &output)
+
+	return renderer.unknown, nil
+}
+
+func parseReportTemplate(document string) ([]*templateNode, error) {
+	document = expandRowLoops(normalizeTemplateParagraphs(document))
+
+	root := &templateNode{}
+	stack := []*templateNode{root}
+	add := func(node *templateNode) {
+		parent := stack[len(stack)-1]
+		if parent.inElse {
+			parent.elseChildren = append(parent.elseChildren, node)
+			return
+		}
+		parent.children = append(parent.children, node)
+	}
+
+	cursor := 0
+	for _, match := range templateTagPattern.FindAllStringSubmatchIndex(document, -1) {
+		tag := strings.TrimSpace(document[match[2]:match[3]])
+		start, end := match[0], match[1]
+		if isTemplateBlockTag(tag) {
+			start, end = templateBlockSpan(document, start, end)
+		}
+		if start < cursor {
+			start = cursor
+		}
+
+		add(&templateNode{kind: templateTextNode, text: document[cursor:start]})
+		cursor = end
+
+		current := stack[len(stack)-1]
+		switch {
+		case strings.HasPrefix(tag, "#if "):
+			node := &templateNode{kind: templateIfNode, expression: strings.TrimSpace(tag[len("#if "):])}
+			add(node)
+			stack = append(stack, node)
+		case strings.HasPrefix(tag, "#each "):
+			node := &templateNode{kind: templateEachNode, expression: strings.TrimSpace(tag[len("#each "):])}
+			add(node)
+			stack = append(stack, node)
+		case tag == "else":
+			if current.kind != templateIfNode || current.inElse {
+				return nil, errors.New("{{else}} outside of {{#if}}")
+			}
+			current.inElse = true
+		case tag == "/if":
+			if current.kind != templateIfNode {
+				return nil, errors.New("{{/if}} without a matching {{#if}}")
+			}
+			stack = stack[:len(stack)-1]
+		case tag == "/each":
+			if current.kind != templateEachNode {
+				return nil, errors.New("{{/each}} without a matching {{#each}}")
+			}
+			stack = stack[:len(stack)-1]
+		case strings.HasPrefix(tag, "#") || strings.HasPrefix(tag, "/"):
+			return nil, fmt.Errorf("unknown template block {{%s}}", tag)
+		default:
+			add(&templateNode{kind: templateValueNode, expression: tag})
+		}
+	}
+	add(&templateNode{kind: templateTextNode, text: document[cursor:]})
+
+	if len(stack) > 1 {
+		unclosed := stack[len(stack)-1]
+		return nil, fmt.Errorf("template block %q is never closed", unclosed.expression)
+	}
+
+	return root.children, nil
+}
+
+func isTemplateBlockTag(tag string) bool {
+	return strings.HasPrefix(tag, "#") || strings.HasPrefix(tag, "/") || tag == "else"
+}
+
+// normalizeTemplateParagraphs joins the runs of paragraphs where Word split a tag, so every tag
+// ends up inside a single text element. Formatting inside those paragraphs is lost.
+func normalizeTemplateParagraphs(document string) string {
+	return templateParagraphPattern.ReplaceAllStringFunc(document, func(paragraph string) string {
+		runs := templateRunTextPattern.FindAllStringSubmatch(paragraph, -1)
+
+		var text strings.Builder
+		tagsInRuns := 0
+		for _, run := range runs {
+			text.WriteString(run[1])
+			tagsInRuns += len(templateTagPattern.FindAllString(run[1], -1))
+		}
+		if tagsInRuns == len(templateTagPattern.FindAllString(text.String(), -1)) {
+			return paragraph
+		}
+
+		first := true
+		return templateRunTextPattern.ReplaceAllStringFunc(paragraph, func(string) string {
+			if first {
+				first = false
+				return `<w:t xml:space="preserve">` + text.String() + `</w:t>`
+			}
+			return "<w:t></w:t>"
+		})
+	})
+}
+
+// expandRowLoops moves a {{#each}} that opens and closes in the same table row around the row.
+func expandRowLoops(document string) string {
+	return templateRowPattern.ReplaceAllStringFunc(document, func(row string) string {
+		tags := templateTagPattern.FindAllStringSubmatchIndex(row, -1)
+		if len(tags) < 2 {
+			return row
+		}
+
+		first, last := tags[0], tags[len(tags)-1]
+		openTag := strings.TrimSpace(row[first[2]:first[3]])
+		if !strings.HasPrefix(openTag, "#each ") || strings.TrimSpace(row[last[2]:last[3]]) != "/each" {
+			return row
+		}
+
+		depth := 0
+		for _, tag := range tags[1 : len(tags)-1] {
+			switch name := strings.TrimSpace(row[tag[2]:tag[3]]); {
+			case strings.HasPrefix(name, "#each "):
+				depth++
+			case name == "/each":
+				depth--
+			}
+			if depth < 0 {
+				return row
+			}
+		}
+		if depth != 0 {
+			return row
+		}
+
+		body := row[:first[0]] + row[first[1]:last[0]] + row[last[1]:]
+		return "{{" + openTag + "}}" + body + "{{/each}}"
+	})
+}
+
+// templateBlockSpan widens a block tag to the paragraph or table row it is alone in, so the blocks
+// don't leave empty lines behind. Paragraphs inside table cells are kept, since cells can't be empty.
+func templateBlockSpan(document string, start, end int) (int, int) {
+	tag := document[start:end]
+
+	paragraphStart, paragraphEnd, ok := enclosingElement(document, start, end, "w:p")
+	if !ok || strings.TrimSpace(fragmentText(document[paragraphStart:paragraphEnd])) != tag {
+		return start, end
+	}
+
+	rowStart, rowEnd, ok := enclosingElement(document, paragraphStart, paragraphEnd, "w:tr")
+	if ok && strings.TrimSpace(fragmentText(document[rowStart:rowEnd])) == tag {
+		return rowStart, rowEnd
+	}
+
+	if _, _, inCell := enclosingElement(document, paragraphStart, paragraphEnd, "w:tc"); inCell {
+		return start, end
+	}
+
+	return paragraphStart, paragraphEnd
+}
+
+func enclosingElement(document string, start, end int, name string) (int, int, bool) {
+	open := max(strings.LastIndex(document[:start], "<"+name+">"), strings.LastIndex(document[:start], "<"+name+" "))
+	closeTag := "</" + name + ">"
+	if open < 0 || strings.Contains(document[open:start], closeTag) {
+		return 0, 0, false
+	}
+
+	closeIndex := strings.Index(document[end:], closeTag)
+	if closeIndex < 0 {
+		return 0, 0, false
+	}
+
+	return open, end + closeIndex + len(closeTag), true
+}
+
+func fragmentText(fragment string) string {
+	var text strings.Builder
+	for _, run := range templateRunTextPattern.FindAllStringSubmatch(fragment, -1) {
+		text.WriteString(run[1])
+	}
+	return text.String()
+}
+
+func (r *templateRenderer) render(nodes []*templateNode, scope *templateScope, output *strings.Builder) {
+	for _, node := range nodes {
+		switch node.kind {
+		case templateTextNode:
+			output.WriteString(node.text)
+		case templateValueNode:
+			output.WriteString(r.value(node.expression, scope))
+		case templateIfNode:
+			value, found := r.lookup(scope, node.expression)
+			if r.validating {
+				r.render(node.children, scope, output)
+				r.render(node.elseChildren, scope, output)
+				continue
+			}
+			if found && isTemplateTruthy(value) {
+				r.render(node.children, scope, output)
+			} else {
+				r.render(node.elseChildren, scope, output)
+			}
+		case templateEachNode:
+			value, _ := r.lookup(scope, node.expression)
+			items, ok := value.([]map[string]any)
+			if !ok {
+				r.reportUnknown(fmt.Sprintf("{{#each %s}}", node.expression))
+				continue
+			}
+			for i, item := range items {
+				values := maps.Clone(item)
+				values["this"] = item
+				values["@index"] = i
+				values["@number"] = i + 1
+				r.render(node.children, &templateScope{values: values, parent: scope}, output)
+			}
+		}
+	}
+}
+
+func (r *templateRenderer) value(expression string, scope *templateScope) string {
+	parts := strings.Split(expression, "|")
+	path := strings.TrimSpace(parts[0])
+
+	value, found := r.lookup(scope, path)
+	if !found {
+		return ""
+	}
+
+	for _, filterName := range parts[1:] {
+		filter, ok := templateFilters[strings.TrimSpace(filterName)]
+		if !ok {
+			r.reportUnknown(fmt.Sprintf("{{%s}}", expression))
+			continue
+		}
+		value = filter(value)
+	}
+
+	if images, ok := value.([]reportImage); ok {
+		marker := fmt.Sprintf(templateImagesMarker, len(r.imageMarker))
+		r.images[marker] = images
+		r.imageMarker = append(r.imageMarker, marker)
+		return marker
+	}
+
+	// lines are escaped one by one, since escapeXML would turn the line breaks into character references.
+	// Dollar signs are escaped too, so the $ placeholders replaced after rendering never match text
+	// written by users, like comments and customer names.
+	lines := strings.Split(strings.ReplaceAll(formatTemplateValue(value), "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(escapeXML(line), "$", "&#36;")
+	}
+	return strings.Join(lines, `</w:t><w:br/><w:t xml:space="preserve">`)
+}
+
+func (r *templateRenderer) lookup(scope *templateScope, path string) (any, bool) {
+	segments := strings.Split(path, ".")
+
+	var current any
+	found := false
+	for s := scope; s != nil; s = s.parent {
+		if value, ok := s.values[segments[0]]; ok {
+			current, found = value, true
+			break
+		}
+	}
+
+	for _, segment := range segments[1:] {
+		if !found {
+			break
+		}
+		fields, ok := current.(map[string]any)
+		if !ok {
+			found = current == nil && !r.validating
+			current = nil
+			break
+		}
+		current, found = fields[segment]
+	}
+
+	if !found {
+		r.reportUnknown(fmt.Sprintf("{{%s}}", path))
+	}
+	return current, found
+}
+
+func (r *templateRenderer) reportUnknown(tag string) {
+	if r.validating && !slices.Contains(r.unknown, tag) {
+		r.unknown = append(r.unknown, tag)
+	}
+}
+
+// placedImages returns the images in the order they were placed, each one once.
+func (r *templateRenderer) placedImages() []reportImage {
+	images := make([]reportImage, 0)
+	placed := make(map[int]bool)
+	for _, marker := range r.imageMarker {
+		for _, img := range r.images[marker] {
+			if !placed[img.index] {
+				placed[img.index] = true
+				images = append(images, img)
+			}
+		}
+	}
+	return images
+}
+
+func isTemplateTruthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case bool:
+		return v
+	case int:
+		return v != 0
+	case float64:
+		return v != 0
+	case *time.Time:
+		return v != nil
+	case time.Time:
+		return !v.IsZero()
+	case []map[string]any:
+		return len(v) > 0
+	case []reportImage:
+		return len(v) > 0
+	case map[string]any:
+		return v != nil
+	default:
+		return true
+	}
+}
+
+func formatTemplateValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time, *time.Time:
+		return formatTemplateTime(v, dateReportLayout).(string)
+	default:
+		return ""
+	}
+}
+
+func formatTemplateTime(value any, layout string) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(layout)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(layout)
+	default:
+		return value
+	}
+}
+
+func formatCurrency(value float64) string {
+	cents := int64(math.Round(math.Abs(value) * 100))
+	integer := strconv.FormatInt(cents/100, 10)
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	sign := ""
+	if value < 0 && cents > 0 {
+		sign = "-"
+	}
+
+	return fmt.Sprintf("%sR$ %s,%02d", sign, grouped.String(), cents%100)
+}
+
+func reportTemplateContext(reportData ReportData, commentImages map[string][]reportImage) map[string]any {
+	comments := make([]map[string]any, 0, len(reportData.Comments))
+	commentsByType := make(map[domain.CommentType][]map[string]any)
+	for _, commentType := range reportCommentTypes {
+		commentsByType[commentType] = make([]map[string]any, 0)
+	}
+	for _, comment := range reportData.Comments {
+		if _, ok := commentsByType[comment.CommentType]; !ok {
+			continue
+		}
+
+		item := map[string]any{
+			"type":       string(comment.CommentType),
+			"content":    comment.Content,
+			"created_by": comment.CreatedBy,
+			"created_at": comment.CreatedAt,
+			"images":     commentImages[comment.CommentID],
+		}
+		comments = append(comments, item)
+		commentsByType[comment.CommentType] = append(commentsByType[comment.CommentType], item)
+	}
+
+	transactions := make([]map[string]any, 0, len(reportData.Transactions))
+	for _, transaction := range reportData.Transactions {
+		transactions = append(transactions, map[string]any{
+			"type":        string(transaction.Type),
+			"status":      string(transaction.Status),
+			"value":       transaction.Value,
+			"description": transaction.Description,
+			"created_at":  transaction.CreatedAt,
+		})
+	}
+
+	var lead any
+	if reportData.CrmTicket.LeadID != "" {
+		lead = map[string]any{
+			"name":         strings.TrimSpace(fmt.Sprintf("%s %s", reportData.Lead.FirstName, reportData.Lead.LastName)),
+			"first_name":   reportData.Lead.FirstName,
+			"last_name":    reportData.Lead.LastName,
+			"company_name": reportData.Lead.CompanyName,
+			"document":     reportData.Lead.Document,
+		}
+	}
+
+	return map[string]any{
+		"date": time.Now(),
+		"tenant": map[string]any{
+			"name": reportData.Tenant.CompanyName,
+		},
+		"ticket": map[string]any{
+			"claim":       reportData.CrmTicket.ExternalReference,
+			"subject":     reportData.CrmTicket.Subject,
+			"status":      string(reportData.CrmTicket.Status),
+			"type":        reportData.CrmTicket.Type,
+			"priority":    string(reportData.CrmTicket.Priority),
+			"created_at":  reportData.CrmTicket.CreatedAt,
+			"target_date": reportData.CrmTicket.TargetDate,
+			"closed_at":   reportData.CrmTicket.ClosedAt,
+		},
+		"customer": map[string]any{
+			"name":         strings.TrimSpace(fmt.Sprintf("%s %s", reportData.Customer.FirstName, reportData.Customer.LastName)),
+			"first_name":   reportData.Customer.FirstName,
+			"last_name":    reportData.Customer.LastName,
+			"company_name": reportData.Customer.CompanyName,
+			"document":     reportData.Customer.Document,
+			"address":      reportData.Customer.ShippingAddress.Address,
+			"city":         reportData.Customer.ShippingAddress.City,
+			"state":        reportData.Customer.ShippingAddress.State,
+			"zip_code":     reportData.Customer.ShippingAddress.ZipCode,
+		},
+		"product": map[string]any{
+			"name":          reportData.Product.Name,
+			"brand":         reportData.Product.Brand,
+			"model":         reportData.Product.Model,
+			"serial_number": reportData.Product.SerialNumber,
+			"description":   reportData.Product.Description,
+			"value":         reportData.Product.Value,
+		},
+		"lead":                lead,
+		"comments":            comments,
+		"content_comments":    commentsByType[domain.CONTENT],
+		"general_comments":    commentsByType[domain.COMMENT],
+		"resolution_comments": commentsByType[domain.RESOLUTION],
+		"transactions":        transactions,
+	}
+}
+
+// sampleReportData has one of every list item, so validation can reach the fields inside loops.
+func sampleReportData() ReportData {
+	comments := make([]domain.Comment, 0, len(reportCommentTypes))
+	for _, commentType := range reportCommentTypes {
+		comments = append(comments, domain.Comment{CommentType: commentType})
+	}
+
+	return ReportData{
+		CrmTicket:    domain.Ticket{LeadID: "sample"},
+		Comments:     comments,
+		Transactions: []domain.Transaction{{}},
+	}
+}
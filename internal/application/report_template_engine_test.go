@@ -0,0 +1,216 @@
+package application
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderReportTemplate(t *testing.T) {
+	createdAt := time.Date(2026, time.March, 5, 14, 30, 0, 0, time.UTC)
+	context := map[string]any{
+		"title":  "Broken <TV> & co",
+		"note":   "first line\nsecond line",
+		"price":  "$client owes $10",
+		"ticket": map[string]any{"claim": "CLM-1", "created_at": createdAt, "target_date": (*time.Time)(nil)},
+		"lead":   nil,
+		"total":  1234.5,
+		"items": []map[string]any{
+			{"name": "screen", "value": 10.0},
+			{"name": "cable", "value": 2.5},
+		},
+		"empty": []map[string]any{},
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		want     string
+	}{
+		{name: "plain text", document: "no tags here", want: "no tags here"},
+		{name: "value", document: "Claim {{ticket.claim}}", want: "Claim CLM-1"},
+		{name: "spaces inside the tag", document: "{{ ticket.claim }}", want: "CLM-1"},
+		{name: "unknown value", document: "[{{ticket.nope}}]", want: "[]"},
+		{name: "field of a missing value", document: "[{{lead.name}}]", want: "[]"},
+		{name: "value is escaped", document: "{{title}}", want: "Broken &lt;TV&gt; &amp; co"},
+		{
+			name:     "line breaks",
+			document: "<w:t>{{note}}</w:t>",
+			want:     `<w:t>first line</w:t><w:br/><w:t xml:space="preserve">second line</w:t>`,
+		},
+		{name: "placeholders in values are escaped", document: "{{price}}", want: "&#36;client owes &#36;10"},
+		{name: "filter", document: "{{ticket.claim | lower}}", want: "clm-1"},
+		{name: "chained filters", document: "{{title | upper | lower}}", want: "broken &lt;tv&gt; &amp; co"},
+		{name: "date filter", document: "{{ticket.created_at | date}}", want: createdAt.Format(dateReportLayout)},
+		{name: "datetime filter", document: "{{ticket.created_at | datetime}}", want: createdAt.Format(dateTimeReportLayout)},
+		{name: "nil date", document: "[{{ticket.target_date | date}}]", want: "[]"},
+		{name: "currency filter", document: "{{total | currency}}", want: "R&#36; 1.234,50"},
+		{name: "if with a value", document: "{{#if ticket.claim}}yes{{else}}no{{/if}}", want: "yes"},
+		{name: "if without a value", document: "{{#if lead}}yes{{else}}no{{/if}}", want: "no"},
+		{name: "if with an empty list", document: "{{#if empty}}yes{{/if}}", want: ""},
+		{
+			name:     "each",
+			document: "{{#each items}}{{@number}}. {{name}} {{value | currency}};{{/each}}",
+			want:     "1. screen R&#36; 10,00;2. cable R&#36; 2,50;",
+		},
+		{
+			name:     "each reaches the outer scope",
+			document: "{{#each items}}{{ticket.claim}}-{{@index}} {{/each}}",
+			want:     "CLM-1-0 CLM-1-1 ",
+		},
+		{name: "each over an empty list", document: "[{{#each empty}}{{name}}{{/each}}]", want: "[]"},
+		{
+			name:     "nested blocks",
+			document: "{{#each items}}{{#if value}}{{name}}{{/if}}{{/each}}",
+			want:     "screencable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := renderReportTemplate(tt.document, context)
+			if err != nil {
+				t.Fatalf("renderReportTemplate(%q) unexpected error: %v", tt.document, err)
+			}
+			if got != tt.want {
+				t.Errorf("renderReportTemplate(%q) = %q, want %q", tt.document, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReportTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		wantErr  string
+	}{
+		{name: "unclosed if", document: "{{#if lead}}text", wantErr: "never closed"},
+		{name: "unclosed each", document: "{{#each items}}{{#if lead}}{{/if}}", wantErr: "never closed"},
+		{name: "else outside of if", document: "{{else}}", wantErr: "{{else}} outside of {{#if}}"},
+		{name: "second else", document: "{{#if lead}}{{else}}{{else}}{{/if}}", wantErr: "{{else}} outside of {{#if}}"},
+		{name: "closing if without opening", document: "{{/if}}", wantErr: "{{/if}} without a matching {{#if}}"},
+		{name: "closing the wrong block", document: "{{#each items}}{{/if}}", wantErr: "{{/if}} without a matching {{#if}}"},
+		{name: "closing each without opening", document: "{{/each}}", wantErr: "{{/each}} without a matching {{#each}}"},
+		{name: "unknown block", document: "{{#with lead}}{{/with}}", wantErr: "unknown template block {{#with lead}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseReportTemplate(tt.document)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseReportTemplate(%q) error = %v, want %q", tt.document, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpandRowLoops(t *testing.T) {
+	cell := func(text string) string {
+		return "<w:tc><w:p><w:r><w:t>" + text + "</w:t></w:r></w:p></w:tc>"
+	}
+	row := func(cells ...string) string {
+		return "<w:tr>" + strings.Join(cells, "") + "</w:tr>"
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		want     string
+	}{
+		{
+			name:     "loop across the row is moved around it",
+			document: row(cell("{{#each items}}{{name}}"), cell("{{value}}{{/each}}")),
+			want:     "{{#each items}}" + row(cell("{{name}}"), cell("{{value}}")) + "{{/each}}",
+		},
+		{
+			name:     "row without a loop",
+			document: row(cell("{{name}}"), cell("{{value}}")),
+			want:     row(cell("{{name}}"), cell("{{value}}")),
+		},
+		{
+			name:     "loop closing in another row",
+			document: row(cell("{{#each items}}{{name}}")) + row(cell("{{/each}}")),
+			want:     row(cell("{{#each items}}{{name}}")) + row(cell("{{/each}}")),
+		},
+		{
+			name:     "row opening with another block",
+			document: row(cell("{{#if lead}}{{name}}"), cell("{{/each}}")),
+			want:     row(cell("{{#if lead}}{{name}}"), cell("{{/each}}")),
+		},
+		{
+			name:     "inner loop of the row stays in its cell",
+			document: row(cell("{{#each items}}{{name}}"), cell("{{#each tags}}{{this}}{{/each}}{{/each}}")),
+			want:     "{{#each items}}" + row(cell("{{name}}"), cell("{{#each tags}}{{this}}{{/each}}")) + "{{/each}}",
+		},
+		{
+			name:     "unbalanced loops in the row",
+			document: row(cell("{{#each items}}{{/each}}{{/each}}"), cell("{{#each tags}}{{/each}}")),
+			want:     row(cell("{{#each items}}{{/each}}{{/each}}"), cell("{{#each tags}}{{/each}}")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandRowLoops(tt.document); got != tt.want {
+				t.Errorf("expandRowLoops() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateReportTemplateLanguage(t *testing.T) {
+	tests := []struct {
+		name        string
+		document    string
+		wantUnknown []string
+	}{
+		{name: "known tags", document: "{{ticket.claim}} {{customer.name | upper}}", wantUnknown: nil},
+		{name: "unknown value", document: "{{ticket.nope}}", wantUnknown: []string{"{{ticket.nope}}"}},
+		{name: "unknown filter", document: "{{ticket.claim | shout}}", wantUnknown: []string{"{{ticket.claim | shout}}"}},
+		{
+			name:        "every branch is checked",
+			document:    "{{#if lead}}{{lead.name}}{{else}}{{lead.nope}}{{/if}}",
+			wantUnknown: []string{"{{lead.nope}}"},
+		},
+		{
+			name:        "fields inside loops",
+			document:    "{{#each comments}}{{content}}{{missing}}{{/each}}",
+			wantUnknown: []string{"{{missing}}"},
+		},
+		{name: "loop over a value", document: "{{#each ticket}}{{/each}}", wantUnknown: []string{"{{#each ticket}}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unknown, err := validateReportTemplateLanguage(tt.document)
+			if err != nil {
+				t.Fatalf("validateReportTemplateLanguage(%q) unexpected error: %v", tt.document, err)
+			}
+			if !slices.Equal(unknown, tt.wantUnknown) {
+				t.Errorf("validateReportTemplateLanguage(%q) = %q, want %q", tt.document, unknown, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{value: 0, want: "R$ 0,00"},
+		{value: 0.5, want: "R$ 0,50"},
+		{value: 999.999, want: "R$ 1.000,00"},
+		{value: 1234567.8, want: "R$ 1.234.567,80"},
+		{value: -42.1, want: "-R$ 42,10"},
+		{value: -0.001, want: "R$ 0,00"},
+	}
+
+	for _, tt := range tests {
+		if got := formatCurrency(tt.value); got != tt.want {
+			t.Errorf("formatCurrency(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
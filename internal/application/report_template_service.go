@@ -0,0 +1,225 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/nguyenthenguyen/docx"
+)
+
+const docxContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+type reportTemplateService struct {
+	reportRepository domain.ReportRepository
+	attachmentBucket domain.AttachmentBucket
+}
+
+type ReportTemplateService interface {
+	Upload(ctx context.Context, tenantID, reportName, fileName string, content []byte, active bool, author string) (*domain.Report, ReportTemplateValidation, error)
+	GetByID(ctx context.Context, reportID string) (*domain.Report, error)
+	Search(ctx context.Context, filters domain.ReportFilters) (domain.PagingResult[domain.Report], error)
+	SetActive(ctx context.Context, reportID string, active bool, author string) error
+	Download(ctx context.Context, reportID string) (*domain.Report, []byte, error)
+	GetActiveTemplate(ctx context.Context, tenantID string) (*domain.Report, []byte, error)
+	Validate(ctx context.Context, reportID string) (ReportTemplateValidation, error)
+}
+
+func NewReportTemplateService(reportRepository domain.ReportRepository, attachmentBucket domain.AttachmentBucket) ReportTemplateService {
+	return &reportTemplateService{
+		reportRepository: reportRepository,
+		attachmentBucket: attachmentBucket,
+	}
+}
+
+// Upload stores a new version of the named template. Uploading the same name again for a tenant
+// creates the next version and keeps the previous ones available. Templates with placeholders that
+// can't be filled are rejected, while the missing ones are only reported back.
+func (s *reportTemplateService) Upload(ctx context.Context, tenantID, reportName, fileName string, content []byte, active bool, author string) (*domain.Report, ReportTemplateValidation, error) {
+	tenantID, err := domain.ResolveTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, ReportTemplateValidation{}, err
+	}
+
+	if tenantID == "" {
+		return nil, ReportTemplateValidation{}, domain.NewValidationError("tenantID is required", nil)
+	}
+
+	if err = validateReportTemplate(fileName, content); err != nil {
+		return nil, ReportTemplateValidation{}, err
+	}
+
+	validation, err := validateTemplatePlaceholders(content)
+	if err != nil {
+		return nil, ReportTemplateValidation{}, err
+	}
+
+	if !validation.Valid() {
+		return nil, validation, domain.NewValidationError("report template has placeholders that can't be filled", map[string]any{
+			"unknown": validation.Unknown,
+			"split":   validation.Split,
+			"syntax":  validation.Syntax,
+		})
+	}
+
+	if reportName == "" {
+		reportName = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	}
+
+	version, err := s.nextVersion(ctx, tenantID, reportName)
+	if err != nil {
+		return nil, ReportTemplateValidation{}, err
+	}
+
+	report, err := domain.NewReport(tenantID, reportName, fileName, len(content), version, author)
+	if err != nil {
+		return nil, ReportTemplateValidation{}, err
+	}
+
+	if err = s.attachmentBucket.Upload(ctx, report.ReportTemplate, bytes.NewReader(content), docxContentType); err != nil {
+		return nil, ReportTemplateValidation{}, err
+	}
+
+	if _, err = s.reportRepository.Create(ctx, report); err != nil {
+		return nil, ReportTemplateValidation{}, err
+	}
+
+	if active {
+		if err = s.SetActive(ctx, report.ReportID, true, author); err != nil {
+			return nil, ReportTemplateValidation{}, err
+		}
+		report.Active = true
+	}
+
+	return &report, validation, nil
+}
+
+func (s *reportTemplateService) GetByID(ctx context.Context, reportID string) (*domain.Report, error) {
+	if reportID == "" {
+		return nil, domain.NewValidationError("reportID cannot be empty", nil)
+	}
+
+	return s.reportRepository.GetByID(ctx, reportID)
+}
+
+func (s *reportTemplateService) Search(ctx context.Context, filters domain.ReportFilters) (domain.PagingResult[domain.Report], error) {
+	return s.reportRepository.Search(ctx, filters)
+}
+
+// SetActive switches the tenant's active template, deactivating whichever one was active before.
+func (s *reportTemplateService) SetActive(ctx context.Context, reportID string, active bool, author string) error {
+	report, err := s.GetByID(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	if active {
+		activeReports, err := s.reportRepository.Search(ctx, domain.ReportFilters{
+			TenantID: []string{report.TenantID},
+			Active:   &active,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, activeReport := range activeReports.Result {
+			if activeReport.ReportID == report.ReportID {
+				continue
+			}
+
+			activeReport.SetActive(false, author)
+			if err = s.reportRepository.Update(ctx, activeReport); err != nil {
+				return err
+			}
+		}
+	}
+
+	report.SetActive(active, author)
+
+	return s.reportRepository.Update(ctx, *report)
+}
+
+func (s *reportTemplateService) Download(ctx context.Context, reportID string) (*domain.Report, []byte, error) {
+	report, err := s.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := s.attachmentBucket.Download(ctx, report.ReportTemplate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return report, file, nil
+}
+
+func (s *reportTemplateService) GetActiveTemplate(ctx context.Context, tenantID string) (*domain.Report, []byte, error) {
+	active := true
+	activeReports, err := s.reportRepository.Search(ctx, domain.ReportFilters{
+		TenantID: []string{tenantID},
+		Active:   &active,
+		PagingFilter: domain.PagingFilter{
+			Limit:         1,
+			SortBy:        "updated_at",
+			SortDirection: domain.DESC,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(activeReports.Result) == 0 {
+		return nil, nil, domain.NewNotFoundError("no active report template found for this tenant", map[string]any{"tenant_id": tenantID})
+	}
+
+	return s.Download(ctx, activeReports.Result[0].ReportID)
+}
+
+func (s *reportTemplateService) Validate(ctx context.Context, reportID string) (ReportTemplateValidation, error) {
+	_, template, err := s.Download(ctx, reportID)
+	if err != nil {
+		return ReportTemplateValidation{}, err
+	}
+
+	return validateTemplatePlaceholders(template)
+}
+
+func (s *reportTemplateService) nextVersion(ctx context.Context, tenantID, reportName string) (int, error) {
+	latestReports, err := s.reportRepository.Search(ctx, domain.ReportFilters{
+		TenantID:   []string{tenantID},
+		ReportName: []string{reportName},
+		PagingFilter: domain.PagingFilter{
+			Limit:         1,
+			SortBy:        "version",
+			SortDirection: domain.DESC,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(latestReports.Result) == 0 {
+		return 1, nil
+	}
+
+	return latestReports.Result[0].Version + 1, nil
+}
+
+func validateReportTemplate(fileName string, content []byte) error {
+	if !strings.EqualFold(filepath.Ext(fileName), domain.ReportTemplateExtension) {
+		return domain.NewValidationError("report template must be a .docx file", map[string]any{"file_name": fileName})
+	}
+
+	if len(content) == 0 {
+		return domain.NewValidationError("report template is empty", map[string]any{"file_name": fileName})
+	}
+
+	templateFile, err := docx.ReadDocxFromMemory(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return domain.NewValidationError("report template is not a valid .docx file", map[string]any{"file_name": fileName})
+	}
+
+	return templateFile.Close()
+}
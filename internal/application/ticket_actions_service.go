@@ -7,27 +7,33 @@ import (
 )
 
 type ticketActionService struct {
-	ticketRepository domain.TicketRepository
-	commentService   CommentService
-	reportService    ReportService
+	ticketRepository     domain.TicketRepository
+	commentService       CommentService
+	reportService        ReportService
+	ticketHistoryService TicketHistoryService
+	notificationService  NotificationService
 }
 
 type TicketActionService interface {
 	ChangeOwner(ctx context.Context, ticketID string, newOwner domain.ChangeOwner) error
 	ChangeStatus(ctx context.Context, ticketID string, newStatus domain.ChangeStatus) error
 	ChangeLead(ctx context.Context, ticketID string, newLead domain.ChangeLead) error
-	GenerateReport(ctx context.Context, ticketID string) ([]byte, string, error)
+	GenerateReport(ctx context.Context, ticketID string, format domain.ReportFormat) ([]byte, string, error)
 }
 
 func NewTicketActionService(
 	ticketRepository domain.TicketRepository,
 	commentService CommentService,
 	reportService ReportService,
+	ticketHistoryService TicketHistoryService,
+	notificationService NotificationService,
 ) TicketActionService {
 	return &ticketActionService{
-		ticketRepository: ticketRepository,
-		commentService:   commentService,
-		reportService:    reportService,
+		ticketRepository:     ticketRepository,
+		commentService:       commentService,
+		reportService:        reportService,
+		ticketHistoryService: ticketHistoryService,
+		notificationService:  notificationService,
 	}
 }
 
@@ -37,14 +43,17 @@ func (c *ticketActionService) ChangeOwner(ctx context.Context, ticketID string,
 		return err
 	}
 
+	before := *crmTicket
 	ticketUpdate := domain.TicketUpdate{
 		OwnerID:   &newOwner.OwnerID,
-		Status:    &newOwner.Status,
+		Status:    optionalStatus(newOwner.Status),
 		UpdatedBy: newOwner.UpdatedBy,
 	}
-	crmTicket.MergeUpdate(ticketUpdate)
+	if err = crmTicket.MergeUpdate(ticketUpdate); err != nil {
+		return err
+	}
 
-	return c.ticketRepository.Update(ctx, *crmTicket)
+	return c.updateTicket(ctx, domain.OWNER_CHANGED, before, *crmTicket)
 }
 
 func (c *ticketActionService) ChangeStatus(ctx context.Context, ticketID string, newStatus domain.ChangeStatus) error {
@@ -53,11 +62,14 @@ func (c *ticketActionService) ChangeStatus(ctx context.Context, ticketID string,
 		return err
 	}
 
+	before := *crmTicket
 	ticketUpdate := domain.TicketUpdate{
 		Status:    &newStatus.Status,
 		UpdatedBy: newStatus.UpdatedBy,
 	}
-	crmTicket.MergeUpdate(ticketUpdate)
+	if err = crmTicket.MergeUpdate(ticketUpdate); err != nil {
+		return err
+	}
 
 	if newStatus.Content != nil {
 		err = c.createChangeStatusComment(ctx, ticketID, newStatus)
@@ -66,7 +78,7 @@ func (c *ticketActionService) ChangeStatus(ctx context.Context, ticketID string,
 		}
 	}
 
-	return c.ticketRepository.Update(ctx, *crmTicket)
+	return c.updateTicket(ctx, domain.STATUS_CHANGED, before, *crmTicket)
 }
 
 func (c *ticketActionService) ChangeLead(ctx context.Context, ticketID string, newLead domain.ChangeLead) error {
@@ -75,18 +87,21 @@ func (c *ticketActionService) ChangeLead(ctx context.Context, ticketID string, n
 		return err
 	}
 
+	before := *crmTicket
 	ticketUpdate := domain.TicketUpdate{
 		LeadID:     &newLead.LeadID,
-		Status:     &newLead.Status,
+		Status:     optionalStatus(newLead.Status),
 		TargetDate: &newLead.TargetDate,
 		UpdatedBy:  newLead.UpdatedBy,
 	}
-	crmTicket.MergeUpdate(ticketUpdate)
+	if err = crmTicket.MergeUpdate(ticketUpdate); err != nil {
+		return err
+	}
 
-	return c.ticketRepository.Update(ctx, *crmTicket)
+	return c.updateTicket(ctx, domain.LEAD_CHANGED, before, *crmTicket)
 }
 
-func (c *ticketActionService) GenerateReport(ctx context.Context, ticketID string) ([]byte, string, error) {
+func (c *ticketActionService) GenerateReport(ctx context.Context, ticketID string, format domain.ReportFormat) ([]byte, string, error) {
 	if ticketID == "" {
 		return nil, "", domain.NewValidationError("ticket_id is required", nil)
 	}
@@ -96,11 +111,21 @@ func (c *ticketActionService) GenerateReport(ctx context.Context, ticketID strin
 		return nil, "", err
 	}
 
-	if !slices.Contains([]domain.TicketStatus{domain.REPORT, domain.PAYMENT, domain.RECEIPT, domain.CLOSED}, crmTicket.Status) {
-		return nil, "", domain.NewValidationError("ticket is not in status REPORT", map[string]any{"status": crmTicket.Status})
+	if err = validateReportTicket(*crmTicket); err != nil {
+		return nil, "", err
+	}
+
+	return c.reportService.GenerateReport(ctx, *crmTicket, format)
+}
+
+func (c *ticketActionService) updateTicket(ctx context.Context, action domain.TicketAction, before, after domain.Ticket) error {
+	if err := c.ticketRepository.Update(ctx, after); err != nil {
+		return err
 	}
+	c.notificationService.NotifyTicketChange(ctx, before, after)
+	c.ticketHistoryService.Record(ctx, action, before, after)
 
-	return c.reportService.GenerateReport(ctx, *crmTicket)
+	return nil
 }
 
 func (c *ticketActionService) createChangeStatusComment(ctx context.Context, ticketID string, newStatus domain.ChangeStatus) error {
@@ -118,7 +143,23 @@ func (c *ticketActionService) createChangeStatusComment(ctx context.Context, tic
 	if err != nil {
 		return err
 	}
+	// what is written when moving a ticket forward is what its report is made of
+	newComment.Visibility = domain.TENANT_VISIBILITY
 
 	_, err = c.commentService.Create(ctx, newComment)
 	return err
 }
+
+func optionalStatus(status domain.TicketStatus) *domain.TicketStatus {
+	if status == "" {
+		return nil
+	}
+	return &status
+}
+
+func validateReportTicket(crmTicket domain.Ticket) error {
+	if !slices.Contains([]domain.TicketStatus{domain.REPORT, domain.PAYMENT, domain.RECEIPT, domain.CLOSED}, crmTicket.Status) {
+		return domain.NewValidationError("ticket is not in status REPORT", map[string]any{"status": crmTicket.Status})
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/repository/memory"
+)
+
+type failingTicketHistoryRepository struct {
+	domain.TicketHistoryRepository
+}
+
+func (r failingTicketHistoryRepository) Create(ctx context.Context, event domain.TicketEvent) (string, error) {
+	return "", errors.New("history is unavailable")
+}
+
+func TestTicketActionServiceChangeOwner(t *testing.T) {
+	tests := []struct {
+		name              string
+		historyRepository domain.TicketHistoryRepository
+		wantEvents        int
+	}{
+		{name: "records the change", historyRepository: memory.NewTicketHistoryRepository(), wantEvents: 1},
+		{name: "history failures don't fail the saved change", historyRepository: failingTicketHistoryRepository{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := callerContext("admin", domain.ADMIN)
+			ticketRepository := memory.NewTicketRepository()
+			crmTicket := domain.Ticket{TicketID: "ticket", TenantID: userTestTenant, Status: domain.NEW}
+			if _, err := ticketRepository.Create(ctx, crmTicket); err != nil {
+				t.Fatalf("Create unexpected error: %v", err)
+			}
+
+			service := NewTicketActionService(
+				ticketRepository,
+				nil,
+				nil,
+				NewTicketHistoryService(tt.historyRepository),
+				NewNotificationService(memory.NewNotificationRepository(), ticketRepository, 0),
+			)
+
+			err := service.ChangeOwner(ctx, crmTicket.TicketID, domain.ChangeOwner{OwnerID: "owner", Status: domain.CUSTOMER_INFO})
+			if err != nil {
+				t.Fatalf("ChangeOwner unexpected error: %v", err)
+			}
+
+			saved, err := ticketRepository.GetByID(ctx, crmTicket.TicketID)
+			if err != nil {
+				t.Fatalf("GetByID unexpected error: %v", err)
+			}
+			if saved.OwnerID != "owner" || saved.Status != domain.CUSTOMER_INFO {
+				t.Errorf("ticket owner %q and status %s, want %q and %s", saved.OwnerID, saved.Status, "owner", domain.CUSTOMER_INFO)
+			}
+
+			if tt.wantEvents == 0 {
+				return
+			}
+			events, err := tt.historyRepository.Search(ctx, domain.TicketHistoryFilters{TicketID: crmTicket.TicketID})
+			if err != nil {
+				t.Fatalf("Search unexpected error: %v", err)
+			}
+			if len(events.Result) != tt.wantEvents || events.Result[0].Action != domain.OWNER_CHANGED || events.Result[0].CreatedBy != "admin" {
+				t.Errorf("events = %+v, want %d %s event by admin", events.Result, tt.wantEvents, domain.OWNER_CHANGED)
+			}
+		})
+	}
+}
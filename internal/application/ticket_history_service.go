@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"log"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ticketHistoryService struct {
+	ticketHistoryRepository domain.TicketHistoryRepository
+}
+
+type TicketHistoryService interface {
+	Record(ctx context.Context, action domain.TicketAction, before, after domain.Ticket)
+	GetByTicketID(ctx context.Context, filters domain.TicketHistoryFilters) (domain.PagingResult[domain.TicketEvent], error)
+}
+
+func NewTicketHistoryService(ticketHistoryRepository domain.TicketHistoryRepository) TicketHistoryService {
+	return &ticketHistoryService{
+		ticketHistoryRepository: ticketHistoryRepository,
+	}
+}
+
+// Record saves what changed between both versions of the ticket. The ticket is already saved, so
+// failures are only logged.
+func (s *ticketHistoryService) Record(ctx context.Context, action domain.TicketAction, before, after domain.Ticket) {
+	changes := domain.DiffTickets(before, after)
+	if len(changes) == 0 {
+		return
+	}
+
+	author := domain.UserIDFromContext(ctx)
+	if author == "" {
+		author = after.UpdatedBy
+	}
+
+	event, err := domain.NewTicketEvent(after.TicketID, after.TenantID, action, changes, author)
+	if err != nil {
+		log.Printf("failed to create %s event of ticket %s: %v", action, after.TicketID, err)
+		return
+	}
+
+	if _, err = s.ticketHistoryRepository.Create(ctx, event); err != nil {
+		log.Printf("failed to save %s event of ticket %s: %v", action, after.TicketID, err)
+	}
+}
+
+func (s *ticketHistoryService) GetByTicketID(ctx context.Context, filters domain.TicketHistoryFilters) (domain.PagingResult[domain.TicketEvent], error) {
+	if filters.TicketID == "" {
+		return domain.PagingResult[domain.TicketEvent]{}, domain.NewValidationError("ticketID is required", nil)
+	}
+
+	return s.ticketHistoryRepository.Search(ctx, filters)
+}
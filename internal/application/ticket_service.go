@@ -9,10 +9,12 @@ import (
 )
 
 type ticketService struct {
-	customerService  CustomerService
-	userService      UserService
-	ticketRepository domain.TicketRepository
-	productService   ProductService
+	customerService      CustomerService
+	userService          UserService
+	ticketRepository     domain.TicketRepository
+	productService       ProductService
+	ticketHistoryService TicketHistoryService
+	notificationService  NotificationService
 }
 
 type TicketService interface {
@@ -27,21 +29,41 @@ func NewTicketService(
 	ticketRepository domain.TicketRepository,
 	productService ProductService,
 	userService UserService,
+	ticketHistoryService TicketHistoryService,
+	notificationService NotificationService,
 ) TicketService {
 	return &ticketService{
-		customerService:  customerService,
-		ticketRepository: ticketRepository,
-		productService:   productService,
-		userService:      userService,
+		customerService:      customerService,
+		ticketRepository:     ticketRepository,
+		productService:       productService,
+		userService:          userService,
+		ticketHistoryService: ticketHistoryService,
+		notificationService:  notificationService,
 	}
 }
 
 func (c *ticketService) CreateTicket(ctx context.Context, newTicket domain.CreateTicket) (string, error) {
 	crmTicket := newTicket.Ticket
+
+	tenantID, err := domain.ResolveTenantID(ctx, crmTicket.TenantID)
+	if err != nil {
+		return "", err
+	}
+	if tenantID == "" {
+		return "", domain.NewValidationError("tenant_id is required", nil)
+	}
+	crmTicket.TenantID = tenantID
+
 	customer, err := c.customerService.GetByID(ctx, crmTicket.CustomerID)
 	if err != nil {
 		return "", err
 	}
+	if customer.TenantID != crmTicket.TenantID {
+		return "", domain.NewValidationError("customer does not belong to the ticket tenant", map[string]any{
+			"customer_id": customer.CustomerID,
+			"tenant_id":   crmTicket.TenantID,
+		})
+	}
 	crmTicket.Region = customer.GetRegion()
 
 	err = c.assignOwnerToNewTicket(ctx, &crmTicket)
@@ -49,6 +71,7 @@ func (c *ticketService) CreateTicket(ctx context.Context, newTicket domain.Creat
 		return "", err
 	}
 
+	newTicket.Product.TenantID = crmTicket.TenantID
 	productID, err := c.productService.CreateProduct(ctx, newTicket.Product)
 	if err != nil {
 		return "", err
@@ -59,6 +82,9 @@ func (c *ticketService) CreateTicket(ctx context.Context, newTicket domain.Creat
 	if err != nil {
 		return "", err
 	}
+	c.notificationService.NotifyTicketChange(ctx, domain.Ticket{}, crmTicket)
+
+	c.ticketHistoryService.Record(ctx, domain.TICKET_CREATED, domain.Ticket{}, crmTicket)
 
 	return ticketID, nil
 }
@@ -89,9 +115,9 @@ func (c *ticketService) assignOwnerToNewTicket(ctx context.Context, crmTicket *d
 		}
 	}
 
-	if len(user) > 0 {
-		crmTicket.OwnerID = user[0].UserID
-		crmTicket.Status = domain.CUSTOMER_INFO
+	if len(user.Result) > 0 {
+		crmTicket.OwnerID = user.Result[0].UserID
+		return crmTicket.TransitionTo(domain.CUSTOMER_INFO)
 	}
 
 	return nil
@@ -107,7 +133,21 @@ func (c *ticketService) UpdateTicket(ctx context.Context, ticketID string, newTi
 		return err
 	}
 
-	crmTicket.MergeUpdate(newTicket)
+	before := *crmTicket
+	if err = crmTicket.MergeUpdate(newTicket); err != nil {
+		return err
+	}
+
+	role := domain.UserRoleFromContext(ctx)
+	if crmTicket.OwnerID != before.OwnerID && !role.Can(domain.TICKET_RESOURCE, domain.CHANGE_OWNER_ACTION) {
+		return domain.NewForbiddenError("user is not allowed to change the ticket owner", map[string]any{"role": role})
+	}
 
-	return c.ticketRepository.Update(ctx, *crmTicket)
+	if err = c.ticketRepository.Update(ctx, *crmTicket); err != nil {
+		return err
+	}
+	c.notificationService.NotifyTicketChange(ctx, before, *crmTicket)
+	c.ticketHistoryService.Record(ctx, domain.TICKET_UPDATED, before, *crmTicket)
+
+	return nil
 }
@@ -8,22 +8,30 @@ import (
 
 type transactionService struct {
 	transactionRepository domain.TransactionRepository
+	ticketRepository      domain.TicketRepository
 }
 
 type TransactionService interface {
 	CreateTransaction(ctx context.Context, transaction domain.Transaction) (string, error)
 	GetTransaction(ctx context.Context, transactionID string) (domain.Transaction, error)
 	UpdateTransaction(ctx context.Context, transactionID string, transactionUpdate domain.TransactionUpdate) error
-	SearchTransactions(ctx context.Context, filters domain.TransactionFilters) ([]domain.Transaction, error)
+	SearchTransactions(ctx context.Context, filters domain.TransactionFilters) (domain.PagingResult[domain.Transaction], error)
 }
 
-func NewTransactionService(transactionRepository domain.TransactionRepository) TransactionService {
+func NewTransactionService(transactionRepository domain.TransactionRepository, ticketRepository domain.TicketRepository) TransactionService {
 	return &transactionService{
 		transactionRepository: transactionRepository,
+		ticketRepository:      ticketRepository,
 	}
 }
 
 func (s *transactionService) CreateTransaction(ctx context.Context, transaction domain.Transaction) (string, error) {
+	crmTicket, err := s.ticketRepository.GetByID(ctx, transaction.TicketID)
+	if err != nil {
+		return "", err
+	}
+	transaction.TenantID = crmTicket.TenantID
+
 	return s.transactionRepository.CreateTransaction(ctx, transaction)
 }
 
@@ -50,6 +58,6 @@ func (s *transactionService) UpdateTransaction(ctx context.Context, transactionI
 	return s.transactionRepository.UpdateTransaction(ctx, transaction)
 }
 
-func (s *transactionService) SearchTransactions(ctx context.Context, filters domain.TransactionFilters) ([]domain.Transaction, error) {
+func (s *transactionService) SearchTransactions(ctx context.Context, filters domain.TransactionFilters) (domain.PagingResult[domain.Transaction], error) {
 	return s.transactionRepository.SearchTransactions(ctx, filters)
 }
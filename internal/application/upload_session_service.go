@@ -0,0 +1,200 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type uploadSessionService struct {
+	uploadSessionRepository domain.UploadSessionRepository
+	commentRepository       domain.CommentRepository
+	attachmentRepository    domain.AttachmentRepository
+	attachmentBucket        domain.AttachmentBucket
+	attachmentValidator     AttachmentValidator
+	imageProcessor          AttachmentImageProcessor
+	uploadURLTTL            time.Duration
+	downloadURLTTL          time.Duration
+}
+
+type UploadSessionService interface {
+	Create(ctx context.Context, commentID string, fileName string, contentType string, size int, visibility domain.Visibility, author string) (*domain.UploadSession, string, error)
+	GetByID(ctx context.Context, sessionID string) (*domain.UploadSession, error)
+	Complete(ctx context.Context, sessionID string) (*domain.Attachment, error)
+}
+
+func NewUploadSessionService(
+	uploadSessionRepository domain.UploadSessionRepository,
+	commentRepository domain.CommentRepository,
+	attachmentRepository domain.AttachmentRepository,
+	attachmentBucket domain.AttachmentBucket,
+	attachmentValidator AttachmentValidator,
+	imageProcessor AttachmentImageProcessor,
+	uploadURLTTL time.Duration,
+	downloadURLTTL time.Duration,
+) UploadSessionService {
+	return &uploadSessionService{
+		uploadSessionRepository: uploadSessionRepository,
+		commentRepository:       commentRepository,
+		attachmentRepository:    attachmentRepository,
+		attachmentBucket:        attachmentBucket,
+		attachmentValidator:     attachmentValidator,
+		imageProcessor:          imageProcessor,
+		uploadURLTTL:            uploadURLTTL,
+		downloadURLTTL:          downloadURLTTL,
+	}
+}
+
+// Create reserves a key for a file to be attached to the comment and returns the presigned URL the
+// client uploads it to. The file is checked against the tenant's attachment policy first, and the URL
+// only accepts the declared size and the content type of the file extension.
+func (s *uploadSessionService) Create(ctx context.Context, commentID string, fileName string, contentType string, size int, visibility domain.Visibility, author string) (*domain.UploadSession, string, error) {
+	if fileName == "" {
+		return nil, "", domain.NewValidationError("file_name is required", nil)
+	}
+
+	comment, err := s.commentRepository.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if comment.IsDeleted() {
+		return nil, "", domain.NewNotFoundError("no comment found with this id", map[string]any{"comment_id": commentID})
+	}
+
+	policy, err := s.attachmentValidator.Policy(ctx, comment.TenantID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	contentType, err = s.attachmentValidator.ValidateFile(policy, fileName, contentType, size)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err = s.attachmentValidator.ValidateTicketSize(ctx, policy, comment.TicketID, size); err != nil {
+		return nil, "", err
+	}
+
+	session, err := domain.NewUploadSession(*comment, fileName, contentType, size, visibility, author, s.uploadURLTTL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	uploadURL, err := s.attachmentBucket.PresignUpload(ctx, session.Key, session.ContentType, session.Size, s.uploadURLTTL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err = s.uploadSessionRepository.Create(ctx, session); err != nil {
+		return nil, "", err
+	}
+
+	return &session, uploadURL, nil
+}
+
+func (s *uploadSessionService) GetByID(ctx context.Context, sessionID string) (*domain.UploadSession, error) {
+	if sessionID == "" {
+		return nil, domain.NewValidationError("sessionID cannot be empty", nil)
+	}
+
+	return s.uploadSessionRepository.GetByID(ctx, sessionID)
+}
+
+// Complete checks the file is in the bucket with the declared size and passes the content validation
+// before attaching it to the comment, with a thumbnail when it's an image; rejected files are removed
+// from the bucket. Completing a session twice returns the attachment created the first time.
+func (s *uploadSessionService) Complete(ctx context.Context, sessionID string) (*domain.Attachment, error) {
+	session, err := s.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status == domain.UPLOAD_SESSION_COMPLETED {
+		return s.signedAttachment(ctx, session.AttachmentID)
+	}
+
+	object, err := s.attachmentBucket.Stat(ctx, session.Key)
+	if err != nil {
+		var customErr *domain.CustomError
+		if !errors.As(err, &customErr) || !customErr.IsNotFound() {
+			return nil, err
+		}
+
+		if time.Now().After(session.ExpiresAt) {
+			return nil, domain.NewValidationError("upload session expired before the file was uploaded", map[string]any{"session_id": sessionID})
+		}
+		return nil, domain.NewConflictError("file was not uploaded yet", map[string]any{"session_id": sessionID})
+	}
+
+	if object.Size != session.Size {
+		if err = s.attachmentBucket.Delete(ctx, session.Key); err != nil {
+			return nil, err
+		}
+		return nil, domain.NewValidationError("uploaded file doesn't match the declared size", map[string]any{
+			"expected": session.Size,
+			"received": object.Size,
+		})
+	}
+
+	if err = s.validateUploadedFile(ctx, *session); err != nil {
+		if deleteErr := s.attachmentBucket.Delete(ctx, session.Key); deleteErr != nil {
+			return nil, deleteErr
+		}
+		return nil, err
+	}
+
+	attachment, err := domain.NewAttachment(session.FileName, "", session.FileExtension, session.Key, session.CreatedBy, object.Size)
+	if err != nil {
+		return nil, err
+	}
+	attachment.CommentID = session.CommentID
+	attachment.TenantID = session.TenantID
+	attachment.ContentType = session.ContentType
+	attachment.Visibility = session.Visibility
+
+	if err = s.imageProcessor.ProcessStored(ctx, &attachment); err != nil {
+		return nil, err
+	}
+
+	if err = s.attachmentRepository.Save(ctx, attachment); err != nil {
+		s.imageProcessor.DeleteThumbnails(ctx, []domain.Attachment{attachment})
+		return nil, err
+	}
+
+	session.Complete(attachment.AttachmentID)
+	if err = s.uploadSessionRepository.Update(ctx, *session); err != nil {
+		return nil, err
+	}
+
+	return s.signedAttachment(ctx, attachment.AttachmentID)
+}
+
+func (s *uploadSessionService) validateUploadedFile(ctx context.Context, session domain.UploadSession) error {
+	policy, err := s.attachmentValidator.Policy(ctx, session.TenantID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.attachmentValidator.ValidateStoredFile(ctx, policy, session.Key, session.FileName); err != nil {
+		return err
+	}
+
+	return s.attachmentValidator.ValidateTicketSize(ctx, policy, session.TicketID, session.Size)
+}
+
+func (s *uploadSessionService) signedAttachment(ctx context.Context, attachmentID string) (*domain.Attachment, error) {
+	attachment, err := s.attachmentRepository.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := []domain.Attachment{attachment}
+	if err = signAttachmentURLs(ctx, s.attachmentBucket, attachments, s.downloadURLTTL); err != nil {
+		return nil, err
+	}
+
+	return &attachments[0], nil
+}
@@ -0,0 +1,199 @@
+package application
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/repository/memory"
+)
+
+func newUploadSessionTestService(t *testing.T) (UploadSessionService, attachmentTestFixture, string) {
+	t.Helper()
+
+	fixture := newAttachmentTestFixture(t, domain.AttachmentPolicy{MaxFileSize: 1000})
+	ctx := callerContext(commentTestAuthor, domain.OPERATOR)
+
+	comment, err := domain.NewComment("ticket", "with files", commentTestAuthor, domain.CONTENT, nil)
+	if err != nil {
+		t.Fatalf("NewComment unexpected error: %v", err)
+	}
+	comment.TenantID = userTestTenant
+	if _, err = fixture.commentRepository.Create(ctx, comment); err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	service := NewUploadSessionService(
+		memory.NewUploadSessionRepository(),
+		fixture.commentRepository,
+		fixture.attachmentRepository,
+		fixture.attachmentBucket,
+		fixture.validator,
+		NewAttachmentImageProcessor(fixture.attachmentBucket, 16),
+		time.Minute,
+		time.Minute,
+	)
+
+	return service, fixture, comment.CommentID
+}
+
+// uploadToPresignedURL sends the file the way a client does, through the presigned URL served by the
+// file system bucket.
+func uploadToPresignedURL(t *testing.T, fixture attachmentTestFixture, uploadURL string, contentType string, content []byte) int {
+	t.Helper()
+
+	request := httptest.NewRequest(http.MethodPut, uploadURL, bytes.NewReader(content))
+	request.Header.Set("Content-Type", contentType)
+	response := httptest.NewRecorder()
+	fixture.attachmentBucket.(http.Handler).ServeHTTP(response, request)
+
+	return response.Code
+}
+
+func TestUploadSessionServiceCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		commentID   string
+		fileName    string
+		contentType string
+		size        int
+		wantStatus  int
+	}{
+		{name: "accepted file", fileName: "photo.png", contentType: "image/png", size: 100},
+		{name: "no file name", contentType: "image/png", size: 100, wantStatus: http.StatusBadRequest},
+		{name: "file type not accepted", fileName: "script.sh", size: 100, wantStatus: http.StatusBadRequest},
+		{name: "content type doesn't match the extension", fileName: "photo.png", contentType: "text/html", size: 100, wantStatus: http.StatusBadRequest},
+		{name: "file above the size limit", fileName: "photo.png", contentType: "image/png", size: 1001, wantStatus: http.StatusBadRequest},
+		{name: "unknown comment", commentID: "unknown", fileName: "photo.png", contentType: "image/png", size: 100, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, commentID := newUploadSessionTestService(t)
+			if tt.commentID != "" {
+				commentID = tt.commentID
+			}
+
+			session, uploadURL, err := service.Create(callerContext(commentTestAuthor, domain.OPERATOR), commentID, tt.fileName, tt.contentType, tt.size, "", commentTestAuthor)
+			assertStatus(t, err, tt.wantStatus)
+			if tt.wantStatus != 0 {
+				return
+			}
+
+			if uploadURL == "" || session.Status != domain.UPLOAD_SESSION_PENDING || session.Size != tt.size {
+				t.Errorf("session = %+v with URL %q, want a pending session of %d bytes", session, uploadURL, tt.size)
+			}
+			if session.Key != domain.AttachmentKeyPrefix(userTestTenant, "ticket")+session.SessionID+".png" {
+				t.Errorf("key %q is outside of the ticket's folder", session.Key)
+			}
+		})
+	}
+}
+
+func TestUploadSessionServiceComplete(t *testing.T) {
+	pngFile := pngTestFile(t)
+	disguisedFile := bytes.Repeat([]byte("<html>"), len(pngFile))[:len(pngFile)]
+
+	tests := []struct {
+		name           string
+		uploadedFile   []byte
+		skipUpload     bool
+		wantStatus     int
+		wantFileStored bool
+	}{
+		{name: "uploaded file", uploadedFile: pngFile, wantFileStored: true},
+		{name: "file not uploaded yet", skipUpload: true, wantStatus: http.StatusConflict},
+		{name: "uploaded size doesn't match the declared one", uploadedFile: append(pngFile, 0), wantStatus: http.StatusBadRequest},
+		{name: "uploaded content doesn't match the file name", uploadedFile: disguisedFile, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, fixture, commentID := newUploadSessionTestService(t)
+			ctx := callerContext(commentTestAuthor, domain.OPERATOR)
+
+			session, uploadURL, err := service.Create(ctx, commentID, "photo.png", "image/png", len(pngFile), "", commentTestAuthor)
+			if err != nil {
+				t.Fatalf("Create unexpected error: %v", err)
+			}
+
+			if !tt.skipUpload {
+				// files that differ from the declared one are written straight to the bucket, since
+				// the presigned URL refuses them
+				if len(tt.uploadedFile) == session.Size {
+					if code := uploadToPresignedURL(t, fixture, uploadURL, session.ContentType, tt.uploadedFile); code != http.StatusOK {
+						t.Fatalf("upload to the presigned URL returned %d", code)
+					}
+				} else if err = fixture.attachmentBucket.Upload(ctx, session.Key, bytes.NewReader(tt.uploadedFile), session.ContentType); err != nil {
+					t.Fatalf("Upload unexpected error: %v", err)
+				}
+			}
+
+			attachment, err := service.Complete(ctx, session.SessionID)
+			assertStatus(t, err, tt.wantStatus)
+
+			_, statErr := os.Stat(filepath.Join(fixture.bucketFolder, filepath.FromSlash(session.Key)))
+			if stored := statErr == nil; stored != tt.wantFileStored {
+				t.Errorf("file stored = %v, want %v", stored, tt.wantFileStored)
+			}
+			if tt.wantStatus != 0 {
+				return
+			}
+
+			if attachment.CommentID != commentID || attachment.Size != len(pngFile) || attachment.CreatedBy != commentTestAuthor || attachment.AttachmentURL == "" {
+				t.Errorf("attachment = %+v, want a signed attachment of %d bytes by %q", attachment, len(pngFile), commentTestAuthor)
+			}
+
+			completed, err := service.GetByID(ctx, session.SessionID)
+			if err != nil {
+				t.Fatalf("GetByID unexpected error: %v", err)
+			}
+			if completed.Status != domain.UPLOAD_SESSION_COMPLETED || completed.AttachmentID != attachment.AttachmentID {
+				t.Errorf("session = %+v, want completed with attachment %q", completed, attachment.AttachmentID)
+			}
+
+			again, err := service.Complete(ctx, session.SessionID)
+			if err != nil {
+				t.Fatalf("second Complete unexpected error: %v", err)
+			}
+			if again.AttachmentID != attachment.AttachmentID {
+				t.Errorf("second Complete returned attachment %q, want %q", again.AttachmentID, attachment.AttachmentID)
+			}
+		})
+	}
+}
+
+func TestUploadSessionPresignedURLRefusesOtherFiles(t *testing.T) {
+	pngFile := pngTestFile(t)
+
+	tests := []struct {
+		name        string
+		contentType string
+		content     []byte
+		wantCode    int
+	}{
+		{name: "declared file", contentType: "image/png", content: pngFile, wantCode: http.StatusOK},
+		{name: "bigger file", contentType: "image/png", content: append(pngFile, 0), wantCode: http.StatusBadRequest},
+		{name: "other content type", contentType: "text/html", content: pngFile, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, fixture, commentID := newUploadSessionTestService(t)
+
+			_, uploadURL, err := service.Create(callerContext(commentTestAuthor, domain.OPERATOR), commentID, "photo.png", "image/png", len(pngFile), "", commentTestAuthor)
+			if err != nil {
+				t.Fatalf("Create unexpected error: %v", err)
+			}
+
+			if code := uploadToPresignedURL(t, fixture, uploadURL, tt.contentType, tt.content); code != tt.wantCode {
+				t.Errorf("upload returned %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -12,10 +12,11 @@ type userService struct {
 
 type UserService interface {
 	Create(ctx context.Context, user domain.User) (string, error)
+	EnsurePlatformAdmin(ctx context.Context, tenantID, email, password string) error
 	GetByID(ctx context.Context, id string) (*domain.User, error)
-	Update(ctx context.Context, user domain.User) error
+	Update(ctx context.Context, userID string, userUpdate domain.UserUpdate, author string) error
 	Delete(ctx context.Context, id string) error
-	Search(ctx context.Context, filters domain.UserFilters) ([]domain.User, error)
+	Search(ctx context.Context, filters domain.UserFilters) (domain.PagingResult[domain.User], error)
 }
 
 func NewUserService(userRepository domain.UserRepository) UserService {
@@ -24,10 +25,56 @@ func NewUserService(userRepository domain.UserRepository) UserService {
 	}
 }
 
+// Create registers the user in the caller's tenant. Platform admins crossing tenants pick it with the
+// tenant header.
 func (us *userService) Create(ctx context.Context, user domain.User) (string, error) {
+	tenantID, err := domain.ResolveTenantID(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	if tenantID == "" {
+		return "", domain.NewValidationError("the user's tenant must be selected with the X-Tenant-ID header", nil)
+	}
+	user.TenantID = tenantID
+
+	if !user.Role.IsValid() {
+		return "", domain.NewValidationError("invalid role", map[string]any{"role": user.Role})
+	}
+
+	callerRole := domain.UserRoleFromContext(ctx)
+	if user.Role.Outranks(callerRole) {
+		return "", domain.NewForbiddenError("user is not allowed to grant this role", map[string]any{"role": user.Role})
+	}
+
 	return us.userRepository.Create(ctx, user)
 }
 
+// EnsurePlatformAdmin creates the first platform admin, as users can only be created by other users.
+// Nothing is done when no credentials are configured or a user with the email already exists.
+func (us *userService) EnsurePlatformAdmin(ctx context.Context, tenantID, email, password string) error {
+	if email == "" || password == "" {
+		return nil
+	}
+
+	users, err := us.userRepository.Search(ctx, domain.UserFilters{Email: []string{email}})
+	if err != nil {
+		return err
+	}
+
+	if len(users.Result) > 0 {
+		return nil
+	}
+
+	admin, err := domain.NewUser(tenantID, "Platform", "Admin", email, password, "", "platform-admin", domain.THAVANNA_ADMIN, 0)
+	if err != nil {
+		return err
+	}
+	admin.MustChangePassword = true
+
+	_, err = us.userRepository.Create(ctx, admin)
+	return err
+}
+
 func (us *userService) GetByID(ctx context.Context, userID string) (*domain.User, error) {
 	if userID == "" {
 		return nil, domain.NewValidationError("userID cannot be empty", nil)
@@ -36,8 +83,33 @@ func (us *userService) GetByID(ctx context.Context, userID string) (*domain.User
 	return us.userRepository.GetByID(ctx, userID)
 }
 
-func (us *userService) Update(ctx context.Context, user domain.User) error {
-	return us.userRepository.Update(ctx, user)
+func (us *userService) Update(ctx context.Context, userID string, userUpdate domain.UserUpdate, author string) error {
+	user, err := us.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkCanManageUser(ctx, *user); err != nil {
+		return err
+	}
+
+	if userUpdate.Role != nil && *userUpdate.Role != user.Role {
+		if !userUpdate.Role.IsValid() {
+			return domain.NewValidationError("invalid role", map[string]any{"role": *userUpdate.Role})
+		}
+
+		callerRole := domain.UserRoleFromContext(ctx)
+		if !callerRole.Outranks(user.Role) || !callerRole.Outranks(*userUpdate.Role) {
+			return domain.NewForbiddenError("user is not allowed to change this role", map[string]any{
+				"role":     user.Role,
+				"new_role": *userUpdate.Role,
+			})
+		}
+	}
+
+	user.MergeUpdate(userUpdate, author)
+
+	return us.userRepository.Update(ctx, *user)
 }
 
 func (us *userService) Delete(ctx context.Context, userID string) error {
@@ -45,9 +117,32 @@ func (us *userService) Delete(ctx context.Context, userID string) error {
 		return domain.NewValidationError("userID cannot be empty", nil)
 	}
 
+	user, err := us.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkCanManageUser(ctx, *user); err != nil {
+		return err
+	}
+
 	return us.userRepository.Delete(ctx, userID)
 }
 
-func (us *userService) Search(ctx context.Context, filters domain.UserFilters) ([]domain.User, error) {
+func (us *userService) Search(ctx context.Context, filters domain.UserFilters) (domain.PagingResult[domain.User], error) {
 	return us.userRepository.Search(ctx, filters)
 }
+
+// checkCanManageUser lets callers change their own record, and the records of other users only when they
+// outrank them, so nobody can take over the account of a user with the same or a higher role.
+func checkCanManageUser(ctx context.Context, user domain.User) error {
+	if domain.UserIDFromContext(ctx) == user.UserID {
+		return nil
+	}
+
+	if !domain.UserRoleFromContext(ctx).Outranks(user.Role) {
+		return domain.NewForbiddenError("user is not allowed to manage this user", map[string]any{"user_id": user.UserID})
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"github.com/icrxz/crm-api-core/internal/repository/memory"
+)
+
+const userTestTenant = "tenant"
+
+func callerContext(userID string, role domain.UserRole) context.Context {
+	ctx := domain.ContextWithTenantScope(context.Background(), userTestTenant)
+	ctx = domain.ContextWithUserID(ctx, userID)
+	return domain.ContextWithUserRole(ctx, role)
+}
+
+func createTestUser(t *testing.T, userRepository domain.UserRepository, email string, role domain.UserRole) domain.User {
+	t.Helper()
+
+	user, err := domain.NewUser(userTestTenant, "Test", "User", email, "password", "", email, role, 0)
+	if err != nil {
+		t.Fatalf("NewUser unexpected error: %v", err)
+	}
+	if _, err = userRepository.Create(domain.ContextWithAllTenants(context.Background()), user); err != nil {
+		t.Fatalf("Create unexpected error: %v", err)
+	}
+
+	return user
+}
+
+func assertStatus(t *testing.T, err error, wantStatus int) {
+	t.Helper()
+
+	if wantStatus == 0 {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+
+	var customErr *domain.CustomError
+	if !errors.As(err, &customErr) || customErr.StatusCode() != wantStatus {
+		t.Fatalf("error = %v, want status %d", err, wantStatus)
+	}
+}
+
+func TestUserServiceCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		callerRole domain.UserRole
+		role       domain.UserRole
+		wantStatus int
+	}{
+		{name: "admin creates an operator", callerRole: domain.ADMIN, role: domain.OPERATOR},
+		{name: "admin creates an admin", callerRole: domain.ADMIN, role: domain.ADMIN},
+		{name: "admin can't create a platform admin", callerRole: domain.ADMIN, role: domain.THAVANNA_ADMIN, wantStatus: http.StatusForbidden},
+		{name: "operator can't create an admin", callerRole: domain.OPERATOR, role: domain.ADMIN, wantStatus: http.StatusForbidden},
+		{name: "unknown role", callerRole: domain.THAVANNA_ADMIN, role: domain.UserRole("root"), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewUserService(memory.NewUserRepository())
+
+			user, err := domain.NewUser("", "New", "User", "new@example.com", "password", "caller", "new", tt.role, 0)
+			if err != nil {
+				t.Fatalf("NewUser unexpected error: %v", err)
+			}
+
+			_, err = service.Create(callerContext("caller", tt.callerRole), user)
+			assertStatus(t, err, tt.wantStatus)
+		})
+	}
+}
+
+func TestUserServiceUpdate(t *testing.T) {
+	email := "changed@example.com"
+	active := false
+	admin := domain.ADMIN
+
+	tests := []struct {
+		name       string
+		callerRole domain.UserRole
+		self       bool
+		targetRole domain.UserRole
+		update     domain.UserUpdate
+		wantStatus int
+	}{
+		{name: "admin changes an operator's email", callerRole: domain.ADMIN, targetRole: domain.OPERATOR, update: domain.UserUpdate{Email: &email}},
+		{name: "operator changes its own email", callerRole: domain.OPERATOR, self: true, targetRole: domain.OPERATOR, update: domain.UserUpdate{Email: &email}},
+		{
+			name:       "operator can't change an admin's email",
+			callerRole: domain.OPERATOR,
+			targetRole: domain.ADMIN,
+			update:     domain.UserUpdate{Email: &email},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "admin can't deactivate another admin",
+			callerRole: domain.ADMIN,
+			targetRole: domain.ADMIN,
+			update:     domain.UserUpdate{Active: &active},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "operator can't promote itself",
+			callerRole: domain.OPERATOR,
+			self:       true,
+			targetRole: domain.OPERATOR,
+			update:     domain.UserUpdate{Role: &admin},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "admin can't grant its own role",
+			callerRole: domain.ADMIN,
+			targetRole: domain.OPERATOR,
+			update:     domain.UserUpdate{Role: &admin},
+			wantStatus: http.StatusForbidden,
+		},
+		{name: "platform admin promotes an operator", callerRole: domain.THAVANNA_ADMIN, targetRole: domain.OPERATOR, update: domain.UserUpdate{Role: &admin}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepository := memory.NewUserRepository()
+			service := NewUserService(userRepository)
+			target := createTestUser(t, userRepository, "target@example.com", tt.targetRole)
+
+			callerID := "caller"
+			if tt.self {
+				callerID = target.UserID
+			}
+
+			err := service.Update(callerContext(callerID, tt.callerRole), target.UserID, tt.update, callerID)
+			assertStatus(t, err, tt.wantStatus)
+
+			saved, err := userRepository.GetByID(domain.ContextWithAllTenants(context.Background()), target.UserID)
+			if err != nil {
+				t.Fatalf("GetByID unexpected error: %v", err)
+			}
+			if changed := saved.UpdatedBy == callerID; changed != (tt.wantStatus == 0) {
+				t.Errorf("user updated = %v, want %v", changed, tt.wantStatus == 0)
+			}
+		})
+	}
+}
+
+func TestUserServiceDelete(t *testing.T) {
+	tests := []struct {
+		name       string
+		callerRole domain.UserRole
+		targetRole domain.UserRole
+		wantStatus int
+	}{
+		{name: "admin deletes an operator", callerRole: domain.ADMIN, targetRole: domain.OPERATOR},
+		{name: "admin can't delete another admin", callerRole: domain.ADMIN, targetRole: domain.ADMIN, wantStatus: http.StatusForbidden},
+		{name: "admin can't delete a platform admin", callerRole: domain.ADMIN, targetRole: domain.THAVANNA_ADMIN, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepository := memory.NewUserRepository()
+			service := NewUserService(userRepository)
+			target := createTestUser(t, userRepository, "target@example.com", tt.targetRole)
+
+			err := service.Delete(callerContext("caller", tt.callerRole), target.UserID)
+			assertStatus(t, err, tt.wantStatus)
+		})
+	}
+}
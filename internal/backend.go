@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/icrxz/crm-api-core/config"
+	"github.com/icrxz/crm-api-core/internal/domain"
+	bucket2 "github.com/icrxz/crm-api-core/internal/repository/bucket"
+	database2 "github.com/icrxz/crm-api-core/internal/repository/database"
+	memory2 "github.com/icrxz/crm-api-core/internal/repository/memory"
+	"github.com/icrxz/crm-api-core/internal/repository/notifier"
+	"github.com/icrxz/crm-api-core/internal/repository/scanner"
+)
+
+type repositories struct {
+	userRepository            domain.UserRepository
+	leadRepository            domain.LeadRepository
+	customerRepository        domain.CustomerRepository
+	tenantRepository          domain.TenantRepository
+	ticketRepository          domain.TicketRepository
+	productRepository         domain.ProductRepository
+	commentRepository         domain.CommentRepository
+	transactionRepository     domain.TransactionRepository
+	attachmentRepository      domain.AttachmentRepository
+	ticketHistoryRepository   domain.TicketHistoryRepository
+	sessionRepository         domain.SessionRepository
+	passwordResetRepository   domain.PasswordResetRepository
+	loginAttemptRepository    domain.LoginAttemptRepository
+	userLockEventRepository   domain.UserLockEventRepository
+	reportRepository          domain.ReportRepository
+	reportJobRepository       domain.ReportJobRepository
+	uploadSessionRepository   domain.UploadSessionRepository
+	commentRevisionRepository domain.CommentRevisionRepository
+	notificationRepository    domain.NotificationRepository
+	attachmentBucket          domain.AttachmentBucket
+}
+
+func loadRepositories(ctx context.Context, appConfig *config.AppConfig) (*repositories, error) {
+	switch appConfig.Backend {
+	case config.MongoBackend:
+		return loadMongoRepositories(ctx, appConfig)
+	case config.MemoryBackend:
+		return loadMemoryRepositories(appConfig)
+	default:
+		return nil, fmt.Errorf("unknown backend %q", appConfig.Backend)
+	}
+}
+
+func loadMongoRepositories(ctx context.Context, appConfig *config.AppConfig) (*repositories, error) {
+	// database
+	mongoDB, err := database2.NewDBInstance(appConfig.Database)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = database2.EnsureLoginAttemptIndexes(ctx, mongoDB); err != nil {
+		return nil, err
+	}
+
+	// bucket
+	s3Client, err := bucket2.NewS3Bucket(ctx, appConfig.AttachmentsBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories{
+		userRepository:            database2.NewUserRepository(mongoDB),
+		leadRepository:            database2.NewLeadRepository(mongoDB),
+		customerRepository:        database2.NewCustomerRepository(mongoDB),
+		tenantRepository:          database2.NewTenantRepository(mongoDB),
+		ticketRepository:          database2.NewTicketRepository(mongoDB),
+		productRepository:         database2.NewProductRepository(mongoDB),
+		commentRepository:         database2.NewCommentRepository(mongoDB),
+		transactionRepository:     database2.NewTransactionRepository(mongoDB),
+		attachmentRepository:      database2.NewAttachmentRepository(mongoDB),
+		ticketHistoryRepository:   database2.NewTicketHistoryRepository(mongoDB),
+		sessionRepository:         database2.NewSessionRepository(mongoDB),
+		passwordResetRepository:   database2.NewPasswordResetRepository(mongoDB),
+		loginAttemptRepository:    database2.NewLoginAttemptRepository(mongoDB),
+		userLockEventRepository:   database2.NewUserLockEventRepository(mongoDB),
+		reportRepository:          database2.NewReportRepository(mongoDB),
+		reportJobRepository:       database2.NewReportJobRepository(mongoDB),
+		uploadSessionRepository:   database2.NewUploadSessionRepository(mongoDB),
+		commentRevisionRepository: database2.NewCommentRevisionRepository(mongoDB),
+		notificationRepository:    database2.NewNotificationRepository(mongoDB),
+		attachmentBucket:          bucket2.NewAttachmentBucket(s3Client, appConfig.AttachmentsBucket.Name),
+	}, nil
+}
+
+func loadMemoryRepositories(appConfig *config.AppConfig) (*repositories, error) {
+	attachmentBucket, err := bucket2.NewFileSystemBucket(
+		appConfig.AttachmentsBucket.LocalFolder,
+		appConfig.AttachmentsBucket.LocalBaseURL,
+		appConfig.SecretKey(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repositories{
+		userRepository:            memory2.NewUserRepository(),
+		leadRepository:            memory2.NewLeadRepository(),
+		customerRepository:        memory2.NewCustomerRepository(),
+		tenantRepository:          memory2.NewTenantRepository(),
+		ticketRepository:          memory2.NewTicketRepository(),
+		productRepository:         memory2.NewProductRepository(),
+		commentRepository:         memory2.NewCommentRepository(),
+		transactionRepository:     memory2.NewTransactionRepository(),
+		attachmentRepository:      memory2.NewAttachmentRepository(),
+		ticketHistoryRepository:   memory2.NewTicketHistoryRepository(),
+		sessionRepository:         memory2.NewSessionRepository(),
+		passwordResetRepository:   memory2.NewPasswordResetRepository(),
+		loginAttemptRepository:    memory2.NewLoginAttemptRepository(),
+		userLockEventRepository:   memory2.NewUserLockEventRepository(),
+		reportRepository:          memory2.NewReportRepository(),
+		reportJobRepository:       memory2.NewReportJobRepository(),
+		uploadSessionRepository:   memory2.NewUploadSessionRepository(),
+		commentRevisionRepository: memory2.NewCommentRevisionRepository(),
+		notificationRepository:    memory2.NewNotificationRepository(),
+		attachmentBucket:          attachmentBucket,
+	}, nil
+}
+
+func loadUserNotifier(appConfig *config.AppConfig) (domain.UserNotifier, error) {
+	switch appConfig.Notifier.Type {
+	case config.LogNotifier:
+		return notifier.NewLogNotifier(), nil
+	case config.FileNotifier:
+		return notifier.NewFileNotifier(appConfig.Notifier.FilePath)
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", appConfig.Notifier.Type)
+	}
+}
+
+func loadMalwareScanner(appConfig *config.AppConfig) (domain.MalwareScanner, error) {
+	switch appConfig.Attachments.Scanner {
+	case config.NoopScanner:
+		return scanner.NewNoopScanner(), nil
+	case config.SignatureScanner:
+		return scanner.NewSignatureScanner(appConfig.Attachments.SignaturesFile)
+	default:
+		return nil, fmt.Errorf("unknown malware scanner %q", appConfig.Attachments.Scanner)
+	}
+}
+
+// loadLocalBucketRoutes serves the presigned URLs of the file system bucket, which point back to the API.
+func loadLocalBucketRoutes(router *gin.Engine, attachmentBucket domain.AttachmentBucket) {
+	handler, ok := attachmentBucket.(http.Handler)
+	if !ok {
+		return
+	}
+
+	router.GET(bucket2.LocalBucketPath, gin.WrapH(handler))
+	router.PUT(bucket2.LocalBucketPath, gin.WrapH(handler))
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"slices"
+)
+
+// AttachmentPolicy limits the files attached to a tenant's tickets. Sizes are in bytes; zero values
+// fall back to the defaults of the API.
+type AttachmentPolicy struct {
+	AllowedContentTypes []string
+	MaxFileSize         int
+	MaxTicketSize       int
+}
+
+type MalwareScanner interface {
+	// Scan returns the name of the threat found in the content, or an empty string when it's clean.
+	Scan(ctx context.Context, content io.Reader) (string, error)
+}
+
+func (p AttachmentPolicy) WithDefaults(defaults AttachmentPolicy) AttachmentPolicy {
+	if len(p.AllowedContentTypes) == 0 {
+		p.AllowedContentTypes = defaults.AllowedContentTypes
+	}
+
+	if p.MaxFileSize == 0 {
+		p.MaxFileSize = defaults.MaxFileSize
+	}
+
+	if p.MaxTicketSize == 0 {
+		p.MaxTicketSize = defaults.MaxTicketSize
+	}
+
+	return p
+}
+
+func (p AttachmentPolicy) Allows(contentType string) bool {
+	return slices.Contains(p.AllowedContentTypes, contentType)
+}
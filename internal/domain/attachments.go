@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,20 +18,60 @@ type AttachmentRepository interface {
 
 type AttachmentBucket interface {
 	Download(ctx context.Context, attachmentID string) ([]byte, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Upload(ctx context.Context, key string, file io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	Stat(ctx context.Context, key string) (*BucketObject, error)
+	PresignUpload(ctx context.Context, key, contentType string, size int, expiresIn time.Duration) (string, error)
+	PresignDownload(ctx context.Context, key string, expiresIn time.Duration) (string, error)
+}
+
+type BucketObject struct {
+	Key         string
+	Size        int
+	ContentType string
 }
 
 type Attachment struct {
 	AttachmentID  string
 	CommentID     string
+	TenantID      string
 	Key           string
 	FileName      string
 	AttachmentURL string
 	FileExtension string
+	ContentType   string
+	Checksum      string
+	Visibility    Visibility
+	ThumbnailKey  string
+	ThumbnailURL  string
+	TakenAt       *time.Time
+	Latitude      *float64
+	Longitude     *float64
+	CameraModel   string
 	CreatedAt     time.Time
 	Size          int
 	CreatedBy     string
 }
 
+// AttachmentUpload is a file received by the API to be stored in the bucket as a comment attachment.
+type AttachmentUpload struct {
+	FileName    string
+	ContentType string
+	File        io.ReadSeeker
+}
+
+// VisibleTo tells whether the audience can see the attachment of the comment. An attachment is never
+// more visible than its comment.
+func (a Attachment) VisibleTo(comment Comment, audience Visibility) bool {
+	return a.Visibility.Narrowest(comment.Visibility).VisibleTo(audience)
+}
+
+// AttachmentKeyPrefix is where the files attached to the ticket's comments are stored in the bucket.
+func AttachmentKeyPrefix(tenantID, ticketID string) string {
+	return fmt.Sprintf("attachments/%s/tickets/%s/", tenantID, ticketID)
+}
+
 func NewAttachment(
 	fileName string,
 	attachmentURL string,
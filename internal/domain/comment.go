@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"regexp"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,18 +13,62 @@ type CommentRepository interface {
 	Create(ctx context.Context, comment Comment) (string, error)
 	GetByID(ctx context.Context, commentID string) (*Comment, error)
 	GetByTicketID(ctx context.Context, ticketID string) ([]Comment, error)
+	Update(ctx context.Context, comment Comment) error
 }
 
+type CommentRevisionRepository interface {
+	Create(ctx context.Context, revision CommentRevision) (string, error)
+	GetByCommentID(ctx context.Context, commentID string) ([]CommentRevision, error)
+}
+
+// maxCommentMentions limits how many users a single comment can notify.
+const maxCommentMentions = 20
+
+// mentionPattern matches `@username` when the @ doesn't follow a word character, so e-mail addresses
+// aren't taken as mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@(\w[\w.-]*)`)
+
 type Comment struct {
+	CommentID       string
+	TicketID        string
+	TenantID        string
+	ParentCommentID string
+	Content         string
+	CommentType     CommentType
+	Visibility      Visibility
+	Mentions        []string
+	Attachments     []Attachment
+	CreatedBy       string
+	CreatedAt       time.Time
+	UpdatedBy       string
+	UpdatedAt       time.Time
+	Revision        int
+	DeletedBy       string
+	DeletedAt       *time.Time
+}
+
+// CommentThread is a comment with the replies to it, and the replies to those, in creation order.
+type CommentThread struct {
+	Comment
+	Replies []CommentThread
+}
+
+type CommentUpdate struct {
+	Content    *string
+	Visibility *Visibility
+}
+
+// CommentRevision is a version of a comment's content, kept when the comment is edited.
+type CommentRevision struct {
+	RevisionID  string
 	CommentID   string
-	TicketID    string
+	TenantID    string
+	Revision    int
 	Content     string
 	CommentType CommentType
-	Attachments []Attachment
-	CreatedBy   string
-	CreatedAt   time.Time
-	UpdatedBy   string
-	UpdatedAt   time.Time
+	Visibility  Visibility
+	EditedBy    string
+	EditedAt    time.Time
 }
 
 type CommentType string
@@ -52,10 +98,179 @@ func NewComment(
 		CommentType: commentType,
 		TicketID:    ticketID,
 		Content:     content,
+		Visibility:  INTERNAL_VISIBILITY,
 		CreatedBy:   createdBy,
 		UpdatedBy:   createdBy,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Revision:    1,
 		Attachments: attachments,
 	}, nil
 }
+
+func (c *Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// Edit changes the content or the visibility of the comment, starting a new revision. The current one
+// must be kept with NewCommentRevision before.
+func (c *Comment) Edit(update CommentUpdate, editedBy string) error {
+	if c.IsDeleted() {
+		return NewValidationError("deleted comments cannot be edited", map[string]any{"comment_id": c.CommentID})
+	}
+
+	if update.Content == nil && update.Visibility == nil {
+		return NewValidationError("content or visibility is required", nil)
+	}
+
+	if update.Content != nil {
+		if *update.Content == "" {
+			return NewValidationError("content cannot be empty", nil)
+		}
+		c.Content = *update.Content
+	}
+
+	if update.Visibility != nil {
+		c.Visibility = *update.Visibility
+	}
+
+	c.Revision = c.CurrentRevision().Revision + 1
+	c.UpdatedBy = editedBy
+	c.UpdatedAt = time.Now().UTC()
+
+	return nil
+}
+
+func (c *Comment) Delete(deletedBy string) error {
+	if c.IsDeleted() {
+		return NewNotFoundError("no comment found with this id", map[string]any{"comment_id": c.CommentID})
+	}
+
+	now := time.Now().UTC()
+	c.DeletedBy = deletedBy
+	c.DeletedAt = &now
+
+	return nil
+}
+
+// CurrentRevision is the comment's content as it is now. Comments created before revisions were kept
+// are on their first revision.
+func (c *Comment) CurrentRevision() CommentRevision {
+	return CommentRevision{
+		CommentID:   c.CommentID,
+		TenantID:    c.TenantID,
+		Revision:    max(c.Revision, 1),
+		Content:     c.Content,
+		CommentType: c.CommentType,
+		Visibility:  c.Visibility.OrDefault(),
+		EditedBy:    c.UpdatedBy,
+		EditedAt:    c.UpdatedAt,
+	}
+}
+
+// VisibleComments returns the comments the audience can see, each with only the attachments the
+// audience can see.
+func VisibleComments(comments []Comment, audience Visibility) []Comment {
+	visible := make([]Comment, 0, len(comments))
+	for _, comment := range comments {
+		if !comment.Visibility.VisibleTo(audience) {
+			continue
+		}
+
+		attachments := make([]Attachment, 0, len(comment.Attachments))
+		for _, attachment := range comment.Attachments {
+			if attachment.VisibleTo(comment, audience) {
+				attachments = append(attachments, attachment)
+			}
+		}
+		comment.Attachments = attachments
+
+		visible = append(visible, comment)
+	}
+
+	return visible
+}
+
+// MentionedUsernames returns the usernames mentioned as `@username` in the content, once each and in
+// the order they first appear. Dots and dashes ending a mention are taken as punctuation.
+func MentionedUsernames(content string) []string {
+	usernames := make([]string, 0)
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		username := trimMentionPunctuation(match[1])
+		if username == "" || slices.Contains(usernames, username) {
+			continue
+		}
+
+		usernames = append(usernames, username)
+		if len(usernames) == maxCommentMentions {
+			break
+		}
+	}
+
+	return usernames
+}
+
+func trimMentionPunctuation(username string) string {
+	for len(username) > 0 && (username[len(username)-1] == '.' || username[len(username)-1] == '-') {
+		username = username[:len(username)-1]
+	}
+	return username
+}
+
+// BuildCommentThreads nests the replies under their parent comments. Replies whose parent isn't in the
+// list, because it was deleted or can't be seen, are kept at the top level so they aren't lost.
+// Replies can't form cycles, as parents are set on creation only, but any would be listed last.
+func BuildCommentThreads(comments []Comment) []CommentThread {
+	commentIDs := make(map[string]bool, len(comments))
+	for _, comment := range comments {
+		commentIDs[comment.CommentID] = true
+	}
+
+	replies := make(map[string][]Comment)
+	roots := make([]Comment, 0, len(comments))
+	for _, comment := range comments {
+		if comment.ParentCommentID != "" && comment.ParentCommentID != comment.CommentID && commentIDs[comment.ParentCommentID] {
+			replies[comment.ParentCommentID] = append(replies[comment.ParentCommentID], comment)
+			continue
+		}
+		roots = append(roots, comment)
+	}
+
+	visited := make(map[string]bool, len(comments))
+	var buildThread func(comment Comment) CommentThread
+	buildThread = func(comment Comment) CommentThread {
+		visited[comment.CommentID] = true
+		thread := CommentThread{Comment: comment, Replies: make([]CommentThread, 0, len(replies[comment.CommentID]))}
+		for _, reply := range replies[comment.CommentID] {
+			if !visited[reply.CommentID] {
+				thread.Replies = append(thread.Replies, buildThread(reply))
+			}
+		}
+		return thread
+	}
+
+	threads := make([]CommentThread, 0, len(roots))
+	for _, root := range roots {
+		threads = append(threads, buildThread(root))
+	}
+
+	for _, comment := range comments {
+		if !visited[comment.CommentID] {
+			threads = append(threads, buildThread(comment))
+		}
+	}
+
+	return threads
+}
+
+func NewCommentRevision(comment Comment) (CommentRevision, error) {
+	revisionID, err := uuid.NewUUID()
+	if err != nil {
+		return CommentRevision{}, err
+	}
+
+	revision := comment.CurrentRevision()
+	revision.RevisionID = revisionID.String()
+
+	return revision, nil
+}
@@ -68,6 +68,24 @@ func NewUnauthorizedError(message string) error {
 	}
 }
 
+func NewForbiddenError(message string, metadata map[string]any) error {
+	return &CustomError{
+		messagePrefix: "Forbidden error - Message:",
+		message:       message,
+		statusCode:    http.StatusForbidden,
+		metadata:      metadata,
+	}
+}
+
+func NewTooManyRequestsError(message string, metadata map[string]any) error {
+	return &CustomError{
+		messagePrefix: "TooManyRequests error - Message:",
+		message:       message,
+		statusCode:    http.StatusTooManyRequests,
+		metadata:      metadata,
+	}
+}
+
 func (e CustomError) IsNotFound() bool {
 	return e.statusCode == http.StatusNotFound
 }
@@ -17,6 +17,7 @@ type CustomerRepository interface {
 
 type Customer struct {
 	CustomerID      string
+	TenantID        string
 	OwnerID         string
 	FirstName       string
 	LastName        string
@@ -54,6 +55,7 @@ const (
 
 type CustomerFilters struct {
 	CustomerID   []string
+	TenantID     []string
 	OwnerID      []string
 	CustomerType []string
 	Document     []string
@@ -61,7 +63,7 @@ type CustomerFilters struct {
 	PagingFilter
 }
 
-func NewCustomer(firstName, lastName, companyName, legalName, document, documentType, author string, personalContact, businessContact Contact, shippingAddress, billingAddress Address) (Customer, error) {
+func NewCustomer(tenantID, firstName, lastName, companyName, legalName, document, documentType, author string, personalContact, businessContact Contact, shippingAddress, billingAddress Address) (Customer, error) {
 	now := time.Now().UTC()
 
 	customerID, err := uuid.NewUUID()
@@ -78,6 +80,7 @@ func NewCustomer(firstName, lastName, companyName, legalName, document, document
 
 	return Customer{
 		CustomerID:      customerID.String(),
+		TenantID:        tenantID,
 		Type:            customerType,
 		FirstName:       firstName,
 		LastName:        lastName,
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginAttemptRepository changes attempts atomically, so concurrent failed logins are all counted.
+// Records past their ExpiresAt are treated as missing.
+type LoginAttemptRepository interface {
+	Get(ctx context.Context, key string) (*LoginAttempt, error)
+	// IncrementFailures counts a failure for the key, starting a new record when there is none, and
+	// returns the updated record.
+	IncrementFailures(ctx context.Context, key string, failedAt, expiresAt time.Time) (*LoginAttempt, error)
+	// Lock locks the key and clears its failures if it still has at least minFailures of them. It
+	// reports whether it did, which is false when a concurrent failure already locked the key.
+	Lock(ctx context.Context, key string, minFailures int, lockedUntil time.Time) (bool, error)
+	ResetFailures(ctx context.Context, key string) error
+	Delete(ctx context.Context, key string) error
+}
+
+type UserLockEventRepository interface {
+	Create(ctx context.Context, event UserLockEvent) (string, error)
+	GetByUserID(ctx context.Context, userID string) ([]UserLockEvent, error)
+}
+
+type LockEventType string
+
+const (
+	USER_LOCKED   LockEventType = "locked"
+	USER_UNLOCKED LockEventType = "unlocked"
+)
+
+type LockoutPolicy struct {
+	MaxAttempts  int
+	BaseLockTime time.Duration
+	MaxLockTime  time.Duration
+}
+
+type LoginAttempt struct {
+	Key           string
+	Failures      int
+	LockCount     int
+	LockedUntil   *time.Time
+	LastFailureAt time.Time
+	UpdatedAt     time.Time
+	ExpiresAt     time.Time
+}
+
+type UserLockEvent struct {
+	EventID     string
+	UserID      string
+	TenantID    string
+	Type        LockEventType
+	Reason      string
+	IPAddress   string
+	LockedUntil *time.Time
+	CreatedAt   time.Time
+	CreatedBy   string
+}
+
+func AccountAttemptKey(email string) string {
+	return "account:" + email
+}
+
+func IPAttemptKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
+
+func NewLoginAttempt(key string) LoginAttempt {
+	return LoginAttempt{
+		Key: key,
+	}
+}
+
+func (a LoginAttempt) IsLocked() bool {
+	return a.LockedUntil != nil && time.Now().UTC().Before(*a.LockedUntil)
+}
+
+func (a LoginAttempt) IsExpired(now time.Time) bool {
+	return !now.Before(a.ExpiresAt)
+}
+
+// LockTime doubles the base lock time on every lock the key already had, up to the policy's maximum.
+func (p LockoutPolicy) LockTime(lockCount int) time.Duration {
+	lockTime := p.BaseLockTime << lockCount
+	if lockTime <= 0 || lockTime > p.MaxLockTime {
+		return p.MaxLockTime
+	}
+	return lockTime
+}
+
+// AttemptExpiry is when the attempt record of a key that failed at lastFailureAt expires. A quiet
+// period as long as the longest lock forgives the earlier failures, and no lock outlives it.
+func (p LockoutPolicy) AttemptExpiry(lastFailureAt time.Time) time.Time {
+	return lastFailureAt.Add(p.MaxLockTime)
+}
+
+func NewUserLockEvent(user User, eventType LockEventType, reason, ipAddress string, lockedUntil *time.Time, author string) (UserLockEvent, error) {
+	eventID, err := uuid.NewRandom()
+	if err != nil {
+		return UserLockEvent{}, err
+	}
+
+	return UserLockEvent{
+		EventID:     eventID.String(),
+		UserID:      user.UserID,
+		TenantID:    user.TenantID,
+		Type:        eventType,
+		Reason:      reason,
+		IPAddress:   ipAddress,
+		LockedUntil: lockedUntil,
+		CreatedAt:   time.Now().UTC(),
+		CreatedBy:   author,
+	}, nil
+}
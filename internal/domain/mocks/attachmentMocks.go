@@ -6,7 +6,9 @@ package mock_domain
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/icrxz/crm-api-core/internal/domain"
@@ -116,6 +118,20 @@ func (m *MockAttachmentBucket) EXPECT() *MockAttachmentBucketMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockAttachmentBucket) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentBucketMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachmentBucket)(nil).Delete), ctx, key)
+}
+
 // Download mocks base method.
 func (m *MockAttachmentBucket) Download(ctx context.Context, attachmentID string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -130,3 +146,77 @@ func (mr *MockAttachmentBucketMockRecorder) Download(ctx, attachmentID interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockAttachmentBucket)(nil).Download), ctx, attachmentID)
 }
+
+// Open mocks base method.
+func (m *MockAttachmentBucket) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockAttachmentBucketMockRecorder) Open(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockAttachmentBucket)(nil).Open), ctx, key)
+}
+
+// PresignDownload mocks base method.
+func (m *MockAttachmentBucket) PresignDownload(ctx context.Context, key string, expiresIn time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignDownload", ctx, key, expiresIn)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignDownload indicates an expected call of PresignDownload.
+func (mr *MockAttachmentBucketMockRecorder) PresignDownload(ctx, key, expiresIn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignDownload", reflect.TypeOf((*MockAttachmentBucket)(nil).PresignDownload), ctx, key, expiresIn)
+}
+
+// PresignUpload mocks base method.
+func (m *MockAttachmentBucket) PresignUpload(ctx context.Context, key, contentType string, size int, expiresIn time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignUpload", ctx, key, contentType, size, expiresIn)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignUpload indicates an expected call of PresignUpload.
+func (mr *MockAttachmentBucketMockRecorder) PresignUpload(ctx, key, contentType, size, expiresIn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignUpload", reflect.TypeOf((*MockAttachmentBucket)(nil).PresignUpload), ctx, key, contentType, size, expiresIn)
+}
+
+// Stat mocks base method.
+func (m *MockAttachmentBucket) Stat(ctx context.Context, key string) (*domain.BucketObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stat", ctx, key)
+	ret0, _ := ret[0].(*domain.BucketObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stat indicates an expected call of Stat.
+func (mr *MockAttachmentBucketMockRecorder) Stat(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stat", reflect.TypeOf((*MockAttachmentBucket)(nil).Stat), ctx, key)
+}
+
+// Upload mocks base method.
+func (m *MockAttachmentBucket) Upload(ctx context.Context, key string, file io.Reader, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, key, file, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockAttachmentBucketMockRecorder) Upload(ctx, key, file, contentType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockAttachmentBucket)(nil).Upload), ctx, key, file, contentType)
+}
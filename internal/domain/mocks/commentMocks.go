@@ -79,3 +79,70 @@ func (mr *MockCommentRepositoryMockRecorder) GetByTicketID(ctx, ticketID interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTicketID", reflect.TypeOf((*MockCommentRepository)(nil).GetByTicketID), ctx, ticketID)
 }
+
+// Update mocks base method.
+func (m *MockCommentRepository) Update(ctx context.Context, comment domain.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentRepositoryMockRecorder) Update(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommentRepository)(nil).Update), ctx, comment)
+}
+
+// MockCommentRevisionRepository is a mock of CommentRevisionRepository interface.
+type MockCommentRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentRevisionRepositoryMockRecorder
+}
+
+// MockCommentRevisionRepositoryMockRecorder is the mock recorder for MockCommentRevisionRepository.
+type MockCommentRevisionRepositoryMockRecorder struct {
+	mock *MockCommentRevisionRepository
+}
+
+// NewMockCommentRevisionRepository creates a new mock instance.
+func NewMockCommentRevisionRepository(ctrl *gomock.Controller) *MockCommentRevisionRepository {
+	mock := &MockCommentRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockCommentRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentRevisionRepository) EXPECT() *MockCommentRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentRevisionRepository) Create(ctx context.Context, revision domain.CommentRevision) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, revision)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentRevisionRepositoryMockRecorder) Create(ctx, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentRevisionRepository)(nil).Create), ctx, revision)
+}
+
+// GetByCommentID mocks base method.
+func (m *MockCommentRevisionRepository) GetByCommentID(ctx context.Context, commentID string) ([]domain.CommentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCommentID", ctx, commentID)
+	ret0, _ := ret[0].([]domain.CommentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCommentID indicates an expected call of GetByCommentID.
+func (mr *MockCommentRevisionRepositoryMockRecorder) GetByCommentID(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCommentID", reflect.TypeOf((*MockCommentRevisionRepository)(nil).GetByCommentID), ctx, commentID)
+}
@@ -80,10 +80,10 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(ctx, userID interface{}) *gomo
 }
 
 // Search mocks base method.
-func (m *MockUserRepository) Search(ctx context.Context, filters domain.UserFilters) ([]domain.User, error) {
+func (m *MockUserRepository) Search(ctx context.Context, filters domain.UserFilters) (domain.PagingResult[domain.User], error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Search", ctx, filters)
-	ret0, _ := ret[0].([]domain.User)
+	ret0, _ := ret[0].(domain.PagingResult[domain.User])
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
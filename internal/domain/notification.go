@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification Notification) (string, error)
+	Search(ctx context.Context, filters NotificationFilters) (PagingResult[Notification], error)
+	MarkRead(ctx context.Context, userID string, notificationIDs []string, readAt time.Time) (int, error)
+}
+
+type NotificationType string
+
+const (
+	TICKET_ASSIGNED_NOTIFICATION NotificationType = "ticket_assigned"
+	LEAD_ASSIGNED_NOTIFICATION   NotificationType = "lead_assigned"
+	STATUS_CHANGED_NOTIFICATION  NotificationType = "status_changed"
+	MENTION_NOTIFICATION         NotificationType = "mention"
+	SLA_BREACHED_NOTIFICATION    NotificationType = "sla_breached"
+)
+
+// Notification is an entry of a user's inbox. It stays unread until ReadAt is set.
+type Notification struct {
+	NotificationID string
+	TenantID       string
+	UserID         string
+	Type           NotificationType
+	TicketID       string
+	CommentID      string
+	Message        string
+	CreatedBy      string
+	CreatedAt      time.Time
+	ReadAt         *time.Time
+}
+
+type NotificationFilters struct {
+	UserID   string
+	TicketID []string
+	Type     []string
+	Read     *bool
+	PagingFilter
+}
+
+func NewNotification(tenantID, userID string, notificationType NotificationType, ticketID, message, author string) (Notification, error) {
+	notificationID, err := uuid.NewUUID()
+	if err != nil {
+		return Notification{}, err
+	}
+
+	return Notification{
+		NotificationID: notificationID.String(),
+		TenantID:       tenantID,
+		UserID:         userID,
+		Type:           notificationType,
+		TicketID:       ticketID,
+		Message:        message,
+		CreatedBy:      author,
+		CreatedAt:      time.Now().UTC(),
+	}, nil
+}
+
+func (n Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
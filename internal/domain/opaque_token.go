@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+func newOpaqueToken(id string) (string, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	encodedSecret := hex.EncodeToString(secret)
+
+	return id + "." + encodedSecret, hashOpaqueSecret(encodedSecret), nil
+}
+
+func parseOpaqueToken(token string) (string, string, bool) {
+	id, secret, found := strings.Cut(token, ".")
+	if !found || id == "" || secret == "" {
+		return "", "", false
+	}
+	return id, secret, true
+}
+
+func matchesOpaqueSecret(hash, secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(hashOpaqueSecret(secret))) == 1
+}
+
+func hashOpaqueSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
@@ -1,8 +1,10 @@
 package domain
 
 type PagingFilter struct {
-	Limit  int
-	Offset int
+	Limit         int
+	Offset        int
+	SortBy        string
+	SortDirection SortDirection
 }
 
 type Paging struct {
@@ -15,3 +17,10 @@ type PagingResult[T any] struct {
 	Result []T
 	Paging Paging
 }
+
+type SortDirection string
+
+const (
+	ASC  SortDirection = "asc"
+	DESC SortDirection = "desc"
+)
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const minPasswordLength = 8
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, resetToken PasswordResetToken) (string, error)
+	GetByID(ctx context.Context, tokenID string) (*PasswordResetToken, error)
+	// MarkUsed sets the token's UsedAt unless it was already used, and reports whether it did.
+	MarkUsed(ctx context.Context, tokenID string, usedAt time.Time) (bool, error)
+}
+
+type UserNotifier interface {
+	NotifyPasswordReset(ctx context.Context, user User, resetLink string, expiresAt time.Time) error
+	NotifyMention(ctx context.Context, user User, comment Comment) error
+}
+
+type PasswordResetToken struct {
+	TokenID   string
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func NewPasswordResetToken(userID string, ttl time.Duration) (PasswordResetToken, string, error) {
+	now := time.Now().UTC()
+	tokenID, err := uuid.NewRandom()
+	if err != nil {
+		return PasswordResetToken{}, "", err
+	}
+
+	token, tokenHash, err := newOpaqueToken(tokenID.String())
+	if err != nil {
+		return PasswordResetToken{}, "", err
+	}
+
+	return PasswordResetToken{
+		TokenID:   tokenID.String(),
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, token, nil
+}
+
+func (t PasswordResetToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().UTC().Before(t.ExpiresAt)
+}
+
+func (t PasswordResetToken) MatchesSecret(secret string) bool {
+	return matchesOpaqueSecret(t.TokenHash, secret)
+}
+
+func ParsePasswordResetToken(token string) (string, string, error) {
+	tokenID, secret, ok := parseOpaqueToken(token)
+	if !ok {
+		return "", "", NewValidationError("invalid or expired reset token", nil)
+	}
+	return tokenID, secret, nil
+}
+
+func ValidatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return NewValidationError("password is too short", map[string]any{"min_length": minPasswordLength})
+	}
+	return nil
+}
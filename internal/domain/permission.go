@@ -0,0 +1,96 @@
+package domain
+
+type Resource string
+
+const (
+	USER_RESOURCE         Resource = "user"
+	LEAD_RESOURCE         Resource = "lead"
+	CUSTOMER_RESOURCE     Resource = "customer"
+	TENANT_RESOURCE       Resource = "tenant"
+	TICKET_RESOURCE       Resource = "ticket"
+	PRODUCT_RESOURCE      Resource = "product"
+	COMMENT_RESOURCE      Resource = "comment"
+	TRANSACTION_RESOURCE  Resource = "transaction"
+	REPORT_RESOURCE       Resource = "report"
+	NOTIFICATION_RESOURCE Resource = "notification"
+)
+
+type Action string
+
+const (
+	CREATE_ACTION       Action = "create"
+	READ_ACTION         Action = "read"
+	UPDATE_ACTION       Action = "update"
+	DELETE_ACTION       Action = "delete"
+	CHANGE_OWNER_ACTION Action = "change_owner"
+	CROSS_TENANT_ACTION Action = "cross_tenant"
+	UNLOCK_ACTION       Action = "unlock"
+	MODERATE_ACTION     Action = "moderate"
+)
+
+var crudActions = []Action{CREATE_ACTION, READ_ACTION, UPDATE_ACTION, DELETE_ACTION}
+
+var rolePermissions = map[UserRole]map[Resource][]Action{
+	THAVANNA_ADMIN: {
+		USER_RESOURCE:         append([]Action{UNLOCK_ACTION}, crudActions...),
+		LEAD_RESOURCE:         crudActions,
+		CUSTOMER_RESOURCE:     crudActions,
+		TENANT_RESOURCE:       append([]Action{CROSS_TENANT_ACTION}, crudActions...),
+		TICKET_RESOURCE:       append([]Action{CHANGE_OWNER_ACTION}, crudActions...),
+		PRODUCT_RESOURCE:      crudActions,
+		COMMENT_RESOURCE:      append([]Action{MODERATE_ACTION}, crudActions...),
+		TRANSACTION_RESOURCE:  crudActions,
+		REPORT_RESOURCE:       crudActions,
+		NOTIFICATION_RESOURCE: {READ_ACTION, UPDATE_ACTION},
+	},
+	ADMIN: {
+		USER_RESOURCE:         append([]Action{UNLOCK_ACTION}, crudActions...),
+		LEAD_RESOURCE:         crudActions,
+		CUSTOMER_RESOURCE:     crudActions,
+		TENANT_RESOURCE:       crudActions,
+		TICKET_RESOURCE:       append([]Action{CHANGE_OWNER_ACTION}, crudActions...),
+		PRODUCT_RESOURCE:      crudActions,
+		COMMENT_RESOURCE:      append([]Action{MODERATE_ACTION}, crudActions...),
+		TRANSACTION_RESOURCE:  crudActions,
+		REPORT_RESOURCE:       crudActions,
+		NOTIFICATION_RESOURCE: {READ_ACTION, UPDATE_ACTION},
+	},
+	OPERATOR: {
+		USER_RESOURCE:         {CREATE_ACTION, READ_ACTION, UPDATE_ACTION},
+		LEAD_RESOURCE:         crudActions,
+		CUSTOMER_RESOURCE:     crudActions,
+		TENANT_RESOURCE:       {READ_ACTION},
+		TICKET_RESOURCE:       crudActions,
+		PRODUCT_RESOURCE:      crudActions,
+		COMMENT_RESOURCE:      crudActions,
+		TRANSACTION_RESOURCE:  crudActions,
+		REPORT_RESOURCE:       {READ_ACTION},
+		NOTIFICATION_RESOURCE: {READ_ACTION, UPDATE_ACTION},
+	},
+}
+
+// roleRanks orders the roles from the least to the most privileged.
+var roleRanks = map[UserRole]int{
+	OPERATOR:       1,
+	ADMIN:          2,
+	THAVANNA_ADMIN: 3,
+}
+
+func (r UserRole) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Outranks tells whether r is more privileged than other. Unknown roles outrank nothing.
+func (r UserRole) Outranks(other UserRole) bool {
+	return roleRanks[r] > roleRanks[other]
+}
+
+func (r UserRole) Can(resource Resource, action Action) bool {
+	for _, allowedAction := range rolePermissions[r][resource] {
+		if allowedAction == action {
+			return true
+		}
+	}
+	return false
+}
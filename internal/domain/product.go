@@ -14,6 +14,7 @@ type ProductRepository interface {
 
 type Product struct {
 	ProductID    string
+	TenantID     string
 	Name         string
 	Description  string
 	Value        float64
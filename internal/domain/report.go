@@ -1,14 +1,104 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
+)
+
+const ReportTemplateExtension = ".docx"
+
+type ReportFormat string
+
+const (
+	DOCX_FORMAT ReportFormat = "docx"
+	PDF_FORMAT  ReportFormat = "pdf"
+)
+
+var reportFormatContentTypes = map[ReportFormat]string{
+	DOCX_FORMAT: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	PDF_FORMAT:  "application/pdf",
+}
+
+type ReportRepository interface {
+	Create(ctx context.Context, report Report) (string, error)
+	GetByID(ctx context.Context, reportID string) (*Report, error)
+	Update(ctx context.Context, report Report) error
+	Search(ctx context.Context, filters ReportFilters) (PagingResult[Report], error)
+}
+
+// Report is a versioned .docx template a tenant uses to render ticket reports. ReportTemplate
+// holds the bucket key of the uploaded file and only one report per tenant is active at a time.
 type Report struct {
 	ReportID       string
+	TenantID       string
 	ReportName     string
 	ReportTemplate string
+	FileName       string
+	Size           int
+	Version        int
 	Active         bool
 	CreatedBy      string
 	CreatedAt      time.Time
 	UpdatedBy      string
 	UpdatedAt      time.Time
 }
+
+type ReportFilters struct {
+	ReportID   []string
+	TenantID   []string
+	ReportName []string
+	Active     *bool
+	PagingFilter
+}
+
+func ParseReportFormat(format string) (ReportFormat, error) {
+	if format == "" {
+		return DOCX_FORMAT, nil
+	}
+
+	reportFormat := ReportFormat(format)
+	if _, ok := reportFormatContentTypes[reportFormat]; !ok {
+		return "", NewValidationError("invalid report format", map[string]any{"format": format})
+	}
+
+	return reportFormat, nil
+}
+
+func (f ReportFormat) ContentType() string {
+	return reportFormatContentTypes[f]
+}
+
+func (f ReportFormat) Extension() string {
+	return "." + string(f)
+}
+
+func NewReport(tenantID, reportName, fileName string, size, version int, author string) (Report, error) {
+	now := time.Now().UTC()
+	reportID, err := uuid.NewRandom()
+	if err != nil {
+		return Report{}, err
+	}
+
+	return Report{
+		ReportID:       reportID.String(),
+		TenantID:       tenantID,
+		ReportName:     reportName,
+		ReportTemplate: fmt.Sprintf("reports/%s/%s%s", tenantID, reportID.String(), ReportTemplateExtension),
+		FileName:       fileName,
+		Size:           size,
+		Version:        version,
+		CreatedBy:      author,
+		CreatedAt:      now,
+		UpdatedBy:      author,
+		UpdatedAt:      now,
+	}, nil
+}
+
+func (r *Report) SetActive(active bool, author string) {
+	r.Active = active
+	r.UpdatedBy = author
+	r.UpdatedAt = time.Now().UTC()
+}
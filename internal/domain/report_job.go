@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportJobRepository interface {
+	Create(ctx context.Context, job ReportJob) (string, error)
+	GetByID(ctx context.Context, jobID string) (*ReportJob, error)
+	Update(ctx context.Context, job ReportJob) error
+	Search(ctx context.Context, filters ReportJobFilters) (PagingResult[ReportJob], error)
+}
+
+type ReportJobStatus string
+
+const (
+	REPORT_JOB_PENDING   ReportJobStatus = "pending"
+	REPORT_JOB_RUNNING   ReportJobStatus = "running"
+	REPORT_JOB_COMPLETED ReportJobStatus = "completed"
+	REPORT_JOB_FAILED    ReportJobStatus = "failed"
+)
+
+// ReportJob tracks the generation of a ticket report. Once completed, ArtifactKey points to the
+// generated file in the attachment bucket, which is never overwritten, and Version numbers the
+// reports generated for the same ticket.
+type ReportJob struct {
+	JobID       string
+	TenantID    string
+	TicketID    string
+	Format      ReportFormat
+	Status      ReportJobStatus
+	Version     int
+	ArtifactKey string
+	FileName    string
+	Size        int
+	Error       string
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+type ReportJobFilters struct {
+	JobID    []string
+	TenantID []string
+	TicketID []string
+	Status   []string
+	PagingFilter
+}
+
+func NewReportJob(tenantID, ticketID string, format ReportFormat, author string) (ReportJob, error) {
+	now := time.Now().UTC()
+	jobID, err := uuid.NewRandom()
+	if err != nil {
+		return ReportJob{}, err
+	}
+
+	return ReportJob{
+		JobID:     jobID.String(),
+		TenantID:  tenantID,
+		TicketID:  ticketID,
+		Format:    format,
+		Status:    REPORT_JOB_PENDING,
+		CreatedBy: author,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+func (j *ReportJob) Start() {
+	j.Status = REPORT_JOB_RUNNING
+	j.Error = ""
+	j.UpdatedAt = time.Now().UTC()
+}
+
+func (j *ReportJob) Complete(version int, fileName string, size int) {
+	now := time.Now().UTC()
+
+	j.Status = REPORT_JOB_COMPLETED
+	j.Version = version
+	j.ArtifactKey = fmt.Sprintf("reports/%s/tickets/%s/v%d-%s%s", j.TenantID, j.TicketID, version, j.JobID, j.Format.Extension())
+	j.FileName = fileName
+	j.Size = size
+	j.UpdatedAt = now
+	j.CompletedAt = &now
+}
+
+func (j *ReportJob) Fail(reason string) {
+	now := time.Now().UTC()
+
+	j.Status = REPORT_JOB_FAILED
+	j.Version = 0
+	j.ArtifactKey = ""
+	j.Error = reason
+	j.UpdatedAt = now
+	j.CompletedAt = &now
+}
+
+func (j *ReportJob) IsFinished() bool {
+	return j.Status == REPORT_JOB_COMPLETED || j.Status == REPORT_JOB_FAILED
+}
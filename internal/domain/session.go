@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session Session) (string, error)
+	GetByID(ctx context.Context, sessionID string) (*Session, error)
+	Update(ctx context.Context, session Session) error
+	RevokeByUserID(ctx context.Context, userID string, revokedAt time.Time) error
+}
+
+type Session struct {
+	SessionID        string
+	UserID           string
+	RefreshTokenHash string
+	ExpiresAt        time.Time
+	RevokedAt        *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+func NewSession(userID string, refreshTokenTTL time.Duration) (Session, string, error) {
+	now := time.Now().UTC()
+	sessionID, err := uuid.NewRandom()
+	if err != nil {
+		return Session{}, "", err
+	}
+
+	session := Session{
+		SessionID: sessionID.String(),
+		UserID:    userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	refreshToken, err := session.Rotate(refreshTokenTTL)
+	if err != nil {
+		return Session{}, "", err
+	}
+
+	return session, refreshToken, nil
+}
+
+func (s *Session) Rotate(refreshTokenTTL time.Duration) (string, error) {
+	refreshToken, refreshTokenHash, err := newOpaqueToken(s.SessionID)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	s.RefreshTokenHash = refreshTokenHash
+	s.ExpiresAt = now.Add(refreshTokenTTL)
+	s.UpdatedAt = now
+
+	return refreshToken, nil
+}
+
+func (s *Session) Revoke() {
+	now := time.Now().UTC()
+	s.RevokedAt = &now
+	s.UpdatedAt = now
+}
+
+func (s Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().UTC().Before(s.ExpiresAt)
+}
+
+func (s Session) MatchesRefreshSecret(secret string) bool {
+	return matchesOpaqueSecret(s.RefreshTokenHash, secret)
+}
+
+func ParseRefreshToken(refreshToken string) (string, string, error) {
+	sessionID, secret, ok := parseOpaqueToken(refreshToken)
+	if !ok {
+		return "", "", NewUnauthorizedError("invalid refresh token")
+	}
+	return sessionID, secret, nil
+}
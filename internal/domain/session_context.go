@@ -0,0 +1,88 @@
+package domain
+
+import "context"
+
+type userIDContextKey struct{}
+
+type userRoleContextKey struct{}
+
+type tenantScopeContextKey struct{}
+
+type sessionIDContextKey struct{}
+
+const ALL_TENANTS = "*"
+
+func ContextWithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDContextKey{}, userID)
+}
+
+func UserIDFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(userIDContextKey{}).(string)
+	return userID
+}
+
+func ContextWithUserRole(ctx context.Context, role UserRole) context.Context {
+	return context.WithValue(ctx, userRoleContextKey{}, role)
+}
+
+func UserRoleFromContext(ctx context.Context) UserRole {
+	role, _ := ctx.Value(userRoleContextKey{}).(UserRole)
+	return role
+}
+
+func ContextWithTenantScope(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantScopeContextKey{}, tenantID)
+}
+
+// ContextWithAllTenants lets internal jobs, and the flows that run before the caller's tenant is known
+// (login, token refresh, password reset), reach every tenant. They have to opt in explicitly.
+func ContextWithAllTenants(ctx context.Context) context.Context {
+	return ContextWithTenantScope(ctx, ALL_TENANTS)
+}
+
+// TenantScopeFromContext returns the tenant the caller is restricted to, and false when it can reach
+// every tenant: platform admins crossing tenants and contexts from ContextWithAllTenants. Contexts
+// without a scope are restricted to an empty tenant ID, which matches no tenant.
+func TenantScopeFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantScopeContextKey{}).(string)
+	if !ok {
+		return "", true
+	}
+	if tenantID == ALL_TENANTS {
+		return "", false
+	}
+	return tenantID, true
+}
+
+// ResolveTenantID returns the tenant a new record belongs to: the caller's own tenant, or the requested
+// one for callers that can reach every tenant.
+func ResolveTenantID(ctx context.Context, tenantID string) (string, error) {
+	scope, scoped := TenantScopeFromContext(ctx)
+	if !scoped {
+		return tenantID, nil
+	}
+
+	if scope == "" {
+		return "", NewForbiddenError("request has no tenant scope", nil)
+	}
+
+	if tenantID != "" && tenantID != scope {
+		return "", NewForbiddenError("tenant is outside of the caller's scope", map[string]any{"tenant_id": tenantID})
+	}
+
+	return scope, nil
+}
+
+func IsInTenantScope(ctx context.Context, tenantID string) bool {
+	scope, scoped := TenantScopeFromContext(ctx)
+	return !scoped || (scope != "" && scope == tenantID)
+}
+
+func ContextWithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionIDContextKey{}, sessionID)
+}
+
+func SessionIDFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionIDContextKey{}).(string)
+	return sessionID
+}
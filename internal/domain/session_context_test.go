@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestResolveTenantID(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		tenantID   string
+		want       string
+		wantStatus int
+	}{
+		{
+			name:       "context without a scope is rejected",
+			ctx:        context.Background(),
+			tenantID:   "tenant-a",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "empty scope is rejected",
+			ctx:        ContextWithTenantScope(context.Background(), ""),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "scoped caller gets its own tenant",
+			ctx:  ContextWithTenantScope(context.Background(), "tenant-a"),
+			want: "tenant-a",
+		},
+		{
+			name:     "scoped caller asking for its own tenant",
+			ctx:      ContextWithTenantScope(context.Background(), "tenant-a"),
+			tenantID: "tenant-a",
+			want:     "tenant-a",
+		},
+		{
+			name:       "scoped caller asking for another tenant",
+			ctx:        ContextWithTenantScope(context.Background(), "tenant-a"),
+			tenantID:   "tenant-b",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:     "cross-tenant caller gets the requested tenant",
+			ctx:      ContextWithAllTenants(context.Background()),
+			tenantID: "tenant-b",
+			want:     "tenant-b",
+		},
+		{
+			name: "cross-tenant caller without a requested tenant",
+			ctx:  ContextWithTenantScope(context.Background(), ALL_TENANTS),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveTenantID(tt.ctx, tt.tenantID)
+
+			if tt.wantStatus != 0 {
+				var customErr *CustomError
+				if !errors.As(err, &customErr) || customErr.StatusCode() != tt.wantStatus {
+					t.Fatalf("ResolveTenantID(%q) error = %v, want status %d", tt.tenantID, err, tt.wantStatus)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ResolveTenantID(%q) unexpected error: %v", tt.tenantID, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTenantID(%q) = %q, want %q", tt.tenantID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsInTenantScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		tenantID string
+		want     bool
+	}{
+		{name: "context without a scope", ctx: context.Background(), tenantID: "tenant-a", want: false},
+		{name: "context without a scope and no tenant", ctx: context.Background(), tenantID: "", want: false},
+		{name: "same tenant", ctx: ContextWithTenantScope(context.Background(), "tenant-a"), tenantID: "tenant-a", want: true},
+		{name: "other tenant", ctx: ContextWithTenantScope(context.Background(), "tenant-a"), tenantID: "tenant-b", want: false},
+		{name: "all tenants", ctx: ContextWithAllTenants(context.Background()), tenantID: "tenant-b", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsInTenantScope(tt.ctx, tt.tenantID); got != tt.want {
+				t.Errorf("IsInTenantScope(%q) = %v, want %v", tt.tenantID, got, tt.want)
+			}
+		})
+	}
+}
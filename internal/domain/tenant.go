@@ -16,28 +16,30 @@ type TenantRepository interface {
 }
 
 type Tenant struct {
-	TenantID        string
-	CompanyName     string
-	LegalName       string
-	Document        string
-	DocumentType    DocumentType
-	BusinessContact Contact
-	Template        TenantPlatformTemplate
-	Tickets         []Ticket
-	CreatedBy       string
-	CreatedAt       time.Time
-	UpdatedBy       string
-	UpdatedAt       time.Time
-	Active          bool
+	TenantID         string
+	CompanyName      string
+	LegalName        string
+	Document         string
+	DocumentType     DocumentType
+	BusinessContact  Contact
+	Template         TenantPlatformTemplate
+	AttachmentPolicy AttachmentPolicy
+	Tickets          []Ticket
+	CreatedBy        string
+	CreatedAt        time.Time
+	UpdatedBy        string
+	UpdatedAt        time.Time
+	Active           bool
 }
 
 type UpdateTenant struct {
-	CompanyName     *string
-	LegalName       *string
-	Document        *string
-	DocumentType    *DocumentType
-	BusinessContact *Contact
-	UpdatedBy       string
+	CompanyName      *string
+	LegalName        *string
+	Document         *string
+	DocumentType     *DocumentType
+	BusinessContact  *Contact
+	AttachmentPolicy *AttachmentPolicy
+	UpdatedBy        string
 }
 
 func (c *Tenant) MergeUpdate(newTenant UpdateTenant) {
@@ -64,6 +66,10 @@ func (c *Tenant) MergeUpdate(newTenant UpdateTenant) {
 	if newTenant.BusinessContact != nil {
 		c.BusinessContact = *newTenant.BusinessContact
 	}
+
+	if newTenant.AttachmentPolicy != nil {
+		c.AttachmentPolicy = *newTenant.AttachmentPolicy
+	}
 }
 
 type TenantFilters struct {
@@ -52,6 +52,11 @@ type TicketFilters struct {
 	CustomerID []string
 	Status     []string
 	Region     []string
+	// CreatedFrom is inclusive and CreatedTo is exclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// DueBefore is exclusive
+	DueBefore *time.Time
 	PagingFilter
 }
 
@@ -121,14 +126,10 @@ func NewTicket(
 	}, nil
 }
 
-func (c *Ticket) MergeUpdate(updateTicket TicketUpdate) {
+func (c *Ticket) MergeUpdate(updateTicket TicketUpdate) error {
 	c.UpdatedAt = time.Now().UTC()
 	c.UpdatedBy = updateTicket.UpdatedBy
 
-	if updateTicket.Status != nil {
-		c.Status = *updateTicket.Status
-	}
-
 	if updateTicket.OwnerID != nil {
 		c.OwnerID = *updateTicket.OwnerID
 	}
@@ -144,4 +145,15 @@ func (c *Ticket) MergeUpdate(updateTicket TicketUpdate) {
 	if updateTicket.ClosedAt != nil {
 		c.ClosedAt = updateTicket.ClosedAt
 	}
+
+	if updateTicket.Status != nil {
+		return c.TransitionTo(*updateTicket.Status)
+	}
+
+	return nil
+}
+
+// IsOverdue tells whether the ticket is still open after its due date.
+func (c *Ticket) IsOverdue(now time.Time) bool {
+	return c.IsOpen() && !c.DueDate.IsZero() && c.DueDate.Before(now)
 }
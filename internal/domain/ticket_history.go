@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TicketHistoryRepository interface {
+	Create(ctx context.Context, event TicketEvent) (string, error)
+	Search(ctx context.Context, filters TicketHistoryFilters) (PagingResult[TicketEvent], error)
+}
+
+type TicketEvent struct {
+	EventID   string
+	TicketID  string
+	TenantID  string
+	Action    TicketAction
+	Changes   []TicketFieldChange
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+type TicketFieldChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+type TicketHistoryFilters struct {
+	TicketID string
+	PagingFilter
+}
+
+type TicketAction string
+
+const (
+	TICKET_CREATED TicketAction = "ticket_created"
+	TICKET_UPDATED TicketAction = "ticket_updated"
+	OWNER_CHANGED  TicketAction = "owner_changed"
+	LEAD_CHANGED   TicketAction = "lead_changed"
+	STATUS_CHANGED TicketAction = "status_changed"
+)
+
+func NewTicketEvent(ticketID, tenantID string, action TicketAction, changes []TicketFieldChange, author string) (TicketEvent, error) {
+	eventID, err := uuid.NewUUID()
+	if err != nil {
+		return TicketEvent{}, err
+	}
+
+	return TicketEvent{
+		EventID:   eventID.String(),
+		TicketID:  ticketID,
+		TenantID:  tenantID,
+		Action:    action,
+		Changes:   changes,
+		CreatedBy: author,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+func DiffTickets(before, after Ticket) []TicketFieldChange {
+	changes := make([]TicketFieldChange, 0)
+
+	appendChange := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, TicketFieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	appendChange("status", string(before.Status), string(after.Status))
+	appendChange("owner_id", before.OwnerID, after.OwnerID)
+	appendChange("lead_id", before.LeadID, after.LeadID)
+	appendChange("priority", string(before.Priority), string(after.Priority))
+	appendChange("target_date", formatOptionalTime(before.TargetDate), formatOptionalTime(after.TargetDate))
+	appendChange("closed_at", formatOptionalTime(before.ClosedAt), formatOptionalTime(after.ClosedAt))
+
+	return changes
+}
+
+func formatOptionalTime(value *time.Time) string {
+	if value == nil || value.IsZero() {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type ticketStatusGuard func(crmTicket Ticket) error
+
+type ticketStatusHook func(crmTicket *Ticket)
+
+var ticketStatusTransitions = map[TicketStatus][]TicketStatus{
+	NEW:           {CUSTOMER_INFO, CANCELED},
+	CUSTOMER_INFO: {WAITING_LEAD, CANCELED},
+	WAITING_LEAD:  {ONGOING, CANCELED},
+	ONGOING:       {REPORT, WAITING_LEAD, CANCELED},
+	REPORT:        {PAYMENT, ONGOING, CANCELED},
+	PAYMENT:       {RECEIPT, CANCELED},
+	RECEIPT:       {CLOSED, CANCELED},
+	CLOSED:        {},
+	CANCELED:      {},
+}
+
+var ticketStatusGuards = map[TicketStatus]ticketStatusGuard{
+	CUSTOMER_INFO: requireTicketOwner,
+	ONGOING:       requireTicketLead,
+	REPORT: func(crmTicket Ticket) error {
+		if err := requireTicketLead(crmTicket); err != nil {
+			return err
+		}
+		return requireTicketTargetDate(crmTicket)
+	},
+}
+
+var ticketStatusHooks = map[TicketStatus]ticketStatusHook{
+	CLOSED: func(crmTicket *Ticket) {
+		closedAt := time.Now().UTC()
+		crmTicket.ClosedAt = &closedAt
+	},
+}
+
+func (s TicketStatus) IsValid() bool {
+	_, ok := ticketStatusTransitions[s]
+	return ok
+}
+
+func (s TicketStatus) AllowedTransitions() []TicketStatus {
+	return slices.Clone(ticketStatusTransitions[s])
+}
+
+func (s TicketStatus) CanTransitionTo(newStatus TicketStatus) bool {
+	return slices.Contains(ticketStatusTransitions[s], newStatus)
+}
+
+func (c *Ticket) TransitionTo(newStatus TicketStatus) error {
+	if c.Status == newStatus {
+		return nil
+	}
+
+	if !newStatus.IsValid() {
+		return NewValidationError("invalid ticket status", map[string]any{
+			"status": newStatus,
+		})
+	}
+
+	if !c.Status.CanTransitionTo(newStatus) {
+		return NewValidationError("ticket status transition not allowed", map[string]any{
+			"ticket_id":        c.TicketID,
+			"current_status":   c.Status,
+			"requested_status": newStatus,
+			"allowed_statuses": c.Status.AllowedTransitions(),
+		})
+	}
+
+	if guard, ok := ticketStatusGuards[newStatus]; ok {
+		if err := guard(*c); err != nil {
+			return err
+		}
+	}
+
+	c.Status = newStatus
+
+	if hook, ok := ticketStatusHooks[newStatus]; ok {
+		hook(c)
+	}
+
+	return nil
+}
+
+func requireTicketOwner(crmTicket Ticket) error {
+	if crmTicket.OwnerID == "" {
+		return NewValidationError("ticket must have an owner", map[string]any{"ticket_id": crmTicket.TicketID})
+	}
+	return nil
+}
+
+func requireTicketLead(crmTicket Ticket) error {
+	if crmTicket.LeadID == "" {
+		return NewValidationError("ticket must have a lead", map[string]any{"ticket_id": crmTicket.TicketID})
+	}
+	return nil
+}
+
+func requireTicketTargetDate(crmTicket Ticket) error {
+	if crmTicket.TargetDate == nil || crmTicket.TargetDate.IsZero() {
+		return NewValidationError("ticket must have a target date", map[string]any{"ticket_id": crmTicket.TicketID})
+	}
+	return nil
+}
+
+// IsOpen tells whether the ticket is still being worked on, that is, it can still change status.
+func (c *Ticket) IsOpen() bool {
+	return len(ticketStatusTransitions[c.Status]) > 0
+}
+
+// OpenTicketStatuses returns the statuses of tickets that are still being worked on, sorted by name.
+func OpenTicketStatuses() []TicketStatus {
+	statuses := make([]TicketStatus, 0, len(ticketStatusTransitions))
+	for status, transitions := range ticketStatusTransitions {
+		if len(transitions) > 0 {
+			statuses = append(statuses, status)
+		}
+	}
+	slices.Sort(statuses)
+
+	return statuses
+}
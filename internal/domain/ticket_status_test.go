@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTicketStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from TicketStatus
+		to   TicketStatus
+		want bool
+	}{
+		{name: "new to customer info", from: NEW, to: CUSTOMER_INFO, want: true},
+		{name: "ongoing back to waiting lead", from: ONGOING, to: WAITING_LEAD, want: true},
+		{name: "report back to ongoing", from: REPORT, to: ONGOING, want: true},
+		{name: "receipt to closed", from: RECEIPT, to: CLOSED, want: true},
+		{name: "any open status can be canceled", from: PAYMENT, to: CANCELED, want: true},
+		{name: "skipping a status", from: NEW, to: ONGOING, want: false},
+		{name: "payment back to report", from: PAYMENT, to: REPORT, want: false},
+		{name: "closed is final", from: CLOSED, to: NEW, want: false},
+		{name: "canceled is final", from: CANCELED, to: NEW, want: false},
+		{name: "unknown status", from: TicketStatus("Unknown"), to: NEW, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTicketTransitionTo(t *testing.T) {
+	targetDate := time.Now().UTC().Add(24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		ticket     Ticket
+		to         TicketStatus
+		wantStatus TicketStatus
+		wantErr    bool
+	}{
+		{
+			name:       "same status is a no-op",
+			ticket:     Ticket{Status: ONGOING},
+			to:         ONGOING,
+			wantStatus: ONGOING,
+		},
+		{
+			name:       "invalid status",
+			ticket:     Ticket{Status: NEW},
+			to:         TicketStatus("Unknown"),
+			wantStatus: NEW,
+			wantErr:    true,
+		},
+		{
+			name:       "transition not allowed",
+			ticket:     Ticket{Status: NEW, OwnerID: "owner"},
+			to:         REPORT,
+			wantStatus: NEW,
+			wantErr:    true,
+		},
+		{
+			name:       "customer info requires an owner",
+			ticket:     Ticket{Status: NEW},
+			to:         CUSTOMER_INFO,
+			wantStatus: NEW,
+			wantErr:    true,
+		},
+		{
+			name:       "customer info with an owner",
+			ticket:     Ticket{Status: NEW, OwnerID: "owner"},
+			to:         CUSTOMER_INFO,
+			wantStatus: CUSTOMER_INFO,
+		},
+		{
+			name:       "ongoing requires a lead",
+			ticket:     Ticket{Status: WAITING_LEAD},
+			to:         ONGOING,
+			wantStatus: WAITING_LEAD,
+			wantErr:    true,
+		},
+		{
+			name:       "report requires a target date",
+			ticket:     Ticket{Status: ONGOING, LeadID: "lead"},
+			to:         REPORT,
+			wantStatus: ONGOING,
+			wantErr:    true,
+		},
+		{
+			name:       "report with a lead and a target date",
+			ticket:     Ticket{Status: ONGOING, LeadID: "lead", TargetDate: &targetDate},
+			to:         REPORT,
+			wantStatus: REPORT,
+		},
+		{
+			name:       "canceling skips the guards",
+			ticket:     Ticket{Status: NEW},
+			to:         CANCELED,
+			wantStatus: CANCELED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crmTicket := tt.ticket
+			err := crmTicket.TransitionTo(tt.to)
+
+			if tt.wantErr {
+				var customErr *CustomError
+				if !errors.As(err, &customErr) || customErr.StatusCode() != http.StatusBadRequest {
+					t.Fatalf("TransitionTo(%s) error = %v, want a validation error", tt.to, err)
+				}
+			} else if err != nil {
+				t.Fatalf("TransitionTo(%s) unexpected error: %v", tt.to, err)
+			}
+
+			if crmTicket.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", crmTicket.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestTicketTransitionToClosedSetsClosedAt(t *testing.T) {
+	crmTicket := Ticket{Status: RECEIPT}
+
+	if err := crmTicket.TransitionTo(CLOSED); err != nil {
+		t.Fatalf("TransitionTo(%s) unexpected error: %v", CLOSED, err)
+	}
+
+	if crmTicket.ClosedAt == nil {
+		t.Error("ClosedAt was not set")
+	}
+}
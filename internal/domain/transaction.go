@@ -11,7 +11,7 @@ type TransactionRepository interface {
 	CreateTransaction(ctx context.Context, transaction Transaction) (string, error)
 	GetTransaction(ctx context.Context, transactionID string) (Transaction, error)
 	UpdateTransaction(ctx context.Context, transaction Transaction) error
-	SearchTransactions(ctx context.Context, filters TransactionFilters) ([]Transaction, error)
+	SearchTransactions(ctx context.Context, filters TransactionFilters) (PagingResult[Transaction], error)
 }
 
 type Transaction struct {
@@ -19,6 +19,7 @@ type Transaction struct {
 	Type          TransactionType
 	Value         float64
 	TicketID      string
+	TenantID      string
 	AttachmentID  string
 	Status        TransactionStatus
 	CreatedBy     string
@@ -37,8 +38,10 @@ type TransactionUpdate struct {
 
 type TransactionFilters struct {
 	TicketIDs []string
+	TenantIDs []string
 	Status    []string
 	Types     []string
+	PagingFilter
 }
 
 type TransactionType string
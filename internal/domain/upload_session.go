@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UploadSessionRepository interface {
+	Create(ctx context.Context, session UploadSession) (string, error)
+	GetByID(ctx context.Context, sessionID string) (*UploadSession, error)
+	Update(ctx context.Context, session UploadSession) error
+}
+
+type UploadSessionStatus string
+
+const (
+	UPLOAD_SESSION_PENDING   UploadSessionStatus = "pending"
+	UPLOAD_SESSION_COMPLETED UploadSessionStatus = "completed"
+)
+
+// UploadSession reserves a key in the ticket's folder of the attachment bucket for a file the client
+// uploads straight to the bucket. The attachment is only created once the upload is confirmed.
+type UploadSession struct {
+	SessionID     string
+	TenantID      string
+	TicketID      string
+	CommentID     string
+	Key           string
+	FileName      string
+	FileExtension string
+	ContentType   string
+	Size          int
+	Visibility    Visibility
+	Status        UploadSessionStatus
+	AttachmentID  string
+	CreatedBy     string
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	CompletedAt   *time.Time
+}
+
+func NewUploadSession(
+	comment Comment,
+	fileName string,
+	contentType string,
+	size int,
+	visibility Visibility,
+	createdBy string,
+	expiresIn time.Duration,
+) (UploadSession, error) {
+	now := time.Now().UTC()
+	sessionID, err := uuid.NewRandom()
+	if err != nil {
+		return UploadSession{}, err
+	}
+
+	fileExtension := strings.ToLower(filepath.Ext(fileName))
+	if visibility == "" {
+		visibility = comment.Visibility
+	}
+
+	return UploadSession{
+		SessionID:     sessionID.String(),
+		TenantID:      comment.TenantID,
+		TicketID:      comment.TicketID,
+		CommentID:     comment.CommentID,
+		Key:           AttachmentKeyPrefix(comment.TenantID, comment.TicketID) + sessionID.String() + fileExtension,
+		FileName:      filepath.Base(fileName),
+		FileExtension: strings.TrimPrefix(fileExtension, "."),
+		ContentType:   contentType,
+		Size:          size,
+		Visibility:    visibility.Narrowest(comment.Visibility),
+		Status:        UPLOAD_SESSION_PENDING,
+		CreatedBy:     createdBy,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(expiresIn),
+	}, nil
+}
+
+func (s *UploadSession) Complete(attachmentID string) {
+	now := time.Now().UTC()
+
+	s.Status = UPLOAD_SESSION_COMPLETED
+	s.AttachmentID = attachmentID
+	s.CompletedAt = &now
+}
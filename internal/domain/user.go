@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,36 +12,40 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user User) (string, error)
 	GetByID(ctx context.Context, userID string) (*User, error)
-	Search(ctx context.Context, filters UserFilters) ([]User, error)
+	Search(ctx context.Context, filters UserFilters) (PagingResult[User], error)
 	Update(ctx context.Context, userToUpdate User) error
 	Delete(ctx context.Context, userID string) error
 }
 
 type User struct {
-	UserID    string
-	Username  string
-	FirstName string
-	LastName  string
-	Email     string
-	Role      UserRole
-	Region    int
-	Password  string
-	Active    bool
-	Tickets   []Ticket
-	CreatedBy string
-	CreatedAt time.Time
-	UpdatedBy string
-	UpdatedAt time.Time
+	UserID             string
+	TenantID           string
+	Username           string
+	FirstName          string
+	LastName           string
+	Email              string
+	Role               UserRole
+	Region             int
+	Password           string
+	Active             bool
+	MustChangePassword bool
+	Tickets            []Ticket
+	CreatedBy          string
+	CreatedAt          time.Time
+	UpdatedBy          string
+	UpdatedAt          time.Time
 }
 
 type UserFilters struct {
 	UserID    []string
+	TenantID  []string
 	Username  []string
 	FirstName []string
 	Email     []string
 	Role      []string
 	Region    []string
 	Active    *bool
+	PagingFilter
 }
 
 type UserUpdate struct {
@@ -49,7 +54,6 @@ type UserUpdate struct {
 	Email     *string
 	Role      *UserRole
 	Region    *int
-	Password  *string
 	Active    *bool
 }
 
@@ -61,7 +65,7 @@ const (
 	OPERATOR       UserRole = "operator"
 )
 
-func NewUser(firstName, lastName, email, password, author, username string, role UserRole, region int) (User, error) {
+func NewUser(tenantID, firstName, lastName, email, password, author, username string, role UserRole, region int) (User, error) {
 	now := time.Now().UTC()
 	userID, err := uuid.NewRandom()
 	if err != nil {
@@ -75,6 +79,7 @@ func NewUser(firstName, lastName, email, password, author, username string, role
 
 	return User{
 		UserID:    userID.String(),
+		TenantID:  tenantID,
 		Username:  username,
 		FirstName: firstName,
 		LastName:  lastName,
@@ -87,6 +92,8 @@ func NewUser(firstName, lastName, email, password, author, username string, role
 		UpdatedBy: author,
 		UpdatedAt: now,
 		Active:    true,
+		// users registered by someone else start with a password they didn't choose
+		MustChangePassword: author != "",
 	}, nil
 }
 
@@ -104,6 +111,34 @@ func (u *User) ComparePassword(passwordInput string) bool {
 	return err == nil
 }
 
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// CompareDummyPassword spends the same time as a real password check, so unknown accounts
+// can't be told apart from wrong passwords by response time.
+func CompareDummyPassword(passwordInput string) {
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(passwordInput))
+}
+
+func (u *User) ChangePassword(newPassword string) error {
+	if err := ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	encryptedPassword, err := encryptPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	u.Password = encryptedPassword
+	u.MustChangePassword = false
+	u.UpdatedAt = time.Now().UTC()
+
+	return nil
+}
+
 func (u *User) MergeUpdate(userUpdate UserUpdate, author string) {
 	u.UpdatedAt = time.Now().UTC()
 
@@ -0,0 +1,68 @@
+package domain
+
+// Visibility is the widest audience that can see a comment or an attachment. Internal notes stay with
+// the tenant's users in the CRM, tenant-visible ones also go into the documents generated for the
+// tenant, like reports, and customer-visible ones can be shown to the ticket's customer as well.
+type Visibility string
+
+const (
+	INTERNAL_VISIBILITY Visibility = "internal"
+	TENANT_VISIBILITY   Visibility = "tenant"
+	CUSTOMER_VISIBILITY Visibility = "customer"
+)
+
+var visibilityLevels = map[Visibility]int{
+	INTERNAL_VISIBILITY: 0,
+	TENANT_VISIBILITY:   1,
+	CUSTOMER_VISIBILITY: 2,
+}
+
+// Audience is the visibility of what the role's users can see. Every CRM role works the tickets from
+// inside, so they see internal notes; unknown roles only see what the customer can.
+func (r UserRole) Audience() Visibility {
+	if !r.IsValid() {
+		return CUSTOMER_VISIBILITY
+	}
+	return INTERNAL_VISIBILITY
+}
+
+// ParseVisibility validates the visibility sent by a client. Empty values are returned as they are, so
+// the caller can apply its default.
+func ParseVisibility(visibility string) (Visibility, error) {
+	if visibility == "" {
+		return "", nil
+	}
+
+	parsed := Visibility(visibility)
+	if _, ok := visibilityLevels[parsed]; !ok {
+		return "", NewValidationError("invalid visibility", map[string]any{
+			"visibility": visibility,
+			"allowed":    []Visibility{INTERNAL_VISIBILITY, TENANT_VISIBILITY, CUSTOMER_VISIBILITY},
+		})
+	}
+
+	return parsed, nil
+}
+
+// OrDefault is the visibility of comments and attachments saved before visibility existed, which were
+// already part of the reports.
+func (v Visibility) OrDefault() Visibility {
+	if _, ok := visibilityLevels[v]; !ok {
+		return TENANT_VISIBILITY
+	}
+	return v
+}
+
+// VisibleTo tells whether the audience can see what has this visibility.
+func (v Visibility) VisibleTo(audience Visibility) bool {
+	return visibilityLevels[v.OrDefault()] >= visibilityLevels[audience.OrDefault()]
+}
+
+// Narrowest returns the visibility with the smallest audience, so an attachment is never seen by
+// someone who can't see its comment.
+func (v Visibility) Narrowest(other Visibility) Visibility {
+	if visibilityLevels[other.OrDefault()] < visibilityLevels[v.OrDefault()] {
+		return other.OrDefault()
+	}
+	return v.OrDefault()
+}
@@ -6,8 +6,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
 )
 
+const tenantHeader = "X-Tenant-ID"
+
 type AuthenticationMiddleware struct {
 	authService application.AuthService
 }
@@ -42,15 +45,53 @@ func (a *AuthenticationMiddleware) Authenticate() gin.HandlerFunc {
 			return
 		}
 
-		userID := claims["user_id"].(string)
-		err = a.authService.VerifyUserSession(ctx.Request.Context(), userID)
+		userID, _ := claims["user_id"].(string)
+		sessionID, _ := claims["session_id"].(string)
+		user, err := a.authService.VerifyUserSession(ctx.Request.Context(), userID, sessionID)
 		if err != nil {
 			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			ctx.Abort()
 			return
 		}
 
+		tenantID, _ := claims["tenant_id"].(string)
+		if tenantID != user.TenantID {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authentication token"})
+			ctx.Abort()
+			return
+		}
+
+		tenantScope, err := resolveTenantScope(ctx.GetHeader(tenantHeader), *user)
+		if err != nil {
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+
 		ctx.Set("user_id", userID)
+		ctx.Set("user_role", user.Role)
+		ctx.Set("must_change_password", user.MustChangePassword)
+		ctx.Set("tenant_id", tenantScope)
+
+		requestCtx := domain.ContextWithUserID(ctx.Request.Context(), userID)
+		requestCtx = domain.ContextWithUserRole(requestCtx, user.Role)
+		requestCtx = domain.ContextWithTenantScope(requestCtx, tenantScope)
+		requestCtx = domain.ContextWithSessionID(requestCtx, sessionID)
+		ctx.Request = ctx.Request.WithContext(requestCtx)
 		ctx.Next()
 	}
 }
+
+func resolveTenantScope(requestedTenant string, user domain.User) (string, error) {
+	if requestedTenant == "" || requestedTenant == user.TenantID {
+		return user.TenantID, nil
+	}
+
+	if !user.Role.Can(domain.TENANT_RESOURCE, domain.CROSS_TENANT_ACTION) {
+		return "", domain.NewForbiddenError("user is not allowed to access other tenants", map[string]any{
+			"tenant_id": requestedTenant,
+		})
+	}
+
+	return requestedTenant, nil
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type AuthorizationMiddleware struct{}
+
+func NewAuthorizationMiddleware() AuthorizationMiddleware {
+	return AuthorizationMiddleware{}
+}
+
+func (a *AuthorizationMiddleware) Authorize(resource domain.Resource, action domain.Action) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetBool("must_change_password") {
+			ctx.Error(domain.NewForbiddenError("password change required", nil))
+			ctx.Abort()
+			return
+		}
+
+		role := domain.UserRoleFromContext(ctx.Request.Context())
+
+		if !role.Can(resource, action) {
+			ctx.Error(domain.NewForbiddenError("user is not allowed to perform this action", map[string]any{
+				"role":     role,
+				"resource": resource,
+				"action":   action,
+			}))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+)
+
+type AttachmentArchiveController struct {
+	attachmentArchiveService application.AttachmentArchiveService
+}
+
+func NewAttachmentArchiveController(attachmentArchiveService application.AttachmentArchiveService) AttachmentArchiveController {
+	return AttachmentArchiveController{
+		attachmentArchiveService: attachmentArchiveService,
+	}
+}
+
+func (c *AttachmentArchiveController) DownloadTicketAttachments(ctx *gin.Context) {
+	crmTicket, err := c.attachmentArchiveService.GetTicket(ctx.Request.Context(), ctx.Param("ticketID"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	fileName := crmTicket.ExternalReference
+	if fileName == "" {
+		fileName = crmTicket.TicketID
+	}
+
+	// the ZIP is streamed as files are read from the bucket, so errors from here on can't be turned
+	// into an error response anymore; the client gets a truncated file instead
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("attachments-%s.zip", fileName)))
+	ctx.Header("Content-Type", "application/zip")
+	ctx.Status(http.StatusOK)
+
+	if err = c.attachmentArchiveService.WriteArchive(ctx.Request.Context(), *crmTicket, ctx.Writer); err != nil {
+		log.Printf("failed to write attachments of ticket %s: %v", crmTicket.TicketID, err)
+		ctx.Abort()
+	}
+}
@@ -7,14 +7,22 @@ import (
 )
 
 type AttachmentDTO struct {
-	AttachmentID  string    `json:"attachment_id"`
-	FileExtension string    `json:"file_extension"`
-	FileName      string    `json:"file_name"`
-	Key           string    `json:"key"`
-	Size          int       `json:"size"`
-	AttachmentURL string    `json:"url"`
-	CreatedBy     string    `json:"created_by"`
-	CreatedAt     time.Time `json:"created_at"`
+	AttachmentID  string     `json:"attachment_id"`
+	FileExtension string     `json:"file_extension"`
+	FileName      string     `json:"file_name"`
+	Key           string     `json:"key"`
+	Size          int        `json:"size"`
+	ContentType   string     `json:"content_type,omitempty"`
+	Checksum      string     `json:"checksum,omitempty"`
+	Visibility    string     `json:"visibility"`
+	AttachmentURL string     `json:"url"`
+	ThumbnailURL  string     `json:"thumbnail_url,omitempty"`
+	TakenAt       *time.Time `json:"taken_at,omitempty"`
+	Latitude      *float64   `json:"latitude,omitempty"`
+	Longitude     *float64   `json:"longitude,omitempty"`
+	CameraModel   string     `json:"camera_model,omitempty"`
+	CreatedBy     string     `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
 }
 
 type CreateAttachmentDTO struct {
@@ -23,18 +31,29 @@ type CreateAttachmentDTO struct {
 	Key           string `json:"key"`
 	Size          int    `json:"size"`
 	AttachmentURL string `json:"url"`
-	CreatedBy     string `json:"created_by"`
+	Visibility    string `json:"visibility"`
 }
 
 func mapCreateAttachmentDTOToAttachment(createAttachmentDTO CreateAttachmentDTO) (domain.Attachment, error) {
-	return domain.NewAttachment(
+	visibility, err := domain.ParseVisibility(createAttachmentDTO.Visibility)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	attachment, err := domain.NewAttachment(
 		createAttachmentDTO.FileName,
 		createAttachmentDTO.AttachmentURL,
 		createAttachmentDTO.FileExtension,
 		createAttachmentDTO.Key,
-		createAttachmentDTO.CreatedBy,
+		"",
 		createAttachmentDTO.Size,
 	)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	attachment.Visibility = visibility
+
+	return attachment, nil
 }
 
 func mapAttachmentToAttachmentDTO(attachment domain.Attachment) AttachmentDTO {
@@ -43,7 +62,15 @@ func mapAttachmentToAttachmentDTO(attachment domain.Attachment) AttachmentDTO {
 		FileExtension: attachment.FileExtension,
 		FileName:      attachment.FileName,
 		Size:          attachment.Size,
+		ContentType:   attachment.ContentType,
+		Checksum:      attachment.Checksum,
+		Visibility:    string(attachment.Visibility.OrDefault()),
 		AttachmentURL: attachment.AttachmentURL,
+		ThumbnailURL:  attachment.ThumbnailURL,
+		TakenAt:       attachment.TakenAt,
+		Latitude:      attachment.Latitude,
+		Longitude:     attachment.Longitude,
+		CameraModel:   attachment.CameraModel,
 		CreatedBy:     attachment.CreatedBy,
 		CreatedAt:     attachment.CreatedAt,
 	}
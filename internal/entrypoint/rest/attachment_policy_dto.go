@@ -0,0 +1,25 @@
+package rest
+
+import "github.com/icrxz/crm-api-core/internal/domain"
+
+type AttachmentPolicyDTO struct {
+	AllowedContentTypes []string `json:"allowed_content_types"`
+	MaxFileSize         int      `json:"max_file_size"`
+	MaxTicketSize       int      `json:"max_ticket_size"`
+}
+
+func mapAttachmentPolicyToAttachmentPolicyDTO(policy domain.AttachmentPolicy) AttachmentPolicyDTO {
+	return AttachmentPolicyDTO{
+		AllowedContentTypes: policy.AllowedContentTypes,
+		MaxFileSize:         policy.MaxFileSize,
+		MaxTicketSize:       policy.MaxTicketSize,
+	}
+}
+
+func mapAttachmentPolicyDTOToAttachmentPolicy(policyDTO AttachmentPolicyDTO) domain.AttachmentPolicy {
+	return domain.AttachmentPolicy{
+		AllowedContentTypes: policyDTO.AllowedContentTypes,
+		MaxFileSize:         policyDTO.MaxFileSize,
+		MaxTicketSize:       policyDTO.MaxTicketSize,
+	}
+}
@@ -5,15 +5,18 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
 )
 
 type AuthController struct {
-	authService application.AuthService
+	authService     application.AuthService
+	passwordService application.PasswordService
 }
 
-func NewAuthController(authService application.AuthService) AuthController {
+func NewAuthController(authService application.AuthService, passwordService application.PasswordService) AuthController {
 	return AuthController{
-		authService: authService,
+		authService:     authService,
+		passwordService: passwordService,
 	}
 }
 
@@ -25,16 +28,118 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
-	token, user, err := c.authService.Login(ctx.Request.Context(), credentials.Email, credentials.Password)
+	tokens, user, err := c.authService.Login(ctx.Request.Context(), credentials.Email, credentials.Password, ctx.ClientIP())
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	authResponseDTO := mapUserToAuthResponseDTO(token, *user)
+	authResponseDTO := mapUserToAuthResponseDTO(*tokens, *user)
 
 	ctx.JSON(http.StatusOK, authResponseDTO)
 }
 
+func (c *AuthController) Refresh(ctx *gin.Context) {
+	var refreshTokenDTO RefreshTokenDTO
+	if err := ctx.BindJSON(&refreshTokenDTO); err != nil {
+		ctx.Error(domain.NewValidationError("invalid request body", nil))
+		return
+	}
+
+	tokens, err := c.authService.Refresh(ctx.Request.Context(), refreshTokenDTO.RefreshToken)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapAuthTokensToAuthTokensDTO(*tokens))
+}
+
 func (c *AuthController) Logout(ctx *gin.Context) {
+	err := c.authService.Logout(ctx.Request.Context())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *AuthController) LogoutAll(ctx *gin.Context) {
+	err := c.authService.LogoutAll(ctx.Request.Context(), ctx.GetString("user_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *AuthController) RevokeUserSessions(ctx *gin.Context) {
+	userID := ctx.Param("userID")
+	if userID == "" {
+		ctx.Error(domain.NewValidationError("userID is required", nil))
+		return
+	}
+
+	err := c.authService.LogoutAll(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *AuthController) ForgotPassword(ctx *gin.Context) {
+	var forgotPasswordDTO ForgotPasswordDTO
+	if err := ctx.BindJSON(&forgotPasswordDTO); err != nil {
+		ctx.Error(domain.NewValidationError("invalid request body", nil))
+		return
+	}
+
+	err := c.passwordService.ForgotPassword(ctx.Request.Context(), forgotPasswordDTO.Email)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
+
+func (c *AuthController) ResetPassword(ctx *gin.Context) {
+	var resetPasswordDTO ResetPasswordDTO
+	if err := ctx.BindJSON(&resetPasswordDTO); err != nil {
+		ctx.Error(domain.NewValidationError("invalid request body", nil))
+		return
+	}
+
+	err := c.passwordService.ResetPassword(ctx.Request.Context(), resetPasswordDTO.Token, resetPasswordDTO.NewPassword)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *AuthController) ChangePassword(ctx *gin.Context) {
+	var changePasswordDTO ChangePasswordDTO
+	if err := ctx.BindJSON(&changePasswordDTO); err != nil {
+		ctx.Error(domain.NewValidationError("invalid request body", nil))
+		return
+	}
+
+	err := c.passwordService.ChangePassword(
+		ctx.Request.Context(),
+		ctx.GetString("user_id"),
+		changePasswordDTO.OldPassword,
+		changePasswordDTO.NewPassword,
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
 }
@@ -1,32 +1,70 @@
 package rest
 
-import "github.com/icrxz/crm-api-core/internal/domain"
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
 
 type CredentialsDTO struct {
 	Email    string `json:"email" validate:"required"`
 	Password string `json:"password" validate:"required"`
 }
 
+type ForgotPasswordDTO struct {
+	Email string `json:"email" validate:"required"`
+}
+
+type ResetPasswordDTO struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+type ChangePasswordDTO struct {
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+type RefreshTokenDTO struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
 type AuthUserDTO struct {
-	UserID string `json:"user_id" validate:"required"`
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID             string `json:"user_id" validate:"required"`
+	Name               string `json:"name"`
+	Email              string `json:"email"`
+	Role               string `json:"role"`
+	MustChangePassword bool   `json:"must_change_password"`
+}
+
+type AuthTokensDTO struct {
+	Token        string    `json:"token" validate:"required"`
+	RefreshToken string    `json:"refresh_token" validate:"required"`
+	ExpiresAt    time.Time `json:"expires_at"`
 }
 
 type AuthResponseDTO struct {
-	Token string      `json:"token" validate:"required"`
-	User  AuthUserDTO `json:"user" validate:"required"`
+	AuthTokensDTO
+	User AuthUserDTO `json:"user" validate:"required"`
+}
+
+func mapAuthTokensToAuthTokensDTO(tokens domain.AuthTokens) AuthTokensDTO {
+	return AuthTokensDTO{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+	}
 }
 
-func mapUserToAuthResponseDTO(token string, user domain.User) AuthResponseDTO {
+func mapUserToAuthResponseDTO(tokens domain.AuthTokens, user domain.User) AuthResponseDTO {
 	return AuthResponseDTO{
-		Token: token,
+		AuthTokensDTO: mapAuthTokensToAuthTokensDTO(tokens),
 		User: AuthUserDTO{
-			UserID: user.UserID,
-			Name:   user.FirstName,
-			Email:  user.Email,
-			Role:   string(user.Role),
+			UserID:             user.UserID,
+			Name:               user.FirstName,
+			Email:              user.Email,
+			Role:               string(user.Role),
+			MustChangePassword: user.MustChangePassword,
 		},
 	}
 }
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/icrxz/crm-api-core/internal/application"
@@ -25,6 +26,11 @@ func (c *CommentController) CreateComment(ctx *gin.Context) {
 		return
 	}
 
+	if ctx.ContentType() == gin.MIMEMultipartPOSTForm {
+		c.createCommentWithFiles(ctx, ticketID)
+		return
+	}
+
 	var commentDTO CreateCommentDTO
 	if err := ctx.ShouldBindJSON(&commentDTO); err != nil {
 		ctx.Error(domain.NewValidationError("invalid request body", nil))
@@ -37,7 +43,53 @@ func (c *CommentController) CreateComment(ctx *gin.Context) {
 		return
 	}
 
-	commentID, err := c.commentService.Create(ctx, comment)
+	commentID, err := c.commentService.Create(ctx.Request.Context(), comment)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"comment_id": commentID})
+}
+
+// createCommentWithFiles handles comments sent as multipart forms, with the files to attach in the
+// `files` field, so the API stores them instead of trusting keys sent by the client.
+func (c *CommentController) createCommentWithFiles(ctx *gin.Context, ticketID string) {
+	var commentDTO CreateCommentDTO
+	if err := ctx.ShouldBind(&commentDTO); err != nil {
+		ctx.Error(domain.NewValidationError("invalid request body", nil))
+		return
+	}
+
+	comment, err := mapCreateCommentDTOToComment(commentDTO, ticketID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		ctx.Error(domain.NewValidationError("invalid multipart form", nil))
+		return
+	}
+
+	uploads := make([]domain.AttachmentUpload, 0, len(form.File["files"]))
+	for _, fileHeader := range form.File["files"] {
+		file, err := fileHeader.Open()
+		if err != nil {
+			ctx.Error(domain.NewValidationError("could not read file", map[string]any{"file_name": fileHeader.Filename}))
+			return
+		}
+		defer file.Close()
+
+		uploads = append(uploads, domain.AttachmentUpload{
+			FileName:    fileHeader.Filename,
+			ContentType: fileHeader.Header.Get("Content-Type"),
+			File:        file,
+		})
+	}
+
+	commentID, err := c.commentService.CreateWithFiles(ctx.Request.Context(), comment, uploads)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -53,7 +105,7 @@ func (c *CommentController) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	comment, err := c.commentService.GetByID(ctx, commentID)
+	comment, err := c.commentService.GetByID(ctx.Request.Context(), commentID)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -69,11 +121,95 @@ func (c *CommentController) GetByTicketID(ctx *gin.Context) {
 		return
 	}
 
-	comments, err := c.commentService.GetByTicketID(ctx, ticketID)
+	audience, err := domain.ParseVisibility(ctx.Query("visibility"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	comments, err := c.commentService.GetByTicketID(ctx.Request.Context(), ticketID)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
+	// the caller only gets what its role can see, and the query can narrow that further
+	comments = domain.VisibleComments(comments, domain.UserRoleFromContext(ctx.Request.Context()).Audience())
+	if audience != "" {
+		comments = domain.VisibleComments(comments, audience)
+	}
+
+	if tree := ctx.Query("tree"); tree != "" {
+		treeBool, err := strconv.ParseBool(tree)
+		if err != nil {
+			ctx.Error(domain.NewValidationError("tree must be a boolean", nil))
+			return
+		}
+
+		if treeBool {
+			ctx.JSON(http.StatusOK, mapCommentThreadsToCommentThreadDTOs(domain.BuildCommentThreads(comments)))
+			return
+		}
+	}
+
 	ctx.JSON(http.StatusOK, mapCommentsToCommentDTOs(comments))
 }
+
+func (c *CommentController) UpdateComment(ctx *gin.Context) {
+	commentID := ctx.Param("commentID")
+	if commentID == "" {
+		ctx.Error(domain.NewValidationError("commentID is required", nil))
+		return
+	}
+
+	var commentDTO UpdateCommentDTO
+	if err := ctx.ShouldBindJSON(&commentDTO); err != nil {
+		ctx.Error(domain.NewValidationError("invalid request body", nil))
+		return
+	}
+
+	commentUpdate, err := mapUpdateCommentDTOToCommentUpdate(commentDTO)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	comment, err := c.commentService.Update(ctx.Request.Context(), commentID, commentUpdate)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapCommentToCommentDTO(*comment))
+}
+
+func (c *CommentController) DeleteComment(ctx *gin.Context) {
+	commentID := ctx.Param("commentID")
+	if commentID == "" {
+		ctx.Error(domain.NewValidationError("commentID is required", nil))
+		return
+	}
+
+	if err := c.commentService.Delete(ctx.Request.Context(), commentID); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *CommentController) GetRevisions(ctx *gin.Context) {
+	commentID := ctx.Param("commentID")
+	if commentID == "" {
+		ctx.Error(domain.NewValidationError("commentID is required", nil))
+		return
+	}
+
+	revisions, err := c.commentService.GetRevisions(ctx.Request.Context(), commentID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapCommentRevisionsToCommentRevisionDTOs(revisions))
+}
@@ -7,37 +7,73 @@ import (
 )
 
 type CreateCommentDTO struct {
-	Content     string                `json:"content"`
-	CommentType domain.CommentType    `json:"comment_type"`
-	Attachments []CreateAttachmentDTO `json:"attachments"`
-	CreatedBy   string                `json:"created_by"`
+	Content         string                `json:"content" form:"content"`
+	CommentType     domain.CommentType    `json:"comment_type" form:"comment_type"`
+	ParentCommentID string                `json:"parent_comment_id" form:"parent_comment_id"`
+	Visibility      string                `json:"visibility" form:"visibility"`
+	Attachments     []CreateAttachmentDTO `json:"attachments" form:"-"`
 }
 
 type CommentDTO struct {
-	CommentID   string          `json:"comment_id"`
-	TicketID    string          `json:"ticket_id"`
-	Content     string          `json:"content"`
-	CommentType string          `json:"comment_type"`
-	CreatedBy   string          `json:"created_by"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedBy   string          `json:"updated_by"`
-	UpdatedAt   time.Time       `json:"updated_at"`
-	Attachments []AttachmentDTO `json:"attachments"`
+	CommentID       string          `json:"comment_id"`
+	TicketID        string          `json:"ticket_id"`
+	ParentCommentID string          `json:"parent_comment_id,omitempty"`
+	Content         string          `json:"content"`
+	CommentType     string          `json:"comment_type"`
+	Visibility      string          `json:"visibility"`
+	Mentions        []string        `json:"mentions"`
+	CreatedBy       string          `json:"created_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedBy       string          `json:"updated_by"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	Revision        int             `json:"revision"`
+	Attachments     []AttachmentDTO `json:"attachments"`
+}
+
+type CommentThreadDTO struct {
+	CommentDTO
+	Replies []CommentThreadDTO `json:"replies"`
+}
+
+type UpdateCommentDTO struct {
+	Content    *string `json:"content"`
+	Visibility *string `json:"visibility"`
+}
+
+type CommentRevisionDTO struct {
+	Revision    int       `json:"revision"`
+	Content     string    `json:"content"`
+	CommentType string    `json:"comment_type"`
+	Visibility  string    `json:"visibility"`
+	EditedBy    string    `json:"edited_by"`
+	EditedAt    time.Time `json:"edited_at"`
 }
 
 func mapCommentToCommentDTO(comment domain.Comment) CommentDTO {
 	attachmentsDTOs := mapAttachmentsToAttachmentDTOs(comment.Attachments)
+	for idx, attachment := range comment.Attachments {
+		attachmentsDTOs[idx].Visibility = string(attachment.Visibility.Narrowest(comment.Visibility))
+	}
+
+	mentions := comment.Mentions
+	if mentions == nil {
+		mentions = []string{}
+	}
 
 	return CommentDTO{
-		CommentID:   comment.CommentID,
-		TicketID:    comment.TicketID,
-		Content:     comment.Content,
-		CommentType: string(comment.CommentType),
-		CreatedBy:   comment.CreatedBy,
-		CreatedAt:   comment.CreatedAt,
-		UpdatedBy:   comment.UpdatedBy,
-		UpdatedAt:   comment.UpdatedAt,
-		Attachments: attachmentsDTOs,
+		CommentID:       comment.CommentID,
+		TicketID:        comment.TicketID,
+		ParentCommentID: comment.ParentCommentID,
+		Content:         comment.Content,
+		CommentType:     string(comment.CommentType),
+		Visibility:      string(comment.Visibility.OrDefault()),
+		Mentions:        mentions,
+		CreatedBy:       comment.CreatedBy,
+		CreatedAt:       comment.CreatedAt,
+		UpdatedBy:       comment.UpdatedBy,
+		UpdatedAt:       comment.UpdatedAt,
+		Revision:        max(comment.Revision, 1),
+		Attachments:     attachmentsDTOs,
 	}
 }
 
@@ -49,16 +85,32 @@ func mapCommentsToCommentDTOs(comments []domain.Comment) []CommentDTO {
 	return commentDTOs
 }
 
+func mapCommentThreadsToCommentThreadDTOs(threads []domain.CommentThread) []CommentThreadDTO {
+	threadDTOs := make([]CommentThreadDTO, 0, len(threads))
+	for _, thread := range threads {
+		threadDTOs = append(threadDTOs, CommentThreadDTO{
+			CommentDTO: mapCommentToCommentDTO(thread.Comment),
+			Replies:    mapCommentThreadsToCommentThreadDTOs(thread.Replies),
+		})
+	}
+	return threadDTOs
+}
+
 func mapCreateCommentDTOToComment(createCommentDTO CreateCommentDTO, ticketID string) (domain.Comment, error) {
 	attachmentsDTO, err := mapCreateAttachmentDTOsToAttachments(createCommentDTO.Attachments)
 	if err != nil {
 		return domain.Comment{}, err
 	}
 
+	visibility, err := domain.ParseVisibility(createCommentDTO.Visibility)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
 	comment, err := domain.NewComment(
 		ticketID,
 		createCommentDTO.Content,
-		createCommentDTO.CreatedBy,
+		"",
 		createCommentDTO.CommentType,
 		attachmentsDTO,
 	)
@@ -66,5 +118,42 @@ func mapCreateCommentDTOToComment(createCommentDTO CreateCommentDTO, ticketID st
 		return domain.Comment{}, err
 	}
 
+	if visibility != "" {
+		comment.Visibility = visibility
+	}
+	comment.ParentCommentID = createCommentDTO.ParentCommentID
+
 	return comment, nil
 }
+
+func mapUpdateCommentDTOToCommentUpdate(updateCommentDTO UpdateCommentDTO) (domain.CommentUpdate, error) {
+	commentUpdate := domain.CommentUpdate{Content: updateCommentDTO.Content}
+	if updateCommentDTO.Visibility != nil {
+		visibility, err := domain.ParseVisibility(*updateCommentDTO.Visibility)
+		if err != nil {
+			return domain.CommentUpdate{}, err
+		}
+
+		if visibility == "" {
+			return domain.CommentUpdate{}, domain.NewValidationError("visibility cannot be empty", nil)
+		}
+		commentUpdate.Visibility = &visibility
+	}
+
+	return commentUpdate, nil
+}
+
+func mapCommentRevisionsToCommentRevisionDTOs(revisions []domain.CommentRevision) []CommentRevisionDTO {
+	revisionDTOs := make([]CommentRevisionDTO, 0, len(revisions))
+	for _, revision := range revisions {
+		revisionDTOs = append(revisionDTOs, CommentRevisionDTO{
+			Revision:    revision.Revision,
+			Content:     revision.Content,
+			CommentType: string(revision.CommentType),
+			Visibility:  string(revision.Visibility),
+			EditedBy:    revision.EditedBy,
+			EditedAt:    revision.EditedAt,
+		})
+	}
+	return revisionDTOs
+}
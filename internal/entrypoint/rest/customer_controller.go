@@ -5,7 +5,6 @@ import (
 	"github.com/icrxz/crm-api-core/internal/application"
 	"github.com/icrxz/crm-api-core/internal/domain"
 	"net/http"
-	"strconv"
 )
 
 type CustomerController struct {
@@ -60,7 +59,11 @@ func (c *CustomerController) GetCustomer(ctx *gin.Context) {
 }
 
 func (c *CustomerController) SearchCustomers(ctx *gin.Context) {
-	filters := c.parseQueryToFilters(ctx)
+	filters, err := c.parseQueryToFilters(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
 
 	customers, err := c.customerService.Search(ctx.Request.Context(), filters)
 	if err != nil {
@@ -114,18 +117,17 @@ func (c *CustomerController) DeleteCustomer(ctx *gin.Context) {
 	ctx.JSON(204, nil)
 }
 
-func (c *CustomerController) parseQueryToFilters(ctx *gin.Context) domain.CustomerFilters {
-	filters := domain.CustomerFilters{
-		PagingFilter: domain.PagingFilter{
-			Limit:  10,
-			Offset: 0,
-		},
-	}
+func (c *CustomerController) parseQueryToFilters(ctx *gin.Context) (domain.CustomerFilters, error) {
+	filters := domain.CustomerFilters{}
 
 	if documents := ctx.QueryArray("document"); len(documents) > 0 {
 		filters.Document = documents
 	}
 
+	if tenantIDs := ctx.QueryArray("tenant_id"); len(tenantIDs) > 0 {
+		filters.TenantID = tenantIDs
+	}
+
 	if customerIDs := ctx.QueryArray("customer_id"); len(customerIDs) > 0 {
 		filters.CustomerID = customerIDs
 	}
@@ -138,19 +140,11 @@ func (c *CustomerController) parseQueryToFilters(ctx *gin.Context) domain.Custom
 		filters.CustomerType = customerTypes
 	}
 
-	if limit := ctx.Query("limit"); limit != "" {
-		parsedLimit, err := strconv.Atoi(limit)
-		if err == nil {
-			filters.Limit = parsedLimit
-		}
-	}
-
-	if offset := ctx.Query("offset"); offset != "" {
-		parsedOffset, err := strconv.Atoi(offset)
-		if err == nil {
-			filters.Offset = parsedOffset
-		}
+	pagingFilter, err := parseQueryToPagingFilter(ctx)
+	if err != nil {
+		return domain.CustomerFilters{}, err
 	}
+	filters.PagingFilter = pagingFilter
 
-	return filters
+	return filters, nil
 }
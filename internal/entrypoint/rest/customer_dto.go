@@ -7,6 +7,7 @@ import (
 )
 
 type CreateCustomerDTO struct {
+	TenantID        string     `json:"tenant_id"`
 	FirstName       string     `json:"first_name"`
 	LastName        string     `json:"last_name"`
 	CompanyName     string     `json:"company_name"`
@@ -23,6 +24,7 @@ type CreateCustomerDTO struct {
 
 type CustomerDTO struct {
 	CustomerID      string     `json:"customer_id"`
+	TenantID        string     `json:"tenant_id"`
 	FirstName       string     `json:"first_name"`
 	LastName        string     `json:"last_name"`
 	CompanyName     string     `json:"company_name"`
@@ -60,6 +62,7 @@ type UpdateCustomerDTO struct {
 func mapCustomerToCustomerDTO(customer domain.Customer) CustomerDTO {
 	return CustomerDTO{
 		CustomerID:      customer.CustomerID,
+		TenantID:        customer.TenantID,
 		FirstName:       customer.FirstName,
 		LastName:        customer.LastName,
 		CompanyName:     customer.CompanyName,
@@ -81,6 +84,7 @@ func mapCustomerToCustomerDTO(customer domain.Customer) CustomerDTO {
 
 func mapCreateCustomerDTOToCustomer(customerDTO CreateCustomerDTO) (domain.Customer, error) {
 	return domain.NewCustomer(
+		customerDTO.TenantID,
 		customerDTO.FirstName,
 		customerDTO.LastName,
 		customerDTO.CompanyName,
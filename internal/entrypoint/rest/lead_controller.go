@@ -1,12 +1,10 @@
 package rest
 
 import (
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/icrxz/crm-api-core/internal/application"
 	"github.com/icrxz/crm-api-core/internal/domain"
 	"net/http"
-	"strconv"
 	"strings"
 )
 
@@ -141,7 +139,7 @@ func (c *LeadController) CreateBatch(ctx *gin.Context) {
 		return
 	}
 
-	result, err := c.leadService.CreateBatch(ctx, file, author)
+	result, err := c.leadService.CreateBatch(ctx.Request.Context(), file, author)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -151,12 +149,7 @@ func (c *LeadController) CreateBatch(ctx *gin.Context) {
 }
 
 func (c *LeadController) parseQueryToFilters(ctx *gin.Context) (domain.LeadFilters, error) {
-	filters := domain.LeadFilters{
-		PagingFilter: domain.PagingFilter{
-			Limit:  10,
-			Offset: 0,
-		},
-	}
+	filters := domain.LeadFilters{}
 
 	if documents := ctx.QueryArray("document"); len(documents) > 0 {
 		filters.Document = documents
@@ -179,28 +172,11 @@ func (c *LeadController) parseQueryToFilters(ctx *gin.Context) (domain.LeadFilte
 		filters.Active = &isActive
 	}
 
-	validationErr := make([]error, 0)
-	if limitParam := ctx.Query("limit"); limitParam != "" {
-		parsedLimit, err := strconv.Atoi(limitParam)
-		if err != nil {
-			validationErr = append(validationErr, domain.NewValidationError("limit must be a number", nil))
-		} else {
-			filters.PagingFilter.Limit = parsedLimit
-		}
-	}
-
-	if offsetParam := ctx.Query("offset"); offsetParam != "" {
-		parsedOffset, err := strconv.Atoi(offsetParam)
-		if err != nil {
-			validationErr = append(validationErr, domain.NewValidationError("offset must be a number", nil))
-		} else {
-			filters.PagingFilter.Offset = parsedOffset
-		}
-	}
-
-	if len(validationErr) > 0 {
-		return domain.LeadFilters{}, errors.Join(validationErr...)
+	pagingFilter, err := parseQueryToPagingFilter(ctx)
+	if err != nil {
+		return domain.LeadFilters{}, err
 	}
+	filters.PagingFilter = pagingFilter
 
 	return filters, nil
 }
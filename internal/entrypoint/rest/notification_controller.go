@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type NotificationController struct {
+	notificationService application.NotificationService
+}
+
+func NewNotificationController(notificationService application.NotificationService) NotificationController {
+	return NotificationController{
+		notificationService: notificationService,
+	}
+}
+
+func (c *NotificationController) GetMyNotifications(ctx *gin.Context) {
+	filters, err := c.parseQueryToFilters(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	notifications, err := c.notificationService.GetByUserID(ctx.Request.Context(), filters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapSearchResultToSearchResultDTO(notifications, mapNotificationsToNotificationDTOs))
+}
+
+func (c *NotificationController) MarkMyNotificationsRead(ctx *gin.Context) {
+	var markReadDTO MarkNotificationsReadDTO
+	if err := ctx.ShouldBindJSON(&markReadDTO); err != nil {
+		ctx.Error(domain.NewValidationError("invalid request body", nil))
+		return
+	}
+
+	if len(markReadDTO.NotificationIDs) == 0 && !markReadDTO.All {
+		ctx.Error(domain.NewValidationError("notification_ids or all is required", nil))
+		return
+	}
+
+	notificationIDs := markReadDTO.NotificationIDs
+	if markReadDTO.All {
+		notificationIDs = nil
+	}
+
+	updated, err := c.notificationService.MarkRead(ctx.Request.Context(), ctx.GetString("user_id"), notificationIDs)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+func (c *NotificationController) parseQueryToFilters(ctx *gin.Context) (domain.NotificationFilters, error) {
+	filters := domain.NotificationFilters{
+		UserID: ctx.GetString("user_id"),
+	}
+
+	if types := ctx.QueryArray("type"); len(types) > 0 {
+		filters.Type = types
+	}
+
+	if ticketIDs := ctx.QueryArray("ticket_id"); len(ticketIDs) > 0 {
+		filters.TicketID = ticketIDs
+	}
+
+	if read := ctx.Query("read"); read != "" {
+		readBool, err := strconv.ParseBool(read)
+		if err != nil {
+			return domain.NotificationFilters{}, domain.NewValidationError("read must be a boolean", nil)
+		}
+		filters.Read = &readBool
+	}
+
+	pagingFilter, err := parseQueryToPagingFilter(ctx)
+	if err != nil {
+		return domain.NotificationFilters{}, err
+	}
+	filters.PagingFilter = pagingFilter
+
+	return filters, nil
+}
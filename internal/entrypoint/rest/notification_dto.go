@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type NotificationDTO struct {
+	NotificationID string     `json:"notification_id"`
+	Type           string     `json:"type"`
+	TicketID       string     `json:"ticket_id,omitempty"`
+	CommentID      string     `json:"comment_id,omitempty"`
+	Message        string     `json:"message"`
+	CreatedBy      string     `json:"created_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Read           bool       `json:"read"`
+	ReadAt         *time.Time `json:"read_at,omitempty"`
+}
+
+type MarkNotificationsReadDTO struct {
+	NotificationIDs []string `json:"notification_ids"`
+	All             bool     `json:"all"`
+}
+
+func mapNotificationToNotificationDTO(notification domain.Notification) NotificationDTO {
+	return NotificationDTO{
+		NotificationID: notification.NotificationID,
+		Type:           string(notification.Type),
+		TicketID:       notification.TicketID,
+		CommentID:      notification.CommentID,
+		Message:        notification.Message,
+		CreatedBy:      notification.CreatedBy,
+		CreatedAt:      notification.CreatedAt,
+		Read:           notification.IsRead(),
+		ReadAt:         notification.ReadAt,
+	}
+}
+
+func mapNotificationsToNotificationDTOs(notifications []domain.Notification) []NotificationDTO {
+	notificationDTOs := make([]NotificationDTO, 0, len(notifications))
+	for _, notification := range notifications {
+		notificationDTOs = append(notificationDTOs, mapNotificationToNotificationDTO(notification))
+	}
+	return notificationDTOs
+}
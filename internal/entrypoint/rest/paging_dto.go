@@ -1,10 +1,23 @@
 package rest
 
-import "github.com/icrxz/crm-api-core/internal/domain"
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const (
+	defaultPagingLimit = 10
+	maxPagingLimit     = 100
+)
 
 type PagingFilterDTO struct {
-	Limit  int `json:"limit"`
-	Offset int `json:"offset"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+	Sort   string `json:"sort"`
 }
 
 type PagingDTO struct {
@@ -19,10 +32,66 @@ type SearchResultDTO[T any] struct {
 }
 
 func mapPagingFilterDTOToPagingFilter(pagingFilterDTO PagingFilterDTO) domain.PagingFilter {
+	sortBy, sortDirection := parseSort(pagingFilterDTO.Sort)
+
 	return domain.PagingFilter{
-		Limit:  pagingFilterDTO.Limit,
-		Offset: pagingFilterDTO.Offset,
+		Limit:         pagingFilterDTO.Limit,
+		Offset:        pagingFilterDTO.Offset,
+		SortBy:        sortBy,
+		SortDirection: sortDirection,
+	}
+}
+
+// parseQueryToPagingFilter reads the limit, offset and sort query params shared by every search endpoint.
+// sort accepts "field", "field:asc" or "field:desc".
+func parseQueryToPagingFilter(ctx *gin.Context) (domain.PagingFilter, error) {
+	pagingFilterDTO := PagingFilterDTO{
+		Limit:  defaultPagingLimit,
+		Offset: 0,
+		Sort:   ctx.Query("sort"),
+	}
+
+	validationErr := make([]error, 0)
+	if limitParam := ctx.Query("limit"); limitParam != "" {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err != nil || parsedLimit <= 0 || parsedLimit > maxPagingLimit {
+			validationErr = append(validationErr, domain.NewValidationError("limit must be a number between 1 and 100", map[string]any{"limit": limitParam}))
+		} else {
+			pagingFilterDTO.Limit = parsedLimit
+		}
+	}
+
+	if offsetParam := ctx.Query("offset"); offsetParam != "" {
+		parsedOffset, err := strconv.Atoi(offsetParam)
+		if err != nil || parsedOffset < 0 {
+			validationErr = append(validationErr, domain.NewValidationError("offset must be a non-negative number", map[string]any{"offset": offsetParam}))
+		} else {
+			pagingFilterDTO.Offset = parsedOffset
+		}
 	}
+
+	if _, direction, found := strings.Cut(pagingFilterDTO.Sort, ":"); found && direction != string(domain.ASC) && direction != string(domain.DESC) {
+		validationErr = append(validationErr, domain.NewValidationError("sort direction must be asc or desc", map[string]any{"sort": pagingFilterDTO.Sort}))
+	}
+
+	if len(validationErr) > 0 {
+		return domain.PagingFilter{}, errors.Join(validationErr...)
+	}
+
+	return mapPagingFilterDTOToPagingFilter(pagingFilterDTO), nil
+}
+
+func parseSort(sort string) (string, domain.SortDirection) {
+	if sort == "" {
+		return "", ""
+	}
+
+	field, direction, found := strings.Cut(sort, ":")
+	if !found {
+		return field, domain.ASC
+	}
+
+	return field, domain.SortDirection(direction)
 }
 
 func mapPagingToPagingDTO(paging domain.Paging) PagingDTO {
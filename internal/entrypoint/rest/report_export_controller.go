@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ReportExportController struct {
+	reportExportService application.ReportExportService
+}
+
+func NewReportExportController(reportExportService application.ReportExportService) ReportExportController {
+	return ReportExportController{
+		reportExportService: reportExportService,
+	}
+}
+
+func (c *ReportExportController) ExportReports(ctx *gin.Context) {
+	var exportDTO ExportReportsDTO
+	if err := ctx.BindJSON(&exportDTO); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	format, err := domain.ParseReportFormat(exportDTO.Format)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	tickets, err := c.reportExportService.SearchTickets(ctx.Request.Context(), mapExportReportsDTOToTicketFilters(exportDTO))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	// the ZIP is streamed as reports are generated, so errors from here on can't be turned into an
+	// error response anymore; the client gets a truncated file instead
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("reports-%s.zip", time.Now().Format("2006_01_02_15_04_05"))))
+	ctx.Header("Content-Type", "application/zip")
+	ctx.Status(http.StatusOK)
+
+	if err = c.reportExportService.WriteExport(ctx.Request.Context(), tickets, format, ctx.Writer); err != nil {
+		log.Printf("failed to export reports: %v", err)
+		ctx.Abort()
+	}
+}
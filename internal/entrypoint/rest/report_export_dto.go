@@ -0,0 +1,32 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ExportReportsDTO struct {
+	TenantID    []string   `json:"tenant_id"`
+	OwnerID     []string   `json:"owner_id"`
+	LeadID      []string   `json:"lead_id"`
+	CustomerID  []string   `json:"customer_id"`
+	Status      []string   `json:"status"`
+	Region      []string   `json:"region"`
+	CreatedFrom *time.Time `json:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"`
+	Format      string     `json:"format"`
+}
+
+func mapExportReportsDTOToTicketFilters(exportDTO ExportReportsDTO) domain.TicketFilters {
+	return domain.TicketFilters{
+		TenantID:    exportDTO.TenantID,
+		OwnerID:     exportDTO.OwnerID,
+		LeadID:      exportDTO.LeadID,
+		CustomerID:  exportDTO.CustomerID,
+		Status:      exportDTO.Status,
+		Region:      exportDTO.Region,
+		CreatedFrom: exportDTO.CreatedFrom,
+		CreatedTo:   exportDTO.CreatedTo,
+	}
+}
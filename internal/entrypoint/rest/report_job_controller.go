@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ReportJobController struct {
+	reportJobService application.ReportJobService
+}
+
+func NewReportJobController(reportJobService application.ReportJobService) ReportJobController {
+	return ReportJobController{
+		reportJobService: reportJobService,
+	}
+}
+
+func (c *ReportJobController) CreateReportJob(ctx *gin.Context) {
+	ticketID := ctx.Param("ticketID")
+	if ticketID == "" {
+		ctx.Error(domain.NewValidationError("ticket_id is required", nil))
+		return
+	}
+
+	format, err := domain.ParseReportFormat(ctx.Query("format"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	job, err := c.reportJobService.Enqueue(ctx.Request.Context(), ticketID, format, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Location", fmt.Sprintf(reportJobPath, job.JobID))
+	ctx.JSON(http.StatusAccepted, mapReportJobToReportJobDTO(*job))
+}
+
+func (c *ReportJobController) GetReportJob(ctx *gin.Context) {
+	jobID := ctx.Param("jobID")
+	if jobID == "" {
+		ctx.Error(domain.NewValidationError("job_id is required", nil))
+		return
+	}
+
+	job, err := c.reportJobService.GetByID(ctx.Request.Context(), jobID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapReportJobToReportJobDTO(*job))
+}
+
+func (c *ReportJobController) SearchTicketReports(ctx *gin.Context) {
+	ticketID := ctx.Param("ticketID")
+	if ticketID == "" {
+		ctx.Error(domain.NewValidationError("ticket_id is required", nil))
+		return
+	}
+
+	pagingFilter, err := parseQueryToPagingFilter(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	jobs, err := c.reportJobService.SearchByTicketID(ctx.Request.Context(), ticketID, pagingFilter)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapSearchResultToSearchResultDTO(jobs, mapReportJobsToReportJobDTOs))
+}
+
+func (c *ReportJobController) DownloadReport(ctx *gin.Context) {
+	jobID := ctx.Param("jobID")
+	if jobID == "" {
+		ctx.Error(domain.NewValidationError("job_id is required", nil))
+		return
+	}
+
+	job, report, err := c.reportJobService.Download(ctx.Request.Context(), jobID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", job.FileName))
+	ctx.Data(http.StatusOK, job.Format.ContentType(), report)
+}
@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const (
+	reportJobPath      = "/crm/core/api/v1/reports/%s"
+	reportDownloadPath = reportJobPath + "/file"
+)
+
+type ReportJobDTO struct {
+	JobID       string     `json:"job_id"`
+	TenantID    string     `json:"tenant_id"`
+	TicketID    string     `json:"ticket_id"`
+	Format      string     `json:"format"`
+	Status      string     `json:"status"`
+	Version     int        `json:"version,omitempty"`
+	FileName    string     `json:"file_name,omitempty"`
+	Size        int        `json:"size,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func mapReportJobToReportJobDTO(job domain.ReportJob) ReportJobDTO {
+	var downloadURL string
+	if job.Status == domain.REPORT_JOB_COMPLETED {
+		downloadURL = fmt.Sprintf(reportDownloadPath, job.JobID)
+	}
+
+	return ReportJobDTO{
+		JobID:       job.JobID,
+		TenantID:    job.TenantID,
+		TicketID:    job.TicketID,
+		Format:      string(job.Format),
+		Status:      string(job.Status),
+		Version:     job.Version,
+		FileName:    job.FileName,
+		Size:        job.Size,
+		DownloadURL: downloadURL,
+		Error:       job.Error,
+		CreatedBy:   job.CreatedBy,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		CompletedAt: job.CompletedAt,
+	}
+}
+
+func mapReportJobsToReportJobDTOs(jobs []domain.ReportJob) []ReportJobDTO {
+	jobDTOs := make([]ReportJobDTO, 0, len(jobs))
+	for _, job := range jobs {
+		jobDTOs = append(jobDTOs, mapReportJobToReportJobDTO(job))
+	}
+
+	return jobDTOs
+}
@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ReportTemplateController struct {
+	reportTemplateService application.ReportTemplateService
+	reportService         application.ReportService
+}
+
+func NewReportTemplateController(reportTemplateService application.ReportTemplateService, reportService application.ReportService) ReportTemplateController {
+	return ReportTemplateController{
+		reportTemplateService: reportTemplateService,
+		reportService:         reportService,
+	}
+}
+
+func (c *ReportTemplateController) UploadTemplate(ctx *gin.Context) {
+	tenantID := ctx.Param("tenantID")
+	if tenantID == "" {
+		ctx.Error(domain.NewValidationError("tenant_id is required", nil))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.Error(domain.NewValidationError("file is required", nil))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	report, validation, err := c.reportTemplateService.Upload(
+		ctx.Request.Context(),
+		tenantID,
+		ctx.PostForm("report_name"),
+		fileHeader.Filename,
+		content,
+		ctx.DefaultPostForm("active", "true") == "true",
+		ctx.GetString("user_id"),
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	reportDTO := mapReportToReportTemplateDTO(*report)
+	validationDTO := mapReportTemplateValidationToDTO(validation)
+	reportDTO.Validation = &validationDTO
+
+	ctx.JSON(http.StatusCreated, reportDTO)
+}
+
+func (c *ReportTemplateController) SearchTemplates(ctx *gin.Context) {
+	tenantID := ctx.Param("tenantID")
+	if tenantID == "" {
+		ctx.Error(domain.NewValidationError("tenant_id is required", nil))
+		return
+	}
+
+	filters, err := c.parseQueryToReportFilters(ctx, tenantID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	reports, err := c.reportTemplateService.Search(ctx.Request.Context(), filters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapSearchResultToSearchResultDTO(reports, mapReportsToReportTemplateDTOs))
+}
+
+func (c *ReportTemplateController) GetTemplate(ctx *gin.Context) {
+	reportID := ctx.Param("reportID")
+	if reportID == "" {
+		ctx.Error(domain.NewValidationError("report_id is required", nil))
+		return
+	}
+
+	report, err := c.reportTemplateService.GetByID(ctx.Request.Context(), reportID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapReportToReportTemplateDTO(*report))
+}
+
+func (c *ReportTemplateController) DownloadTemplate(ctx *gin.Context) {
+	reportID := ctx.Param("reportID")
+	if reportID == "" {
+		ctx.Error(domain.NewValidationError("report_id is required", nil))
+		return
+	}
+
+	report, file, err := c.reportTemplateService.Download(ctx.Request.Context(), reportID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", report.FileName))
+	ctx.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.wordprocessingml.document", file)
+}
+
+func (c *ReportTemplateController) UpdateTemplateActive(ctx *gin.Context) {
+	reportID := ctx.Param("reportID")
+	if reportID == "" {
+		ctx.Error(domain.NewValidationError("report_id is required", nil))
+		return
+	}
+
+	var activeDTO UpdateReportTemplateActiveDTO
+	if err := ctx.BindJSON(&activeDTO); err != nil || activeDTO.Active == nil {
+		ctx.Error(domain.NewValidationError("active is required", nil))
+		return
+	}
+
+	err := c.reportTemplateService.SetActive(ctx.Request.Context(), reportID, *activeDTO.Active, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *ReportTemplateController) ListPlaceholders(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, mapReportPlaceholdersToDTOs(application.ReportPlaceholders()))
+}
+
+func (c *ReportTemplateController) ValidateTemplate(ctx *gin.Context) {
+	reportID := ctx.Param("reportID")
+	if reportID == "" {
+		ctx.Error(domain.NewValidationError("report_id is required", nil))
+		return
+	}
+
+	validation, err := c.reportTemplateService.Validate(ctx.Request.Context(), reportID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapReportTemplateValidationToDTO(validation))
+}
+
+func (c *ReportTemplateController) PreviewTemplate(ctx *gin.Context) {
+	reportID := ctx.Param("reportID")
+	if reportID == "" {
+		ctx.Error(domain.NewValidationError("report_id is required", nil))
+		return
+	}
+
+	preview, filename, err := c.reportService.PreviewReport(ctx.Request.Context(), reportID, ctx.Query("ticket_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, domain.DOCX_FORMAT.ContentType(), preview)
+}
+
+func (c *ReportTemplateController) parseQueryToReportFilters(ctx *gin.Context, tenantID string) (domain.ReportFilters, error) {
+	filters := domain.ReportFilters{
+		TenantID: []string{tenantID},
+	}
+
+	if reportNames := ctx.QueryArray("report_name"); len(reportNames) > 0 {
+		filters.ReportName = reportNames
+	}
+
+	if active := ctx.Query("active"); active != "" {
+		activeBool := active == "true"
+		filters.Active = &activeBool
+	}
+
+	pagingFilter, err := parseQueryToPagingFilter(ctx)
+	if err != nil {
+		return domain.ReportFilters{}, err
+	}
+	filters.PagingFilter = pagingFilter
+
+	return filters, nil
+}
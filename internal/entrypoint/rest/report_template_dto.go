@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ReportTemplateDTO struct {
+	ReportID   string    `json:"report_id"`
+	TenantID   string    `json:"tenant_id"`
+	ReportName string    `json:"report_name"`
+	FileName   string    `json:"file_name"`
+	Size       int       `json:"size"`
+	Version    int       `json:"version"`
+	Active     bool      `json:"active"`
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedBy  string    `json:"updated_by"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	Validation *ReportTemplateValidationDTO `json:"validation,omitempty"`
+}
+
+type ReportTemplateValidationDTO struct {
+	Placeholders []string `json:"placeholders"`
+	Unknown      []string `json:"unknown"`
+	Missing      []string `json:"missing"`
+	Split        []string `json:"split"`
+	Syntax       []string `json:"syntax"`
+}
+
+type ReportPlaceholderDTO struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Source      string `json:"source"`
+	Image       bool   `json:"image"`
+}
+
+type UpdateReportTemplateActiveDTO struct {
+	Active *bool `json:"active"`
+}
+
+func mapReportToReportTemplateDTO(report domain.Report) ReportTemplateDTO {
+	return ReportTemplateDTO{
+		ReportID:   report.ReportID,
+		TenantID:   report.TenantID,
+		ReportName: report.ReportName,
+		FileName:   report.FileName,
+		Size:       report.Size,
+		Version:    report.Version,
+		Active:     report.Active,
+		CreatedBy:  report.CreatedBy,
+		CreatedAt:  report.CreatedAt,
+		UpdatedBy:  report.UpdatedBy,
+		UpdatedAt:  report.UpdatedAt,
+	}
+}
+
+func mapReportsToReportTemplateDTOs(reports []domain.Report) []ReportTemplateDTO {
+	reportDTOs := make([]ReportTemplateDTO, 0, len(reports))
+	for _, report := range reports {
+		reportDTOs = append(reportDTOs, mapReportToReportTemplateDTO(report))
+	}
+
+	return reportDTOs
+}
+
+func mapReportTemplateValidationToDTO(validation application.ReportTemplateValidation) ReportTemplateValidationDTO {
+	return ReportTemplateValidationDTO{
+		Placeholders: validation.Placeholders,
+		Unknown:      validation.Unknown,
+		Missing:      validation.Missing,
+		Split:        validation.Split,
+		Syntax:       validation.Syntax,
+	}
+}
+
+func mapReportPlaceholdersToDTOs(placeholders []application.ReportPlaceholder) []ReportPlaceholderDTO {
+	placeholderDTOs := make([]ReportPlaceholderDTO, 0, len(placeholders))
+	for _, placeholder := range placeholders {
+		placeholderDTOs = append(placeholderDTOs, ReportPlaceholderDTO{
+			Name:        placeholder.Name,
+			Description: placeholder.Description,
+			Source:      placeholder.Source,
+			Image:       placeholder.Image,
+		})
+	}
+
+	return placeholderDTOs
+}
@@ -84,7 +84,11 @@ func (c *TenantController) GetTenant(ctx *gin.Context) {
 }
 
 func (c *TenantController) SearchTenants(ctx *gin.Context) {
-	filters := c.parseQueryToFilters(ctx)
+	filters, err := c.parseQueryToFilters(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
 
 	tenants, err := c.tenantService.Search(ctx.Request.Context(), filters)
 	if err != nil {
@@ -113,13 +117,8 @@ func (c *TenantController) DeleteTenant(ctx *gin.Context) {
 	ctx.JSON(http.StatusNoContent, nil)
 }
 
-func (c *TenantController) parseQueryToFilters(ctx *gin.Context) domain.TenantFilters {
-	filters := domain.TenantFilters{
-		PagingFilter: domain.PagingFilter{
-			Limit:  10,
-			Offset: 0,
-		},
-	}
+func (c *TenantController) parseQueryToFilters(ctx *gin.Context) (domain.TenantFilters, error) {
+	filters := domain.TenantFilters{}
 
 	if documents := ctx.QueryArray("document"); len(documents) > 0 {
 		filters.Document = documents
@@ -136,24 +135,16 @@ func (c *TenantController) parseQueryToFilters(ctx *gin.Context) domain.TenantFi
 	if active := ctx.Query("active"); active != "" {
 		activeBool, err := strconv.ParseBool(active)
 		if err != nil {
-			return filters
+			return domain.TenantFilters{}, domain.NewValidationError("active must be a boolean", nil)
 		}
 		filters.Active = &activeBool
 	}
 
-	if limitParam := ctx.Query("limit"); limitParam != "" {
-		parsedLimit, err := strconv.Atoi(limitParam)
-		if err == nil {
-			filters.PagingFilter.Limit = parsedLimit
-		}
-	}
-
-	if offsetParam := ctx.Query("offset"); offsetParam != "" {
-		parsedOffset, err := strconv.Atoi(offsetParam)
-		if err == nil {
-			filters.PagingFilter.Offset = parsedOffset
-		}
+	pagingFilter, err := parseQueryToPagingFilter(ctx)
+	if err != nil {
+		return domain.TenantFilters{}, err
 	}
+	filters.PagingFilter = pagingFilter
 
-	return filters
+	return filters, nil
 }
@@ -16,41 +16,44 @@ type CreateTenantDTO struct {
 }
 
 type TenantDTO struct {
-	TenantID        string     `json:"tenant_id"`
-	CompanyName     string     `json:"company_name"`
-	LegalName       string     `json:"legal_name"`
-	Document        string     `json:"document"`
-	DocumentType    string     `json:"document_type"`
-	BusinessContact ContactDTO `json:"business_contact"`
-	CreatedBy       string     `json:"created_by"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedBy       string     `json:"updated_by"`
-	UpdatedAt       time.Time  `json:"updated_at"`
-	Active          bool       `json:"active"`
+	TenantID         string              `json:"tenant_id"`
+	CompanyName      string              `json:"company_name"`
+	LegalName        string              `json:"legal_name"`
+	Document         string              `json:"document"`
+	DocumentType     string              `json:"document_type"`
+	BusinessContact  ContactDTO          `json:"business_contact"`
+	AttachmentPolicy AttachmentPolicyDTO `json:"attachment_policy"`
+	CreatedBy        string              `json:"created_by"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedBy        string              `json:"updated_by"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+	Active           bool                `json:"active"`
 }
 
 type UpdateTenantDTO struct {
-	CompanyName     *string     `json:"company_name"`
-	LegalName       *string     `json:"legal_name"`
-	Document        *string     `json:"document"`
-	DocumentType    *string     `json:"document_type"`
-	BusinessContact *ContactDTO `json:"business_contact"`
-	UpdatedBy       string      `json:"updated_by"`
+	CompanyName      *string              `json:"company_name"`
+	LegalName        *string              `json:"legal_name"`
+	Document         *string              `json:"document"`
+	DocumentType     *string              `json:"document_type"`
+	BusinessContact  *ContactDTO          `json:"business_contact"`
+	AttachmentPolicy *AttachmentPolicyDTO `json:"attachment_policy"`
+	UpdatedBy        string               `json:"updated_by"`
 }
 
 func mapTenantToTenantDTO(tenant domain.Tenant) TenantDTO {
 	return TenantDTO{
-		TenantID:        tenant.TenantID,
-		CompanyName:     tenant.CompanyName,
-		LegalName:       tenant.LegalName,
-		Document:        tenant.Document,
-		DocumentType:    string(tenant.DocumentType),
-		BusinessContact: mapContactToContactDTO(tenant.BusinessContact),
-		CreatedBy:       tenant.CreatedBy,
-		CreatedAt:       tenant.CreatedAt,
-		UpdatedBy:       tenant.UpdatedBy,
-		UpdatedAt:       tenant.UpdatedAt,
-		Active:          tenant.Active,
+		TenantID:         tenant.TenantID,
+		CompanyName:      tenant.CompanyName,
+		LegalName:        tenant.LegalName,
+		Document:         tenant.Document,
+		DocumentType:     string(tenant.DocumentType),
+		BusinessContact:  mapContactToContactDTO(tenant.BusinessContact),
+		AttachmentPolicy: mapAttachmentPolicyToAttachmentPolicyDTO(tenant.AttachmentPolicy),
+		CreatedBy:        tenant.CreatedBy,
+		CreatedAt:        tenant.CreatedAt,
+		UpdatedBy:        tenant.UpdatedBy,
+		UpdatedAt:        tenant.UpdatedAt,
+		Active:           tenant.Active,
 	}
 }
 
@@ -93,12 +96,19 @@ func mapUpdateTenantDTOToUpdateTenant(updateTenantDTO UpdateTenantDTO) domain.Up
 		parsedBusinessContact = &businessContact
 	}
 
+	var parsedAttachmentPolicy *domain.AttachmentPolicy
+	if updateTenantDTO.AttachmentPolicy != nil {
+		attachmentPolicy := mapAttachmentPolicyDTOToAttachmentPolicy(*updateTenantDTO.AttachmentPolicy)
+		parsedAttachmentPolicy = &attachmentPolicy
+	}
+
 	return domain.UpdateTenant{
-		CompanyName:     updateTenantDTO.CompanyName,
-		LegalName:       updateTenantDTO.LegalName,
-		Document:        updateTenantDTO.Document,
-		DocumentType:    parsedDocumentType,
-		BusinessContact: parsedBusinessContact,
-		UpdatedBy:       updateTenantDTO.UpdatedBy,
+		CompanyName:      updateTenantDTO.CompanyName,
+		LegalName:        updateTenantDTO.LegalName,
+		Document:         updateTenantDTO.Document,
+		DocumentType:     parsedDocumentType,
+		BusinessContact:  parsedBusinessContact,
+		AttachmentPolicy: parsedAttachmentPolicy,
+		UpdatedBy:        updateTenantDTO.UpdatedBy,
 	}
 }
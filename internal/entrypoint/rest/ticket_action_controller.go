@@ -104,12 +104,18 @@ func (c *TicketActionController) DownloadReport(ctx *gin.Context) {
 		return
 	}
 
-	report, filename, err := c.ticketActionService.GenerateReport(ctx.Request.Context(), ticketID)
+	format, err := domain.ParseReportFormat(ctx.Query("format"))
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	contentType := fmt.Sprintf("application/vnd.openxmlformats-officedocument.wordprocessingml.document;%s.docx", filename)
-	ctx.Data(http.StatusOK, contentType, report)
+	report, filename, err := c.ticketActionService.GenerateReport(ctx.Request.Context(), ticketID, format)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Data(http.StatusOK, format.ContentType(), report)
 }
@@ -2,7 +2,7 @@ package rest
 
 import (
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/icrxz/crm-api-core/internal/application"
@@ -62,7 +62,11 @@ func (c *TicketController) GetTicket(ctx *gin.Context) {
 }
 
 func (c *TicketController) SearchTickets(ctx *gin.Context) {
-	filters := c.parseQueryToFilters(ctx)
+	filters, err := c.parseQueryToFilters(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
 
 	tickets, err := c.ticketService.SearchTickets(ctx.Request.Context(), filters)
 	if err != nil {
@@ -75,13 +79,8 @@ func (c *TicketController) SearchTickets(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, searchResult)
 }
 
-func (c *TicketController) parseQueryToFilters(ctx *gin.Context) domain.TicketFilters {
-	filters := domain.TicketFilters{
-		PagingFilter: domain.PagingFilter{
-			Limit:  10,
-			Offset: 0,
-		},
-	}
+func (c *TicketController) parseQueryToFilters(ctx *gin.Context) (domain.TicketFilters, error) {
+	filters := domain.TicketFilters{}
 
 	if ownerIDs := ctx.QueryArray("owner_id"); len(ownerIDs) > 0 {
 		filters.OwnerID = ownerIDs
@@ -107,21 +106,25 @@ func (c *TicketController) parseQueryToFilters(ctx *gin.Context) domain.TicketFi
 		filters.Region = region
 	}
 
-	if limit := ctx.Query("limit"); limit != "" {
-		parsedLimit, err := strconv.Atoi(limit)
-		if err == nil {
-			filters.Limit = parsedLimit
-		}
+	createdFrom, err := parseQueryTime(ctx, "created_from")
+	if err != nil {
+		return domain.TicketFilters{}, err
 	}
+	filters.CreatedFrom = createdFrom
 
-	if offset := ctx.Query("offset"); offset != "" {
-		parsedOffset, err := strconv.Atoi(offset)
-		if err == nil {
-			filters.Offset = parsedOffset
-		}
+	createdTo, err := parseQueryTime(ctx, "created_to")
+	if err != nil {
+		return domain.TicketFilters{}, err
 	}
+	filters.CreatedTo = createdTo
 
-	return filters
+	pagingFilter, err := parseQueryToPagingFilter(ctx)
+	if err != nil {
+		return domain.TicketFilters{}, err
+	}
+	filters.PagingFilter = pagingFilter
+
+	return filters, nil
 }
 
 func (c *TicketController) UpdateTicket(ctx *gin.Context) {
@@ -147,3 +150,17 @@ func (c *TicketController) UpdateTicket(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusNoContent, nil)
 }
+
+func parseQueryTime(ctx *gin.Context, key string) (*time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsedTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, domain.NewValidationError("date must be in RFC3339 format", map[string]any{key: value})
+	}
+
+	return &parsedTime, nil
+}
@@ -0,0 +1,55 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type TicketHistoryController struct {
+	ticketHistoryService application.TicketHistoryService
+}
+
+func NewTicketHistoryController(ticketHistoryService application.TicketHistoryService) TicketHistoryController {
+	return TicketHistoryController{
+		ticketHistoryService: ticketHistoryService,
+	}
+}
+
+func (c *TicketHistoryController) GetHistory(ctx *gin.Context) {
+	ticketID := ctx.Param("ticketID")
+	if ticketID == "" {
+		ctx.Error(domain.NewValidationError("ticket_id is required", nil))
+		return
+	}
+
+	filters, err := c.parseQueryToFilters(ctx, ticketID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	history, err := c.ticketHistoryService.GetByTicketID(ctx.Request.Context(), filters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapSearchResultToSearchResultDTO(history, mapTicketEventsToTicketEventDTOs))
+}
+
+func (c *TicketHistoryController) parseQueryToFilters(ctx *gin.Context, ticketID string) (domain.TicketHistoryFilters, error) {
+	filters := domain.TicketHistoryFilters{
+		TicketID: ticketID,
+	}
+
+	pagingFilter, err := parseQueryToPagingFilter(ctx)
+	if err != nil {
+		return domain.TicketHistoryFilters{}, err
+	}
+	filters.PagingFilter = pagingFilter
+
+	return filters, nil
+}
@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type TicketEventDTO struct {
+	EventID   string                 `json:"event_id"`
+	TicketID  string                 `json:"ticket_id"`
+	Action    string                 `json:"action"`
+	Changes   []TicketFieldChangeDTO `json:"changes"`
+	CreatedBy string                 `json:"created_by"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type TicketFieldChangeDTO struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+func mapTicketEventToTicketEventDTO(event domain.TicketEvent) TicketEventDTO {
+	changes := make([]TicketFieldChangeDTO, 0, len(event.Changes))
+	for _, change := range event.Changes {
+		changes = append(changes, TicketFieldChangeDTO{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	return TicketEventDTO{
+		EventID:   event.EventID,
+		TicketID:  event.TicketID,
+		Action:    string(event.Action),
+		Changes:   changes,
+		CreatedBy: event.CreatedBy,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+func mapTicketEventsToTicketEventDTOs(events []domain.TicketEvent) []TicketEventDTO {
+	eventDTOs := make([]TicketEventDTO, 0, len(events))
+	for _, event := range events {
+		eventDTOs = append(eventDTOs, mapTicketEventToTicketEventDTO(event))
+	}
+	return eventDTOs
+}
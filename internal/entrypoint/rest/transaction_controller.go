@@ -89,7 +89,11 @@ func (c *TransactionController) UpdateTransaction(ctx *gin.Context) {
 }
 
 func (c *TransactionController) SearchTransactions(ctx *gin.Context) {
-	filters := c.parseQueryToFilters(ctx)
+	filters, err := c.parseQueryToFilters(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
 
 	transactions, err := c.transactionService.SearchTransactions(ctx.Request.Context(), filters)
 	if err != nil {
@@ -97,18 +101,22 @@ func (c *TransactionController) SearchTransactions(ctx *gin.Context) {
 		return
 	}
 
-	transactionDTOs := mapTransactionsToTransactionsDTO(transactions)
+	searchResult := mapSearchResultToSearchResultDTO(transactions, mapTransactionsToTransactionsDTO)
 
-	ctx.JSON(200, transactionDTOs)
+	ctx.JSON(200, searchResult)
 }
 
-func (c *TransactionController) parseQueryToFilters(ctx *gin.Context) domain.TransactionFilters {
+func (c *TransactionController) parseQueryToFilters(ctx *gin.Context) (domain.TransactionFilters, error) {
 	filters := domain.TransactionFilters{}
 
 	if ticketIDs := ctx.QueryArray("ticket_id"); len(ticketIDs) > 0 {
 		filters.TicketIDs = ticketIDs
 	}
 
+	if tenantIDs := ctx.QueryArray("tenant_id"); len(tenantIDs) > 0 {
+		filters.TenantIDs = tenantIDs
+	}
+
 	if status := ctx.QueryArray("status"); len(status) > 0 {
 		filters.Status = status
 	}
@@ -117,5 +125,11 @@ func (c *TransactionController) parseQueryToFilters(ctx *gin.Context) domain.Tra
 		filters.Types = types
 	}
 
-	return filters
+	pagingFilter, err := parseQueryToPagingFilter(ctx)
+	if err != nil {
+		return domain.TransactionFilters{}, err
+	}
+	filters.PagingFilter = pagingFilter
+
+	return filters, nil
 }
@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type UploadSessionController struct {
+	uploadSessionService application.UploadSessionService
+}
+
+func NewUploadSessionController(uploadSessionService application.UploadSessionService) UploadSessionController {
+	return UploadSessionController{
+		uploadSessionService: uploadSessionService,
+	}
+}
+
+func (c *UploadSessionController) CreateUploadSession(ctx *gin.Context) {
+	commentID := ctx.Param("commentID")
+	if commentID == "" {
+		ctx.Error(domain.NewValidationError("commentID is required", nil))
+		return
+	}
+
+	var sessionDTO CreateUploadSessionDTO
+	if err := ctx.ShouldBindJSON(&sessionDTO); err != nil {
+		ctx.Error(domain.NewValidationError("invalid request body", nil))
+		return
+	}
+
+	visibility, err := domain.ParseVisibility(sessionDTO.Visibility)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	session, uploadURL, err := c.uploadSessionService.Create(
+		ctx.Request.Context(),
+		commentID,
+		sessionDTO.FileName,
+		sessionDTO.ContentType,
+		sessionDTO.Size,
+		visibility,
+		ctx.GetString("user_id"),
+	)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapUploadSessionToUploadSessionDTO(*session, uploadURL))
+}
+
+func (c *UploadSessionController) GetUploadSession(ctx *gin.Context) {
+	sessionID := ctx.Param("sessionID")
+	if sessionID == "" {
+		ctx.Error(domain.NewValidationError("sessionID is required", nil))
+		return
+	}
+
+	session, err := c.uploadSessionService.GetByID(ctx.Request.Context(), sessionID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapUploadSessionToUploadSessionDTO(*session, ""))
+}
+
+func (c *UploadSessionController) CompleteUploadSession(ctx *gin.Context) {
+	sessionID := ctx.Param("sessionID")
+	if sessionID == "" {
+		ctx.Error(domain.NewValidationError("sessionID is required", nil))
+		return
+	}
+
+	attachment, err := c.uploadSessionService.Complete(ctx.Request.Context(), sessionID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapAttachmentToAttachmentDTO(*attachment))
+}
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type CreateUploadSessionDTO struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+	Visibility  string `json:"visibility"`
+}
+
+type UploadSessionDTO struct {
+	SessionID     string            `json:"session_id"`
+	TicketID      string            `json:"ticket_id"`
+	CommentID     string            `json:"comment_id"`
+	FileName      string            `json:"file_name"`
+	ContentType   string            `json:"content_type"`
+	Size          int               `json:"size"`
+	Visibility    string            `json:"visibility"`
+	Status        string            `json:"status"`
+	AttachmentID  string            `json:"attachment_id,omitempty"`
+	UploadURL     string            `json:"upload_url,omitempty"`
+	UploadMethod  string            `json:"upload_method,omitempty"`
+	UploadHeaders map[string]string `json:"upload_headers,omitempty"`
+	CreatedBy     string            `json:"created_by"`
+	CreatedAt     time.Time         `json:"created_at"`
+	ExpiresAt     time.Time         `json:"expires_at"`
+	CompletedAt   *time.Time        `json:"completed_at,omitempty"`
+}
+
+func mapUploadSessionToUploadSessionDTO(session domain.UploadSession, uploadURL string) UploadSessionDTO {
+	sessionDTO := UploadSessionDTO{
+		SessionID:    session.SessionID,
+		TicketID:     session.TicketID,
+		CommentID:    session.CommentID,
+		FileName:     session.FileName,
+		ContentType:  session.ContentType,
+		Size:         session.Size,
+		Visibility:   string(session.Visibility),
+		Status:       string(session.Status),
+		AttachmentID: session.AttachmentID,
+		CreatedBy:    session.CreatedBy,
+		CreatedAt:    session.CreatedAt,
+		ExpiresAt:    session.ExpiresAt,
+		CompletedAt:  session.CompletedAt,
+	}
+
+	if uploadURL != "" {
+		sessionDTO.UploadURL = uploadURL
+		sessionDTO.UploadMethod = "PUT"
+		sessionDTO.UploadHeaders = map[string]string{"Content-Type": session.ContentType}
+	}
+
+	return sessionDTO
+}
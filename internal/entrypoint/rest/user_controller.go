@@ -7,12 +7,14 @@ import (
 )
 
 type UserController struct {
-	userService application.UserService
+	userService    application.UserService
+	lockoutService application.LockoutService
 }
 
-func NewUserController(userService application.UserService) UserController {
+func NewUserController(userService application.UserService, lockoutService application.LockoutService) UserController {
 	return UserController{
-		userService: userService,
+		userService:    userService,
+		lockoutService: lockoutService,
 	}
 }
 
@@ -24,7 +26,8 @@ func (c *UserController) CreateUser(ctx *gin.Context) {
 		return
 	}
 
-	user, err := mapCreateUserDTOToUser(*userDTO)
+	// the creator is the authenticated caller, whatever the body says
+	user, err := mapCreateUserDTOToUser(*userDTO, domain.UserIDFromContext(ctx.Request.Context()))
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -46,10 +49,16 @@ func (c *UserController) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
-	var user *domain.User
-	ctx.BindJSON(&user)
+	var updateUserDTO *UpdateUserDTO
+	err := ctx.BindJSON(&updateUserDTO)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userUpdate := mapUpdateUserDTOToUserUpdate(*updateUserDTO)
 
-	err := c.userService.Update(ctx.Request.Context(), *user)
+	err = c.userService.Update(ctx.Request.Context(), userID, userUpdate, domain.UserIDFromContext(ctx.Request.Context()))
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -92,8 +101,44 @@ func (c *UserController) DeleteUser(ctx *gin.Context) {
 	ctx.JSON(204, nil)
 }
 
+func (c *UserController) GetLockEvents(ctx *gin.Context) {
+	userID := ctx.Param("userID")
+	if userID == "" {
+		ctx.Error(domain.NewValidationError("param userID cannot be empty", nil))
+		return
+	}
+
+	lockEvents, err := c.lockoutService.GetLockEvents(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, mapUserLockEventsToUserLockEventDTOs(lockEvents))
+}
+
+func (c *UserController) UnlockUser(ctx *gin.Context) {
+	userID := ctx.Param("userID")
+	if userID == "" {
+		ctx.Error(domain.NewValidationError("param userID cannot be empty", nil))
+		return
+	}
+
+	err := c.lockoutService.Unlock(ctx.Request.Context(), userID, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(204, nil)
+}
+
 func (c *UserController) SearchUser(ctx *gin.Context) {
-	userFilters := c.parseQueryToUserFilters(ctx)
+	userFilters, err := c.parseQueryToUserFilters(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
 
 	users, err := c.userService.Search(ctx.Request.Context(), userFilters)
 	if err != nil {
@@ -101,12 +146,12 @@ func (c *UserController) SearchUser(ctx *gin.Context) {
 		return
 	}
 
-	userDTOs := mapUsersToUserDTOs(users)
+	searchResult := mapSearchResultToSearchResultDTO(users, mapUsersToUserDTOs)
 
-	ctx.JSON(200, userDTOs)
+	ctx.JSON(200, searchResult)
 }
 
-func (c *UserController) parseQueryToUserFilters(ctx *gin.Context) domain.UserFilters {
+func (c *UserController) parseQueryToUserFilters(ctx *gin.Context) (domain.UserFilters, error) {
 	filters := domain.UserFilters{}
 
 	if emails := ctx.QueryArray("email"); len(emails) > 0 {
@@ -117,6 +162,10 @@ func (c *UserController) parseQueryToUserFilters(ctx *gin.Context) domain.UserFi
 		filters.FirstName = firstNames
 	}
 
+	if tenantIDs := ctx.QueryArray("tenant_id"); len(tenantIDs) > 0 {
+		filters.TenantID = tenantIDs
+	}
+
 	if userIDs := ctx.QueryArray("user_id"); len(userIDs) > 0 {
 		filters.UserID = userIDs
 	}
@@ -134,5 +183,11 @@ func (c *UserController) parseQueryToUserFilters(ctx *gin.Context) domain.UserFi
 		filters.Active = &activeBool
 	}
 
-	return filters
+	pagingFilter, err := parseQueryToPagingFilter(ctx)
+	if err != nil {
+		return domain.UserFilters{}, err
+	}
+	filters.PagingFilter = pagingFilter
+
+	return filters, nil
 }
@@ -14,31 +14,65 @@ type CreateUserDTO struct {
 	Role      domain.UserRole `json:"role"`
 	Region    int             `json:"region"`
 	Password  string          `json:"password"`
-	CreatedBy string          `json:"created_by"`
 }
 
 type UserDTO struct {
-	UserID    string          `json:"user_id"`
-	Username  string          `json:"username"`
-	FirstName string          `json:"first_name"`
-	LastName  string          `json:"last_name"`
-	Email     string          `json:"email"`
-	Role      domain.UserRole `json:"role"`
-	Region    int             `json:"region"`
-	CreatedAt time.Time       `json:"created_at"`
-	CreatedBy string          `json:"created_by"`
-	UpdatedAt time.Time       `json:"updated_at"`
-	UpdatedBy string          `json:"updated_by"`
-	Active    bool            `json:"active"`
+	UserID             string          `json:"user_id"`
+	TenantID           string          `json:"tenant_id"`
+	Username           string          `json:"username"`
+	FirstName          string          `json:"first_name"`
+	LastName           string          `json:"last_name"`
+	Email              string          `json:"email"`
+	Role               domain.UserRole `json:"role"`
+	Region             int             `json:"region"`
+	CreatedAt          time.Time       `json:"created_at"`
+	CreatedBy          string          `json:"created_by"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+	UpdatedBy          string          `json:"updated_by"`
+	Active             bool            `json:"active"`
+	MustChangePassword bool            `json:"must_change_password"`
+}
+
+type UpdateUserDTO struct {
+	FirstName *string          `json:"first_name"`
+	LastName  *string          `json:"last_name"`
+	Email     *string          `json:"email"`
+	Role      *domain.UserRole `json:"role"`
+	Region    *int             `json:"region"`
+	Active    *bool            `json:"active"`
 }
 
-func mapCreateUserDTOToUser(userDTO CreateUserDTO) (domain.User, error) {
+type UserLockEventDTO struct {
+	EventID     string               `json:"event_id"`
+	UserID      string               `json:"user_id"`
+	TenantID    string               `json:"tenant_id"`
+	Type        domain.LockEventType `json:"type"`
+	Reason      string               `json:"reason"`
+	IPAddress   string               `json:"ip_address,omitempty"`
+	LockedUntil *time.Time           `json:"locked_until,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	CreatedBy   string               `json:"created_by,omitempty"`
+}
+
+func mapUpdateUserDTOToUserUpdate(updateUserDTO UpdateUserDTO) domain.UserUpdate {
+	return domain.UserUpdate{
+		FirstName: updateUserDTO.FirstName,
+		LastName:  updateUserDTO.LastName,
+		Email:     updateUserDTO.Email,
+		Role:      updateUserDTO.Role,
+		Region:    updateUserDTO.Region,
+		Active:    updateUserDTO.Active,
+	}
+}
+
+func mapCreateUserDTOToUser(userDTO CreateUserDTO, author string) (domain.User, error) {
 	user, err := domain.NewUser(
+		"",
 		userDTO.FirstName,
 		userDTO.LastName,
 		userDTO.Email,
 		userDTO.Password,
-		userDTO.CreatedBy,
+		author,
 		userDTO.Username,
 		userDTO.Role,
 		userDTO.Region,
@@ -52,18 +86,20 @@ func mapCreateUserDTOToUser(userDTO CreateUserDTO) (domain.User, error) {
 
 func mapUserToUserDTO(user domain.User) UserDTO {
 	return UserDTO{
-		UserID:    user.UserID,
-		Username:  user.Username,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
-		CreatedBy: user.CreatedBy,
-		UpdatedAt: user.UpdatedAt,
-		UpdatedBy: user.UpdatedBy,
-		Region:    user.Region,
-		Active:    user.Active,
+		UserID:             user.UserID,
+		TenantID:           user.TenantID,
+		Username:           user.Username,
+		FirstName:          user.FirstName,
+		LastName:           user.LastName,
+		Email:              user.Email,
+		Role:               user.Role,
+		CreatedAt:          user.CreatedAt,
+		CreatedBy:          user.CreatedBy,
+		UpdatedAt:          user.UpdatedAt,
+		UpdatedBy:          user.UpdatedBy,
+		Region:             user.Region,
+		Active:             user.Active,
+		MustChangePassword: user.MustChangePassword,
 	}
 }
 
@@ -76,3 +112,22 @@ func mapUsersToUserDTOs(users []domain.User) []UserDTO {
 
 	return userDTOs
 }
+
+func mapUserLockEventsToUserLockEventDTOs(lockEvents []domain.UserLockEvent) []UserLockEventDTO {
+	lockEventDTOs := make([]UserLockEventDTO, 0, len(lockEvents))
+	for _, lockEvent := range lockEvents {
+		lockEventDTOs = append(lockEventDTOs, UserLockEventDTO{
+			EventID:     lockEvent.EventID,
+			UserID:      lockEvent.UserID,
+			TenantID:    lockEvent.TenantID,
+			Type:        lockEvent.Type,
+			Reason:      lockEvent.Reason,
+			IPAddress:   lockEvent.IPAddress,
+			LockedUntil: lockEvent.LockedUntil,
+			CreatedAt:   lockEvent.CreatedAt,
+			CreatedBy:   lockEvent.CreatedBy,
+		})
+	}
+
+	return lockEventDTOs
+}
@@ -2,6 +2,7 @@ package entrypoint
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/icrxz/crm-api-core/internal/domain"
 	"github.com/icrxz/crm-api-core/internal/entrypoint/middleware"
 	rest2 "github.com/icrxz/crm-api-core/internal/entrypoint/rest"
 )
@@ -16,79 +17,130 @@ func LoadRoutes(
 	tenantController rest2.TenantController,
 	authController rest2.AuthController,
 	authMiddleware middleware.AuthenticationMiddleware,
+	authorizationMiddleware middleware.AuthorizationMiddleware,
 	ticketController rest2.TicketController,
 	productController rest2.ProductController,
 	commentController rest2.CommentController,
 	transactionController rest2.TransactionController,
 	ticketActionController rest2.TicketActionController,
+	ticketHistoryController rest2.TicketHistoryController,
+	reportTemplateController rest2.ReportTemplateController,
+	reportJobController rest2.ReportJobController,
+	reportExportController rest2.ReportExportController,
+	uploadSessionController rest2.UploadSessionController,
+	attachmentArchiveController rest2.AttachmentArchiveController,
+	notificationController rest2.NotificationController,
 ) {
 	authGroup := app.Group("/crm/core/api/v1")
 	authGroup.Use(authMiddleware.Authenticate())
 
 	publicGroup := app.Group("/crm/core/api/v1")
 
+	can := authorizationMiddleware.Authorize
+
 	// miscellaneous
 	app.GET("/ping", pingController.Pong)
 
 	// user
-	publicGroup.POST("/users", userController.CreateUser)
-	authGroup.GET("/users", userController.SearchUser)
-	authGroup.GET("/users/:userID", userController.GetUser)
-	authGroup.PUT("/users/:userID", userController.UpdateUser)
-	authGroup.DELETE("/users/:userID", userController.DeleteUser)
+	authGroup.POST("/users", can(domain.USER_RESOURCE, domain.CREATE_ACTION), userController.CreateUser)
+	authGroup.GET("/users", can(domain.USER_RESOURCE, domain.READ_ACTION), userController.SearchUser)
+	authGroup.GET("/users/:userID", can(domain.USER_RESOURCE, domain.READ_ACTION), userController.GetUser)
+	authGroup.PUT("/users/:userID", can(domain.USER_RESOURCE, domain.UPDATE_ACTION), userController.UpdateUser)
+	authGroup.DELETE("/users/:userID", can(domain.USER_RESOURCE, domain.DELETE_ACTION), userController.DeleteUser)
+	authGroup.GET("/users/:userID/lock-events", can(domain.USER_RESOURCE, domain.UNLOCK_ACTION), userController.GetLockEvents)
+	authGroup.POST("/users/:userID/unlock", can(domain.USER_RESOURCE, domain.UNLOCK_ACTION), userController.UnlockUser)
 
 	// lead
-	authGroup.POST("/leads", leadController.CreateLead)
-	authGroup.GET("/leads", leadController.SearchLeads)
-	authGroup.GET("/leads/:leadID", leadController.GetLead)
-	authGroup.PUT("/leads/:leadID", leadController.UpdateLead)
-	authGroup.DELETE("/leads/:leadID", leadController.DeleteLead)
-	authGroup.POST("/leads/batch", leadController.CreateBatch)
+	authGroup.POST("/leads", can(domain.LEAD_RESOURCE, domain.CREATE_ACTION), leadController.CreateLead)
+	authGroup.GET("/leads", can(domain.LEAD_RESOURCE, domain.READ_ACTION), leadController.SearchLeads)
+	authGroup.GET("/leads/:leadID", can(domain.LEAD_RESOURCE, domain.READ_ACTION), leadController.GetLead)
+	authGroup.PUT("/leads/:leadID", can(domain.LEAD_RESOURCE, domain.UPDATE_ACTION), leadController.UpdateLead)
+	authGroup.DELETE("/leads/:leadID", can(domain.LEAD_RESOURCE, domain.DELETE_ACTION), leadController.DeleteLead)
+	authGroup.POST("/leads/batch", can(domain.LEAD_RESOURCE, domain.CREATE_ACTION), leadController.CreateBatch)
 
 	// customers
-	authGroup.POST("/customers", customerController.CreateCustomer)
-	authGroup.GET("/customers", customerController.SearchCustomers)
-	authGroup.GET("/customers/:customerID", customerController.GetCustomer)
-	authGroup.PUT("/customers/:customerID", customerController.UpdateCustomer)
-	authGroup.DELETE("/customers/:customerID", customerController.DeleteCustomer)
+	authGroup.POST("/customers", can(domain.CUSTOMER_RESOURCE, domain.CREATE_ACTION), customerController.CreateCustomer)
+	authGroup.GET("/customers", can(domain.CUSTOMER_RESOURCE, domain.READ_ACTION), customerController.SearchCustomers)
+	authGroup.GET("/customers/:customerID", can(domain.CUSTOMER_RESOURCE, domain.READ_ACTION), customerController.GetCustomer)
+	authGroup.PUT("/customers/:customerID", can(domain.CUSTOMER_RESOURCE, domain.UPDATE_ACTION), customerController.UpdateCustomer)
+	authGroup.DELETE("/customers/:customerID", can(domain.CUSTOMER_RESOURCE, domain.DELETE_ACTION), customerController.DeleteCustomer)
 
 	// tenants
-	authGroup.POST("/tenants", tenantController.CreateTenant)
-	authGroup.GET("/tenants", tenantController.SearchTenants)
-	authGroup.GET("/tenants/:tenantID", tenantController.GetTenant)
-	authGroup.PUT("/tenants/:tenantID", tenantController.UpdateTenant)
-	authGroup.DELETE("/tenants/:tenantID", tenantController.DeleteTenant)
+	authGroup.POST("/tenants", can(domain.TENANT_RESOURCE, domain.CREATE_ACTION), tenantController.CreateTenant)
+	authGroup.GET("/tenants", can(domain.TENANT_RESOURCE, domain.READ_ACTION), tenantController.SearchTenants)
+	authGroup.GET("/tenants/:tenantID", can(domain.TENANT_RESOURCE, domain.READ_ACTION), tenantController.GetTenant)
+	authGroup.PUT("/tenants/:tenantID", can(domain.TENANT_RESOURCE, domain.UPDATE_ACTION), tenantController.UpdateTenant)
+	authGroup.DELETE("/tenants/:tenantID", can(domain.TENANT_RESOURCE, domain.DELETE_ACTION), tenantController.DeleteTenant)
+
+	// report templates
+	authGroup.POST("/tenants/:tenantID/report-templates", can(domain.REPORT_RESOURCE, domain.CREATE_ACTION), reportTemplateController.UploadTemplate)
+	authGroup.GET("/tenants/:tenantID/report-templates", can(domain.REPORT_RESOURCE, domain.READ_ACTION), reportTemplateController.SearchTemplates)
+	authGroup.GET("/report-templates/placeholders", can(domain.REPORT_RESOURCE, domain.READ_ACTION), reportTemplateController.ListPlaceholders)
+	authGroup.GET("/report-templates/:reportID", can(domain.REPORT_RESOURCE, domain.READ_ACTION), reportTemplateController.GetTemplate)
+	authGroup.GET("/report-templates/:reportID/file", can(domain.REPORT_RESOURCE, domain.READ_ACTION), reportTemplateController.DownloadTemplate)
+	authGroup.GET("/report-templates/:reportID/validation", can(domain.REPORT_RESOURCE, domain.READ_ACTION), reportTemplateController.ValidateTemplate)
+	authGroup.GET("/report-templates/:reportID/preview", can(domain.REPORT_RESOURCE, domain.READ_ACTION), reportTemplateController.PreviewTemplate)
+	authGroup.PATCH("/report-templates/:reportID/active", can(domain.REPORT_RESOURCE, domain.UPDATE_ACTION), reportTemplateController.UpdateTemplateActive)
 
 	// auth
 	publicGroup.POST("/login", authController.Login)
+	publicGroup.POST("/refresh", authController.Refresh)
 	authGroup.POST("/logout", authController.Logout)
+	authGroup.POST("/logout/all", authController.LogoutAll)
+	publicGroup.POST("/password/forgot", authController.ForgotPassword)
+	publicGroup.POST("/password/reset", authController.ResetPassword)
+	authGroup.POST("/password/change", authController.ChangePassword)
+	authGroup.DELETE("/users/:userID/sessions", can(domain.USER_RESOURCE, domain.UPDATE_ACTION), authController.RevokeUserSessions)
 
 	// webMessage
 	publicGroup.POST("/web/message", webMessageController.ReceiveMessage)
 
 	// tickets
-	authGroup.POST("/tickets", ticketController.CreateTicket)
-	authGroup.GET("/tickets/:ticketID", ticketController.GetTicket)
-	authGroup.PATCH("/tickets/:ticketID", ticketController.UpdateTicket)
-	authGroup.GET("/tickets", ticketController.SearchTickets)
+	authGroup.POST("/tickets", can(domain.TICKET_RESOURCE, domain.CREATE_ACTION), ticketController.CreateTicket)
+	authGroup.GET("/tickets/:ticketID", can(domain.TICKET_RESOURCE, domain.READ_ACTION), ticketController.GetTicket)
+	authGroup.PATCH("/tickets/:ticketID", can(domain.TICKET_RESOURCE, domain.UPDATE_ACTION), ticketController.UpdateTicket)
+	authGroup.GET("/tickets", can(domain.TICKET_RESOURCE, domain.READ_ACTION), ticketController.SearchTickets)
 
 	// products
-	authGroup.GET("/products/:productID", productController.GetProductByID)
+	authGroup.GET("/products/:productID", can(domain.PRODUCT_RESOURCE, domain.READ_ACTION), productController.GetProductByID)
 
 	// comments
-	authGroup.GET("/comments/:commentID", commentController.GetByID)
-	authGroup.POST("/tickets/:ticketID/comments", commentController.CreateComment)
-	authGroup.GET("/tickets/:ticketID/comments", commentController.GetByTicketID)
+	authGroup.GET("/comments/:commentID", can(domain.COMMENT_RESOURCE, domain.READ_ACTION), commentController.GetByID)
+	authGroup.PUT("/comments/:commentID", can(domain.COMMENT_RESOURCE, domain.UPDATE_ACTION), commentController.UpdateComment)
+	authGroup.DELETE("/comments/:commentID", can(domain.COMMENT_RESOURCE, domain.DELETE_ACTION), commentController.DeleteComment)
+	authGroup.GET("/comments/:commentID/revisions", can(domain.COMMENT_RESOURCE, domain.READ_ACTION), commentController.GetRevisions)
+	authGroup.POST("/tickets/:ticketID/comments", can(domain.COMMENT_RESOURCE, domain.CREATE_ACTION), commentController.CreateComment)
+	authGroup.GET("/tickets/:ticketID/comments", can(domain.COMMENT_RESOURCE, domain.READ_ACTION), commentController.GetByTicketID)
+	authGroup.GET("/tickets/:ticketID/attachments.zip", can(domain.COMMENT_RESOURCE, domain.READ_ACTION), attachmentArchiveController.DownloadTicketAttachments)
+
+	// attachment uploads
+	authGroup.POST("/comments/:commentID/uploads", can(domain.COMMENT_RESOURCE, domain.CREATE_ACTION), uploadSessionController.CreateUploadSession)
+	authGroup.GET("/uploads/:sessionID", can(domain.COMMENT_RESOURCE, domain.READ_ACTION), uploadSessionController.GetUploadSession)
+	authGroup.POST("/uploads/:sessionID/complete", can(domain.COMMENT_RESOURCE, domain.CREATE_ACTION), uploadSessionController.CompleteUploadSession)
 
 	// transactions
-	authGroup.POST("/tickets/:ticketID/transactions", transactionController.CreateTransaction)
-	authGroup.GET("/transactions/:transactionID", transactionController.GetTransaction)
-	authGroup.PUT("/transactions/:transactionID", transactionController.UpdateTransaction)
-	authGroup.GET("/transactions", transactionController.SearchTransactions)
+	authGroup.POST("/tickets/:ticketID/transactions", can(domain.TRANSACTION_RESOURCE, domain.CREATE_ACTION), transactionController.CreateTransaction)
+	authGroup.GET("/transactions/:transactionID", can(domain.TRANSACTION_RESOURCE, domain.READ_ACTION), transactionController.GetTransaction)
+	authGroup.PUT("/transactions/:transactionID", can(domain.TRANSACTION_RESOURCE, domain.UPDATE_ACTION), transactionController.UpdateTransaction)
+	authGroup.GET("/transactions", can(domain.TRANSACTION_RESOURCE, domain.READ_ACTION), transactionController.SearchTransactions)
 
 	// ticket actions
-	authGroup.PATCH("/tickets/:ticketID/owner", ticketActionController.ChangeOwner)
-	authGroup.PATCH("/tickets/:ticketID/status", ticketActionController.ChangeStatus)
-	authGroup.PATCH("/tickets/:ticketID/lead", ticketActionController.ChangeLead)
-	authGroup.GET("/tickets/:ticketID/report", ticketActionController.DownloadReport)
+	authGroup.PATCH("/tickets/:ticketID/owner", can(domain.TICKET_RESOURCE, domain.CHANGE_OWNER_ACTION), ticketActionController.ChangeOwner)
+	authGroup.PATCH("/tickets/:ticketID/status", can(domain.TICKET_RESOURCE, domain.UPDATE_ACTION), ticketActionController.ChangeStatus)
+	authGroup.PATCH("/tickets/:ticketID/lead", can(domain.TICKET_RESOURCE, domain.UPDATE_ACTION), ticketActionController.ChangeLead)
+	authGroup.GET("/tickets/:ticketID/report", can(domain.TICKET_RESOURCE, domain.READ_ACTION), ticketActionController.DownloadReport)
+
+	// report jobs
+	authGroup.POST("/tickets/:ticketID/reports", can(domain.TICKET_RESOURCE, domain.READ_ACTION), reportJobController.CreateReportJob)
+	authGroup.GET("/tickets/:ticketID/reports", can(domain.TICKET_RESOURCE, domain.READ_ACTION), reportJobController.SearchTicketReports)
+	authGroup.GET("/reports/:jobID", can(domain.TICKET_RESOURCE, domain.READ_ACTION), reportJobController.GetReportJob)
+	authGroup.GET("/reports/:jobID/file", can(domain.TICKET_RESOURCE, domain.READ_ACTION), reportJobController.DownloadReport)
+	authGroup.POST("/reports/export", can(domain.TICKET_RESOURCE, domain.READ_ACTION), reportExportController.ExportReports)
+
+	// ticket history
+	authGroup.GET("/tickets/:ticketID/history", can(domain.TICKET_RESOURCE, domain.READ_ACTION), ticketHistoryController.GetHistory)
+
+	// notifications
+	authGroup.GET("/me/notifications", can(domain.NOTIFICATION_RESOURCE, domain.READ_ACTION), notificationController.GetMyNotifications)
+	authGroup.POST("/me/notifications/read", can(domain.NOTIFICATION_RESOURCE, domain.UPDATE_ACTION), notificationController.MarkMyNotificationsRead)
 }
@@ -2,22 +2,27 @@ package bucket
 
 import (
 	"context"
+	"errors"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/icrxz/crm-api-core/internal/domain"
 )
 
 type attachmentBucket struct {
-	s3Client   *s3.Client
-	bucketName string
+	s3Client      *s3.Client
+	presignClient *s3.PresignClient
+	bucketName    string
 }
 
 func NewAttachmentBucket(s3Client *s3.Client, bucketName string) domain.AttachmentBucket {
 	return &attachmentBucket{
-		s3Client:   s3Client,
-		bucketName: bucketName,
+		s3Client:      s3Client,
+		presignClient: s3.NewPresignClient(s3Client),
+		bucketName:    bucketName,
 	}
 }
 
@@ -38,3 +43,85 @@ func (b *attachmentBucket) Download(ctx context.Context, fileID string) ([]byte,
 
 	return file, nil
 }
+
+func (b *attachmentBucket) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	result, err := b.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, domain.NewNotFoundError("attachment file not found", map[string]any{"key": key})
+		}
+		return nil, err
+	}
+
+	return result.Body, nil
+}
+
+func (b *attachmentBucket) Upload(ctx context.Context, key string, file io.Reader, contentType string) error {
+	_, err := b.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucketName),
+		Key:         aws.String(key),
+		Body:        file,
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+func (b *attachmentBucket) Delete(ctx context.Context, key string) error {
+	_, err := b.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucketName),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (b *attachmentBucket) Stat(ctx context.Context, key string) (*domain.BucketObject, error) {
+	result, err := b.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(b.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, domain.NewNotFoundError("attachment file not found", map[string]any{"key": key})
+		}
+		return nil, err
+	}
+
+	return &domain.BucketObject{
+		Key:         key,
+		Size:        int(aws.ToInt64(result.ContentLength)),
+		ContentType: aws.ToString(result.ContentType),
+	}, nil
+}
+
+// PresignUpload signs the content type and length with the URL, so the upload is refused by S3 when
+// the client sends a different file than the one it declared.
+func (b *attachmentBucket) PresignUpload(ctx context.Context, key, contentType string, size int, expiresIn time.Duration) (string, error) {
+	request, err := b.presignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(b.bucketName),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(int64(size)),
+	}, s3.WithPresignExpires(expiresIn))
+	if err != nil {
+		return "", err
+	}
+
+	return request.URL, nil
+}
+
+func (b *attachmentBucket) PresignDownload(ctx context.Context, key string, expiresIn time.Duration) (string, error) {
+	request, err := b.presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucketName),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expiresIn))
+	if err != nil {
+		return "", err
+	}
+
+	return request.URL, nil
+}
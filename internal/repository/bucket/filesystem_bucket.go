@@ -0,0 +1,203 @@
+package bucket
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+// LocalBucketPath is where the API serves the presigned URLs of the file system bucket.
+const LocalBucketPath = "/local-bucket"
+
+type fileSystemBucket struct {
+	rootFolder string
+	baseURL    string
+	secret     []byte
+}
+
+// NewFileSystemBucket stores attachments in a local folder. Its presigned URLs point back to the API,
+// which must route LocalBucketPath to the returned bucket, as it is also an http.Handler.
+func NewFileSystemBucket(rootFolder, baseURL, secret string) (domain.AttachmentBucket, error) {
+	if err := os.MkdirAll(rootFolder, 0o755); err != nil {
+		return nil, err
+	}
+
+	signingKey := []byte(secret)
+	if secret == "" {
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return &fileSystemBucket{
+		rootFolder: rootFolder,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		secret:     signingKey,
+	}, nil
+}
+
+func (b *fileSystemBucket) Download(ctx context.Context, fileID string) ([]byte, error) {
+	file, err := os.ReadFile(b.filePath(fileID))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, domain.NewNotFoundError("attachment file not found", map[string]any{"key": fileID})
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (b *fileSystemBucket) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(b.filePath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, domain.NewNotFoundError("attachment file not found", map[string]any{"key": key})
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (b *fileSystemBucket) Upload(ctx context.Context, key string, file io.Reader, contentType string) error {
+	filePath := b.filePath(key)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+
+	output, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	if _, err = io.Copy(output, file); err != nil {
+		return err
+	}
+
+	return output.Close()
+}
+
+func (b *fileSystemBucket) Delete(ctx context.Context, key string) error {
+	err := os.Remove(b.filePath(key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (b *fileSystemBucket) Stat(ctx context.Context, key string) (*domain.BucketObject, error) {
+	info, err := os.Stat(b.filePath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, domain.NewNotFoundError("attachment file not found", map[string]any{"key": key})
+		}
+		return nil, err
+	}
+
+	return &domain.BucketObject{
+		Key:         key,
+		Size:        int(info.Size()),
+		ContentType: mime.TypeByExtension(filepath.Ext(key)),
+	}, nil
+}
+
+func (b *fileSystemBucket) PresignUpload(ctx context.Context, key, contentType string, size int, expiresIn time.Duration) (string, error) {
+	return b.presign(http.MethodPut, key, contentType, size, expiresIn), nil
+}
+
+func (b *fileSystemBucket) PresignDownload(ctx context.Context, key string, expiresIn time.Duration) (string, error) {
+	return b.presign(http.MethodGet, key, "", 0, expiresIn), nil
+}
+
+// ServeHTTP plays the part of the bucket for presigned URLs: it checks the signature and expiry, and
+// for uploads the declared content type and length, the same way S3 does.
+func (b *fileSystemBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	key := query.Get("key")
+	contentType := query.Get("content_type")
+	expires, _ := strconv.ParseInt(query.Get("expires"), 10, 64)
+	size, _ := strconv.Atoi(query.Get("size"))
+
+	signature := b.sign(r.Method, key, contentType, size, expires)
+	if time.Now().Unix() > expires || !hmac.Equal([]byte(signature), []byte(query.Get("signature"))) {
+		http.Error(w, "invalid or expired signature", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		file, err := os.Open(b.filePath(key))
+		if err != nil {
+			http.Error(w, "file not found", http.StatusNotFound)
+			return
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		http.ServeContent(w, r, filepath.Base(key), info.ModTime(), file)
+	case http.MethodPut:
+		if r.Header.Get("Content-Type") != contentType || r.ContentLength != int64(size) {
+			http.Error(w, "content type or length don't match the signed ones", http.StatusBadRequest)
+			return
+		}
+
+		if err := b.Upload(r.Context(), key, io.LimitReader(r.Body, int64(size)), contentType); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (b *fileSystemBucket) presign(method, key, contentType string, size int, expiresIn time.Duration) string {
+	expires := time.Now().Add(expiresIn).Unix()
+
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	if method == http.MethodPut {
+		query.Set("content_type", contentType)
+		query.Set("size", strconv.Itoa(size))
+	}
+	query.Set("signature", b.sign(method, key, contentType, size, expires))
+
+	return b.baseURL + LocalBucketPath + "?" + query.Encode()
+}
+
+func (b *fileSystemBucket) sign(method, key, contentType string, size int, expires int64) string {
+	mac := hmac.New(sha256.New, b.secret)
+	mac.Write([]byte(strings.Join([]string{method, key, contentType, strconv.Itoa(size), strconv.FormatInt(expires, 10)}, "\n")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (b *fileSystemBucket) filePath(fileID string) string {
+	return filepath.Join(b.rootFolder, filepath.Clean("/"+fileID))
+}
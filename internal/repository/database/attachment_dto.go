@@ -7,25 +7,43 @@ import (
 )
 
 type AttachmentDTO struct {
-	AttachmentID  string    `db:"attachment_id"`
-	CommentID     string    `db:"comment_id"`
-	Key           string    `db:"key"`
-	FileName      string    `db:"file_name"`
-	AttachmentURL string    `db:"attachment_url"`
-	FileExtension string    `db:"file_extension"`
-	Size          int       `db:"size"`
-	CreatedAt     time.Time `db:"created_at"`
-	CreatedBy     string    `db:"created_by"`
+	AttachmentID  string     `db:"attachment_id" bson:"_id"`
+	TenantID      string     `db:"tenant_id" bson:"tenant_id"`
+	CommentID     string     `db:"comment_id" bson:"comment_id"`
+	Key           string     `db:"key" bson:"key"`
+	FileName      string     `db:"file_name" bson:"file_name"`
+	AttachmentURL string     `db:"attachment_url" bson:"attachment_url"`
+	FileExtension string     `db:"file_extension" bson:"file_extension"`
+	ContentType   string     `db:"content_type" bson:"content_type"`
+	Checksum      string     `db:"checksum" bson:"checksum"`
+	Visibility    string     `db:"visibility" bson:"visibility"`
+	ThumbnailKey  string     `db:"thumbnail_key" bson:"thumbnail_key"`
+	TakenAt       *time.Time `db:"taken_at" bson:"taken_at"`
+	Latitude      *float64   `db:"latitude" bson:"latitude"`
+	Longitude     *float64   `db:"longitude" bson:"longitude"`
+	CameraModel   string     `db:"camera_model" bson:"camera_model"`
+	Size          int        `db:"size" bson:"size"`
+	CreatedAt     time.Time  `db:"created_at" bson:"created_at"`
+	CreatedBy     string     `db:"created_by" bson:"created_by"`
 }
 
 func mapAttachmentToAttachmentDTO(attachment domain.Attachment) AttachmentDTO {
 	return AttachmentDTO{
 		AttachmentID:  attachment.AttachmentID,
+		TenantID:      attachment.TenantID,
 		CommentID:     attachment.CommentID,
 		Key:           attachment.Key,
 		FileName:      attachment.FileName,
 		AttachmentURL: attachment.AttachmentURL,
 		FileExtension: attachment.FileExtension,
+		ContentType:   attachment.ContentType,
+		Checksum:      attachment.Checksum,
+		Visibility:    string(attachment.Visibility),
+		ThumbnailKey:  attachment.ThumbnailKey,
+		TakenAt:       attachment.TakenAt,
+		Latitude:      attachment.Latitude,
+		Longitude:     attachment.Longitude,
+		CameraModel:   attachment.CameraModel,
 		Size:          attachment.Size,
 		CreatedAt:     attachment.CreatedAt,
 		CreatedBy:     attachment.CreatedBy,
@@ -35,11 +53,20 @@ func mapAttachmentToAttachmentDTO(attachment domain.Attachment) AttachmentDTO {
 func mapAttachmentDTOToAttachment(attachmentDTO AttachmentDTO) domain.Attachment {
 	return domain.Attachment{
 		AttachmentID:  attachmentDTO.AttachmentID,
+		TenantID:      attachmentDTO.TenantID,
 		CommentID:     attachmentDTO.CommentID,
 		Key:           attachmentDTO.Key,
 		FileName:      attachmentDTO.FileName,
 		AttachmentURL: attachmentDTO.AttachmentURL,
 		FileExtension: attachmentDTO.FileExtension,
+		ContentType:   attachmentDTO.ContentType,
+		Checksum:      attachmentDTO.Checksum,
+		Visibility:    domain.Visibility(attachmentDTO.Visibility),
+		ThumbnailKey:  attachmentDTO.ThumbnailKey,
+		TakenAt:       attachmentDTO.TakenAt,
+		Latitude:      attachmentDTO.Latitude,
+		Longitude:     attachmentDTO.Longitude,
+		CameraModel:   attachmentDTO.CameraModel,
 		Size:          attachmentDTO.Size,
 		CreatedAt:     attachmentDTO.CreatedAt,
 		CreatedBy:     attachmentDTO.CreatedBy,
@@ -2,7 +2,6 @@ package database
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/icrxz/crm-api-core/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
@@ -20,8 +19,8 @@ func NewAttachmentRepository(db *mongo.Client) domain.AttachmentRepository {
 }
 
 func (r *attachmentRepository) attachmentCollection(ctx context.Context) *mongo.Collection {
-	userCollection := GetCollection(r.db, "user")
-	return userCollection
+	attachmentCollection := GetCollection(r.db, "attachments")
+	return attachmentCollection
 }
 
 func (r *attachmentRepository) Save(ctx context.Context, attachment domain.Attachment) error {
@@ -49,9 +48,9 @@ func (r *attachmentRepository) GetByID(ctx context.Context, attachmentID string)
 	}
 
 	var attachmentDTO AttachmentDTO
-	err := r.attachmentCollection(ctx).FindOne(context.TODO(), bson.D{{"_id", attachmentID}}).Decode(&attachmentDTO)
+	err := r.attachmentCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": attachmentID}, "tenant_id")).Decode(&attachmentDTO)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
 			return domain.Attachment{}, domain.NewNotFoundError("attachment not found", map[string]any{"attachment_id": attachmentID})
 		}
 
@@ -66,12 +65,15 @@ func (r *attachmentRepository) GetByCommentID(ctx context.Context, commentID str
 		return nil, domain.NewValidationError("comment_id is required", nil)
 	}
 
-	var attachmentsDTO []AttachmentDTO
-	err := r.attachmentCollection(ctx).FindOne(context.TODO(), bson.D{{"comment_id", commentID}}).Decode(&attachmentsDTO)
+	filter := withTenantScope(ctx, bson.M{"comment_id": commentID}, "tenant_id")
+
+	cursor, err := r.attachmentCollection(ctx).Find(ctx, filter)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
-		}
+		return nil, err
+	}
+
+	var attachmentsDTO []AttachmentDTO
+	if err = cursor.All(ctx, &attachmentsDTO); err != nil {
 		return nil, err
 	}
 
@@ -7,39 +7,60 @@ import (
 )
 
 type CommentDTO struct {
-	CommentID   string    `db:"comment_id"`
-	TicketID    string    `db:"ticket_id"`
-	Content     string    `db:"content"`
-	CommentType string    `db:"comment_type"`
-	CreatedBy   string    `db:"created_by"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedBy   string    `db:"updated_by"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	CommentID       string     `db:"comment_id" bson:"_id"`
+	TenantID        string     `db:"tenant_id" bson:"tenant_id"`
+	TicketID        string     `db:"ticket_id" bson:"ticket_id"`
+	ParentCommentID string     `db:"parent_comment_id" bson:"parent_comment_id"`
+	Content         string     `db:"content" bson:"content"`
+	CommentType     string     `db:"comment_type" bson:"comment_type"`
+	Visibility      string     `db:"visibility" bson:"visibility"`
+	Mentions        []string   `db:"mentions" bson:"mentions"`
+	CreatedBy       string     `db:"created_by" bson:"created_by"`
+	CreatedAt       time.Time  `db:"created_at" bson:"created_at"`
+	UpdatedBy       string     `db:"updated_by" bson:"updated_by"`
+	UpdatedAt       time.Time  `db:"updated_at" bson:"updated_at"`
+	Revision        int        `db:"revision" bson:"revision"`
+	DeletedBy       string     `db:"deleted_by" bson:"deleted_by"`
+	DeletedAt       *time.Time `db:"deleted_at" bson:"deleted_at"`
 }
 
 func mapCommentToCommentDTO(comment domain.Comment) CommentDTO {
 	return CommentDTO{
-		CommentID:   comment.CommentID,
-		TicketID:    comment.TicketID,
-		Content:     comment.Content,
-		CommentType: string(comment.CommentType),
-		CreatedBy:   comment.CreatedBy,
-		CreatedAt:   comment.CreatedAt,
-		UpdatedBy:   comment.UpdatedBy,
-		UpdatedAt:   comment.UpdatedAt,
+		CommentID:       comment.CommentID,
+		TenantID:        comment.TenantID,
+		TicketID:        comment.TicketID,
+		ParentCommentID: comment.ParentCommentID,
+		Content:         comment.Content,
+		CommentType:     string(comment.CommentType),
+		Visibility:      string(comment.Visibility),
+		Mentions:        comment.Mentions,
+		CreatedBy:       comment.CreatedBy,
+		CreatedAt:       comment.CreatedAt,
+		UpdatedBy:       comment.UpdatedBy,
+		UpdatedAt:       comment.UpdatedAt,
+		Revision:        comment.Revision,
+		DeletedBy:       comment.DeletedBy,
+		DeletedAt:       comment.DeletedAt,
 	}
 }
 
 func mapCommentDTOToComment(commentDTO CommentDTO) domain.Comment {
 	return domain.Comment{
-		CommentID:   commentDTO.CommentID,
-		TicketID:    commentDTO.TicketID,
-		Content:     commentDTO.Content,
-		CommentType: domain.CommentType(commentDTO.CommentType),
-		CreatedBy:   commentDTO.CreatedBy,
-		CreatedAt:   commentDTO.CreatedAt,
-		UpdatedBy:   commentDTO.UpdatedBy,
-		UpdatedAt:   commentDTO.UpdatedAt,
+		CommentID:       commentDTO.CommentID,
+		TenantID:        commentDTO.TenantID,
+		TicketID:        commentDTO.TicketID,
+		ParentCommentID: commentDTO.ParentCommentID,
+		Content:         commentDTO.Content,
+		CommentType:     domain.CommentType(commentDTO.CommentType),
+		Visibility:      domain.Visibility(commentDTO.Visibility),
+		Mentions:        commentDTO.Mentions,
+		CreatedBy:       commentDTO.CreatedBy,
+		CreatedAt:       commentDTO.CreatedAt,
+		UpdatedBy:       commentDTO.UpdatedBy,
+		UpdatedAt:       commentDTO.UpdatedAt,
+		Revision:        commentDTO.Revision,
+		DeletedBy:       commentDTO.DeletedBy,
+		DeletedAt:       commentDTO.DeletedAt,
 	}
 }
 
@@ -2,10 +2,10 @@ package database
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/icrxz/crm-api-core/internal/domain"
 )
@@ -21,8 +21,8 @@ func NewCommentRepository(db *mongo.Client) domain.CommentRepository {
 }
 
 func (r *commentRepository) commentCollection(ctx context.Context) *mongo.Collection {
-	userCollection := GetCollection(r.db, "user")
-	return userCollection
+	commentCollection := GetCollection(r.db, "comments")
+	return commentCollection
 }
 
 func (r *commentRepository) Create(ctx context.Context, comment domain.Comment) (string, error) {
@@ -43,9 +43,9 @@ func (r *commentRepository) GetByID(ctx context.Context, commentID string) (*dom
 	}
 
 	var commentDTO CommentDTO
-	err := r.commentCollection(ctx).FindOne(context.TODO(), bson.D{{"_id", commentID}}).Decode(&commentDTO)
+	err := r.commentCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": commentID}, "tenant_id")).Decode(&commentDTO)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, domain.NewNotFoundError("no comment found with this id", map[string]any{"comment_id": commentID})
 		}
 		return nil, err
@@ -61,13 +61,36 @@ func (r *commentRepository) GetByTicketID(ctx context.Context, ticketID string)
 		return nil, domain.NewValidationError("ticketID is required", nil)
 	}
 
-	var commentDTOs []CommentDTO
-	err := r.commentCollection(ctx).FindOne(context.TODO(), bson.D{{"ticket_id", ticketID}}).Decode(&commentDTOs)
+	filter := withTenantScope(ctx, bson.M{"ticket_id": ticketID, "deleted_at": nil}, "tenant_id")
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.commentCollection(ctx).Find(ctx, filter, findOptions)
 	if err != nil {
 		return nil, err
 	}
 
+	var commentDTOs []CommentDTO
+	if err = cursor.All(ctx, &commentDTOs); err != nil {
+		return nil, err
+	}
+
 	comments := mapCommentDTOsToComments(commentDTOs)
 
 	return comments, nil
 }
+
+func (r *commentRepository) Update(ctx context.Context, comment domain.Comment) error {
+	commentDTO := mapCommentToCommentDTO(comment)
+	filter := withTenantScope(ctx, bson.M{"_id": comment.CommentID}, "tenant_id")
+
+	result, err := r.commentCollection(ctx).ReplaceOne(ctx, filter, commentDTO)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.NewNotFoundError("no comment found with this id", map[string]any{"comment_id": comment.CommentID})
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type CommentRevisionDTO struct {
+	RevisionID  string    `db:"revision_id" bson:"_id"`
+	CommentID   string    `db:"comment_id" bson:"comment_id"`
+	TenantID    string    `db:"tenant_id" bson:"tenant_id"`
+	Revision    int       `db:"revision" bson:"revision"`
+	Content     string    `db:"content" bson:"content"`
+	CommentType string    `db:"comment_type" bson:"comment_type"`
+	Visibility  string    `db:"visibility" bson:"visibility"`
+	EditedBy    string    `db:"edited_by" bson:"edited_by"`
+	EditedAt    time.Time `db:"edited_at" bson:"edited_at"`
+}
+
+func mapCommentRevisionToCommentRevisionDTO(revision domain.CommentRevision) CommentRevisionDTO {
+	return CommentRevisionDTO{
+		RevisionID:  revision.RevisionID,
+		CommentID:   revision.CommentID,
+		TenantID:    revision.TenantID,
+		Revision:    revision.Revision,
+		Content:     revision.Content,
+		CommentType: string(revision.CommentType),
+		Visibility:  string(revision.Visibility),
+		EditedBy:    revision.EditedBy,
+		EditedAt:    revision.EditedAt,
+	}
+}
+
+func mapCommentRevisionDTOToCommentRevision(revisionDTO CommentRevisionDTO) domain.CommentRevision {
+	return domain.CommentRevision{
+		RevisionID:  revisionDTO.RevisionID,
+		CommentID:   revisionDTO.CommentID,
+		TenantID:    revisionDTO.TenantID,
+		Revision:    revisionDTO.Revision,
+		Content:     revisionDTO.Content,
+		CommentType: domain.CommentType(revisionDTO.CommentType),
+		Visibility:  domain.Visibility(revisionDTO.Visibility),
+		EditedBy:    revisionDTO.EditedBy,
+		EditedAt:    revisionDTO.EditedAt,
+	}
+}
+
+func mapCommentRevisionDTOsToCommentRevisions(revisionDTOs []CommentRevisionDTO) []domain.CommentRevision {
+	revisions := make([]domain.CommentRevision, 0, len(revisionDTOs))
+	for _, revisionDTO := range revisionDTOs {
+		revisions = append(revisions, mapCommentRevisionDTOToCommentRevision(revisionDTO))
+	}
+	return revisions
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commentRevisionRepository struct {
+	client *mongo.Client
+}
+
+func NewCommentRevisionRepository(client *mongo.Client) domain.CommentRevisionRepository {
+	return &commentRevisionRepository{
+		client: client,
+	}
+}
+
+func (r *commentRevisionRepository) commentRevisionCollection(ctx context.Context) *mongo.Collection {
+	commentRevisionCollection := GetCollection(r.client, "comment_revisions")
+	return commentRevisionCollection
+}
+
+func (r *commentRevisionRepository) Create(ctx context.Context, revision domain.CommentRevision) (string, error) {
+	revisionDTO := mapCommentRevisionToCommentRevisionDTO(revision)
+
+	_, err := r.commentRevisionCollection(ctx).InsertOne(ctx, revisionDTO)
+	if err != nil {
+		return "", err
+	}
+
+	return revision.RevisionID, nil
+}
+
+func (r *commentRevisionRepository) GetByCommentID(ctx context.Context, commentID string) ([]domain.CommentRevision, error) {
+	if commentID == "" {
+		return nil, domain.NewValidationError("commentID is required", nil)
+	}
+
+	filter := withTenantScope(ctx, bson.M{"comment_id": commentID}, "tenant_id")
+	findOptions := options.Find().SetSort(bson.D{{Key: "revision", Value: 1}})
+
+	cursor, err := r.commentRevisionCollection(ctx).Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisionDTOs []CommentRevisionDTO
+	if err = cursor.All(ctx, &revisionDTOs); err != nil {
+		return nil, err
+	}
+
+	return mapCommentRevisionDTOsToCommentRevisions(revisionDTOs), nil
+}
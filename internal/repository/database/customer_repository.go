@@ -2,13 +2,14 @@ package database
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/icrxz/crm-api-core/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+var customerSortableFields = []string{"created_at", "updated_at", "first_name", "company_name"}
+
 type customerRepository struct {
 	client *mongo.Client
 }
@@ -20,8 +21,8 @@ func NewCustomerRepository(client *mongo.Client) domain.CustomerRepository {
 }
 
 func (db *customerRepository) customerCollection(ctx context.Context) *mongo.Collection {
-	leadCollection := GetCollection(db.client, "leads")
-	return leadCollection
+	customerCollection := GetCollection(db.client, "customers")
+	return customerCollection
 }
 
 func (db *customerRepository) Create(ctx context.Context, customer domain.Customer) (string, error) {
@@ -38,9 +39,9 @@ func (db *customerRepository) Create(ctx context.Context, customer domain.Custom
 
 func (db *customerRepository) GetByID(ctx context.Context, customerID string) (*domain.Customer, error) {
 	var customerDTO CustomerDTO
-	err := db.customerCollection(ctx).FindOne(context.TODO(), bson.D{{"_id", customerID}}).Decode(&customerDTO)
+	err := db.customerCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": customerID}, "tenant_id")).Decode(&customerDTO)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, domain.NewNotFoundError("no customer found with this id", map[string]any{"customer_id": customerID})
 		}
 		return nil, err
@@ -52,34 +53,33 @@ func (db *customerRepository) GetByID(ctx context.Context, customerID string) (*
 }
 
 func (db *customerRepository) Search(ctx context.Context, filters domain.CustomerFilters) (domain.PagingResult[domain.Customer], error) {
-
 	filter := bson.M{}
 	if len(filters.CustomerID) > 0 {
-		filter["_id"] = filters.CustomerID
+		filter["_id"] = inFilter(filters.CustomerID)
+	}
+	if len(filters.TenantID) > 0 {
+		filter["tenant_id"] = inFilter(filters.TenantID)
+	}
+	if len(filters.OwnerID) > 0 {
+		filter["owner_id"] = inFilter(filters.OwnerID)
 	}
 	if len(filters.CustomerType) > 0 {
-		filter["document"] = filters.CustomerType
+		filter["customer_type"] = inFilter(filters.CustomerType)
 	}
 	if len(filters.Document) > 0 {
-		filter["lead_type"] = filters.Document
+		filter["document"] = inFilter(filters.Document)
 	}
 
-	cursor, err := db.customerCollection(ctx).Find(ctx, filter)
+	filter = withTenantScope(ctx, filter, "tenant_id")
 
-	var results []CustomerDTO
-	if err = cursor.All(context.TODO(), &results); err != nil {
-		panic(err)
+	results, paging, err := searchCollection[CustomerDTO](ctx, db.customerCollection(ctx), filter, filters.PagingFilter, customerSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Customer]{}, err
 	}
 
-	customers := mapCustomerDTOsToCustomers(results)
-
 	result := domain.PagingResult[domain.Customer]{
-		Result: customers,
-		Paging: domain.Paging{
-			Total:  len(results),
-			Limit:  filters.Limit,
-			Offset: filters.Offset,
-		},
+		Result: mapCustomerDTOsToCustomers(results),
+		Paging: paging,
 	}
 
 	return result, nil
@@ -88,12 +88,15 @@ func (db *customerRepository) Search(ctx context.Context, filters domain.Custome
 func (db *customerRepository) Update(ctx context.Context, customer domain.Customer) error {
 	customerDTO := mapCustomerToCustomerDTO(customer)
 
-	filter := bson.D{{"_id", customer.CustomerID}}
+	filter := withTenantScope(ctx, bson.M{"_id": customer.CustomerID}, "tenant_id")
 
-	result := db.customerCollection(ctx).FindOneAndUpdate(ctx, filter, customerDTO)
+	result, err := db.customerCollection(ctx).ReplaceOne(ctx, filter, customerDTO)
+	if err != nil {
+		return err
+	}
 
-	if result.Err() != nil {
-		return result.Err()
+	if result.MatchedCount == 0 {
+		return domain.NewNotFoundError("no customer found with this id", map[string]any{"customer_id": customer.CustomerID})
 	}
 
 	return nil
@@ -104,7 +107,7 @@ func (db *customerRepository) Delete(ctx context.Context, customerID string) err
 		return domain.NewValidationError("customer id is required", map[string]any{"customer_id": customerID})
 	}
 
-	_, err := db.customerCollection(ctx).DeleteOne(ctx, bson.D{{"_id", customerID}})
+	_, err := db.customerCollection(ctx).DeleteOne(ctx, withTenantScope(ctx, bson.M{"_id": customerID}, "tenant_id"))
 	if err != nil {
 		return err
 	}
@@ -7,35 +7,35 @@ import (
 )
 
 type LeadDTO struct {
-	LeadID          string    `db:"lead_id"`
-	FirstName       string    `db:"first_name"`
-	LastName        string    `db:"last_name"`
-	CompanyName     string    `db:"company_name"`
-	LegalName       string    `db:"legal_name"`
-	LeadType        string    `db:"lead_type"`
-	Document        string    `db:"document"`
-	DocumentType    string    `db:"document_type"`
-	ShippingAddress string    `db:"shipping_address"`
-	ShippingCity    string    `db:"shipping_city"`
-	ShippingState   string    `db:"shipping_state"`
-	ShippingZipCode string    `db:"shipping_zip_code"`
-	ShippingCountry string    `db:"shipping_country"`
-	BillingAddress  string    `db:"billing_address"`
-	BillingCity     string    `db:"billing_city"`
-	BillingState    string    `db:"billing_state"`
-	BillingZipCode  string    `db:"billing_zip_code"`
-	BillingCountry  string    `db:"billing_country"`
-	PersonalPhone   string    `db:"personal_phone"`
-	BusinessPhone   string    `db:"business_phone"`
-	PersonalEmail   string    `db:"personal_email"`
-	BusinessEmail   string    `db:"business_email"`
-	Region          *int      `db:"region"`
-	CreatedBy       string    `db:"created_by"`
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedBy       string    `db:"updated_by"`
-	UpdatedAt       time.Time `db:"updated_at"`
-	Active          bool      `db:"active"`
-	Description     *string   `db:"description"`
+	LeadID          string    `db:"lead_id" bson:"_id"`
+	FirstName       string    `db:"first_name" bson:"first_name"`
+	LastName        string    `db:"last_name" bson:"last_name"`
+	CompanyName     string    `db:"company_name" bson:"company_name"`
+	LegalName       string    `db:"legal_name" bson:"legal_name"`
+	LeadType        string    `db:"lead_type" bson:"lead_type"`
+	Document        string    `db:"document" bson:"document"`
+	DocumentType    string    `db:"document_type" bson:"document_type"`
+	ShippingAddress string    `db:"shipping_address" bson:"shipping_address"`
+	ShippingCity    string    `db:"shipping_city" bson:"shipping_city"`
+	ShippingState   string    `db:"shipping_state" bson:"shipping_state"`
+	ShippingZipCode string    `db:"shipping_zip_code" bson:"shipping_zip_code"`
+	ShippingCountry string    `db:"shipping_country" bson:"shipping_country"`
+	BillingAddress  string    `db:"billing_address" bson:"billing_address"`
+	BillingCity     string    `db:"billing_city" bson:"billing_city"`
+	BillingState    string    `db:"billing_state" bson:"billing_state"`
+	BillingZipCode  string    `db:"billing_zip_code" bson:"billing_zip_code"`
+	BillingCountry  string    `db:"billing_country" bson:"billing_country"`
+	PersonalPhone   string    `db:"personal_phone" bson:"personal_phone"`
+	BusinessPhone   string    `db:"business_phone" bson:"business_phone"`
+	PersonalEmail   string    `db:"personal_email" bson:"personal_email"`
+	BusinessEmail   string    `db:"business_email" bson:"business_email"`
+	Region          *int      `db:"region" bson:"region"`
+	CreatedBy       string    `db:"created_by" bson:"created_by"`
+	CreatedAt       time.Time `db:"created_at" bson:"created_at"`
+	UpdatedBy       string    `db:"updated_by" bson:"updated_by"`
+	UpdatedAt       time.Time `db:"updated_at" bson:"updated_at"`
+	Active          bool      `db:"active" bson:"active"`
+	Description     *string   `db:"description" bson:"description"`
 }
 
 func mapLeadToLeadDTO(lead domain.Lead) LeadDTO {
@@ -2,13 +2,14 @@ package database
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/icrxz/crm-api-core/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+var leadSortableFields = []string{"created_at", "updated_at", "first_name", "company_name", "shipping_state"}
+
 type leadRepository struct {
 	client *mongo.Client
 }
@@ -52,7 +53,7 @@ func (db *leadRepository) GetByID(ctx context.Context, leadID string) (*domain.L
 	var leadDTO LeadDTO
 	err := db.leadCollection(ctx).FindOne(context.TODO(), bson.D{{"_id", leadID}}).Decode(&leadDTO)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, domain.NewNotFoundError("no lead found with this id", map[string]any{"lead_id": leadID})
 		}
 		return nil, err
@@ -64,37 +65,31 @@ func (db *leadRepository) GetByID(ctx context.Context, leadID string) (*domain.L
 }
 
 func (db *leadRepository) Search(ctx context.Context, filters domain.LeadFilters) (domain.PagingResult[domain.Lead], error) {
-
 	filter := bson.M{}
 	if len(filters.LeadID) > 0 {
-		filter["_id"] = filters.LeadID
+		filter["_id"] = inFilter(filters.LeadID)
 	}
 	if len(filters.Document) > 0 {
-		filter["document"] = filters.Document
+		filter["document"] = inFilter(filters.Document)
 	}
 	if len(filters.LeadType) > 0 {
-		filter["lead_type"] = filters.LeadType
+		filter["lead_type"] = inFilter(filters.LeadType)
 	}
 	if len(filters.State) > 0 {
-		filter["shipping_state"] = filters.State
+		filter["shipping_state"] = inFilter(filters.State)
 	}
-
-	cursor, err := db.leadCollection(ctx).Find(ctx, filter)
-
-	var results []LeadDTO
-	if err = cursor.All(context.TODO(), &results); err != nil {
-		panic(err)
+	if filters.Active != nil {
+		filter["active"] = *filters.Active
 	}
 
-	leads := mapLeadDTOsToLeads(results)
+	results, paging, err := searchCollection[LeadDTO](ctx, db.leadCollection(ctx), filter, filters.PagingFilter, leadSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Lead]{}, err
+	}
 
 	result := domain.PagingResult[domain.Lead]{
-		Result: leads,
-		Paging: domain.Paging{
-			Total:  len(results),
-			Limit:  filters.Limit,
-			Offset: filters.Offset,
-		},
+		Result: mapLeadDTOsToLeads(results),
+		Paging: paging,
 	}
 
 	return result, nil
@@ -107,10 +102,13 @@ func (db *leadRepository) Update(ctx context.Context, lead domain.Lead) error {
 		"_id": leadDTO.LeadID,
 	}
 
-	result := db.leadCollection(ctx).FindOneAndUpdate(ctx, filter, leadDTO)
+	result, err := db.leadCollection(ctx).ReplaceOne(ctx, filter, leadDTO)
+	if err != nil {
+		return err
+	}
 
-	if result.Err() != nil {
-		return result.Err()
+	if result.MatchedCount == 0 {
+		return domain.NewNotFoundError("no lead found with this id", map[string]any{"lead_id": lead.LeadID})
 	}
 
 	return nil
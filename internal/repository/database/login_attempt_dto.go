@@ -0,0 +1,77 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type LoginAttemptDTO struct {
+	Key           string     `db:"key" bson:"_id"`
+	Failures      int        `db:"failures" bson:"failures"`
+	LockCount     int        `db:"lock_count" bson:"lock_count"`
+	LockedUntil   *time.Time `db:"locked_until" bson:"locked_until"`
+	LastFailureAt time.Time  `db:"last_failure_at" bson:"last_failure_at"`
+	UpdatedAt     time.Time  `db:"updated_at" bson:"updated_at"`
+	ExpiresAt     time.Time  `db:"expires_at" bson:"expires_at"`
+}
+
+type UserLockEventDTO struct {
+	EventID     string     `db:"event_id" bson:"_id"`
+	UserID      string     `db:"user_id" bson:"user_id"`
+	TenantID    string     `db:"tenant_id" bson:"tenant_id"`
+	Type        string     `db:"type" bson:"type"`
+	Reason      string     `db:"reason" bson:"reason"`
+	IPAddress   string     `db:"ip_address" bson:"ip_address"`
+	LockedUntil *time.Time `db:"locked_until" bson:"locked_until"`
+	CreatedAt   time.Time  `db:"created_at" bson:"created_at"`
+	CreatedBy   string     `db:"created_by" bson:"created_by"`
+}
+
+func mapLoginAttemptDTOToLoginAttempt(attemptDTO LoginAttemptDTO) domain.LoginAttempt {
+	return domain.LoginAttempt{
+		Key:           attemptDTO.Key,
+		Failures:      attemptDTO.Failures,
+		LockCount:     attemptDTO.LockCount,
+		LockedUntil:   attemptDTO.LockedUntil,
+		LastFailureAt: attemptDTO.LastFailureAt,
+		UpdatedAt:     attemptDTO.UpdatedAt,
+		ExpiresAt:     attemptDTO.ExpiresAt,
+	}
+}
+
+func mapUserLockEventToUserLockEventDTO(event domain.UserLockEvent) UserLockEventDTO {
+	return UserLockEventDTO{
+		EventID:     event.EventID,
+		UserID:      event.UserID,
+		TenantID:    event.TenantID,
+		Type:        string(event.Type),
+		Reason:      event.Reason,
+		IPAddress:   event.IPAddress,
+		LockedUntil: event.LockedUntil,
+		CreatedAt:   event.CreatedAt,
+		CreatedBy:   event.CreatedBy,
+	}
+}
+
+func mapUserLockEventDTOToUserLockEvent(eventDTO UserLockEventDTO) domain.UserLockEvent {
+	return domain.UserLockEvent{
+		EventID:     eventDTO.EventID,
+		UserID:      eventDTO.UserID,
+		TenantID:    eventDTO.TenantID,
+		Type:        domain.LockEventType(eventDTO.Type),
+		Reason:      eventDTO.Reason,
+		IPAddress:   eventDTO.IPAddress,
+		LockedUntil: eventDTO.LockedUntil,
+		CreatedAt:   eventDTO.CreatedAt,
+		CreatedBy:   eventDTO.CreatedBy,
+	}
+}
+
+func mapUserLockEventDTOsToUserLockEvents(eventDTOs []UserLockEventDTO) []domain.UserLockEvent {
+	events := make([]domain.UserLockEvent, 0, len(eventDTOs))
+	for _, eventDTO := range eventDTOs {
+		events = append(events, mapUserLockEventDTOToUserLockEvent(eventDTO))
+	}
+	return events
+}
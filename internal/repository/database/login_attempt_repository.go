@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type loginAttemptRepository struct {
+	client *mongo.Client
+}
+
+func NewLoginAttemptRepository(client *mongo.Client) domain.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		client: client,
+	}
+}
+
+// EnsureLoginAttemptIndexes creates the TTL index that lets MongoDB remove attempts once they expire.
+func EnsureLoginAttemptIndexes(ctx context.Context, client *mongo.Client) error {
+	_, err := GetCollection(client, "login_attempts").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+func (r *loginAttemptRepository) loginAttemptCollection(ctx context.Context) *mongo.Collection {
+	loginAttemptCollection := GetCollection(r.client, "login_attempts")
+	return loginAttemptCollection
+}
+
+func (r *loginAttemptRepository) Get(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	var attemptDTO LoginAttemptDTO
+	filter := bson.M{"_id": key, "expires_at": bson.M{"$gt": time.Now().UTC()}}
+	err := r.loginAttemptCollection(ctx).FindOne(ctx, filter).Decode(&attemptDTO)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.NewNotFoundError("no login attempt found with this key", map[string]any{"key": key})
+		}
+		return nil, err
+	}
+
+	attempt := mapLoginAttemptDTOToLoginAttempt(attemptDTO)
+	return &attempt, nil
+}
+
+func (r *loginAttemptRepository) IncrementFailures(ctx context.Context, key string, failedAt, expiresAt time.Time) (*domain.LoginAttempt, error) {
+	// the TTL monitor only runs every minute, so an expired record still around is dropped first
+	_, err := r.loginAttemptCollection(ctx).DeleteOne(ctx, bson.M{"_id": key, "expires_at": bson.M{"$lte": failedAt}})
+	if err != nil {
+		return nil, err
+	}
+
+	var attemptDTO LoginAttemptDTO
+	err = r.loginAttemptCollection(ctx).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": key},
+		bson.M{
+			"$inc": bson.M{"failures": 1},
+			"$set": bson.M{"last_failure_at": failedAt, "updated_at": failedAt, "expires_at": expiresAt},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&attemptDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := mapLoginAttemptDTOToLoginAttempt(attemptDTO)
+	return &attempt, nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, minFailures int, lockedUntil time.Time) (bool, error) {
+	result, err := r.loginAttemptCollection(ctx).UpdateOne(
+		ctx,
+		bson.M{"_id": key, "failures": bson.M{"$gte": minFailures}},
+		bson.M{
+			"$inc": bson.M{"lock_count": 1},
+			"$set": bson.M{"failures": 0, "locked_until": lockedUntil, "updated_at": time.Now().UTC()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *loginAttemptRepository) ResetFailures(ctx context.Context, key string) error {
+	_, err := r.loginAttemptCollection(ctx).UpdateOne(
+		ctx,
+		bson.M{"_id": key, "failures": bson.M{"$gt": 0}},
+		bson.M{"$set": bson.M{"failures": 0, "updated_at": time.Now().UTC()}},
+	)
+	return err
+}
+
+func (r *loginAttemptRepository) Delete(ctx context.Context, key string) error {
+	_, err := r.loginAttemptCollection(ctx).DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type NotificationDTO struct {
+	NotificationID string     `db:"notification_id" bson:"_id"`
+	TenantID       string     `db:"tenant_id" bson:"tenant_id"`
+	UserID         string     `db:"user_id" bson:"user_id"`
+	Type           string     `db:"type" bson:"type"`
+	TicketID       string     `db:"ticket_id" bson:"ticket_id"`
+	CommentID      string     `db:"comment_id" bson:"comment_id"`
+	Message        string     `db:"message" bson:"message"`
+	CreatedBy      string     `db:"created_by" bson:"created_by"`
+	CreatedAt      time.Time  `db:"created_at" bson:"created_at"`
+	ReadAt         *time.Time `db:"read_at" bson:"read_at"`
+}
+
+func mapNotificationToNotificationDTO(notification domain.Notification) NotificationDTO {
+	return NotificationDTO{
+		NotificationID: notification.NotificationID,
+		TenantID:       notification.TenantID,
+		UserID:         notification.UserID,
+		Type:           string(notification.Type),
+		TicketID:       notification.TicketID,
+		CommentID:      notification.CommentID,
+		Message:        notification.Message,
+		CreatedBy:      notification.CreatedBy,
+		CreatedAt:      notification.CreatedAt,
+		ReadAt:         notification.ReadAt,
+	}
+}
+
+func mapNotificationDTOToNotification(notificationDTO NotificationDTO) domain.Notification {
+	return domain.Notification{
+		NotificationID: notificationDTO.NotificationID,
+		TenantID:       notificationDTO.TenantID,
+		UserID:         notificationDTO.UserID,
+		Type:           domain.NotificationType(notificationDTO.Type),
+		TicketID:       notificationDTO.TicketID,
+		CommentID:      notificationDTO.CommentID,
+		Message:        notificationDTO.Message,
+		CreatedBy:      notificationDTO.CreatedBy,
+		CreatedAt:      notificationDTO.CreatedAt,
+		ReadAt:         notificationDTO.ReadAt,
+	}
+}
+
+func mapNotificationDTOsToNotifications(notificationDTOs []NotificationDTO) []domain.Notification {
+	notifications := make([]domain.Notification, 0, len(notificationDTOs))
+	for _, notificationDTO := range notificationDTOs {
+		notifications = append(notifications, mapNotificationDTOToNotification(notificationDTO))
+	}
+	return notifications
+}
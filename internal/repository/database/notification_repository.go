@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var notificationSortableFields = []string{"created_at", "read_at", "type"}
+
+type notificationRepository struct {
+	client *mongo.Client
+}
+
+func NewNotificationRepository(client *mongo.Client) domain.NotificationRepository {
+	return &notificationRepository{
+		client: client,
+	}
+}
+
+func (r *notificationRepository) notificationCollection(ctx context.Context) *mongo.Collection {
+	notificationCollection := GetCollection(r.client, "notifications")
+	return notificationCollection
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification domain.Notification) (string, error) {
+	notificationDTO := mapNotificationToNotificationDTO(notification)
+
+	_, err := r.notificationCollection(ctx).InsertOne(ctx, notificationDTO)
+	if err != nil {
+		return "", err
+	}
+
+	return notification.NotificationID, nil
+}
+
+func (r *notificationRepository) Search(ctx context.Context, filters domain.NotificationFilters) (domain.PagingResult[domain.Notification], error) {
+	filter := bson.M{}
+	if filters.UserID != "" {
+		filter["user_id"] = filters.UserID
+	}
+	if len(filters.TicketID) > 0 {
+		filter["ticket_id"] = inFilter(filters.TicketID)
+	}
+	if len(filters.Type) > 0 {
+		filter["type"] = inFilter(filters.Type)
+	}
+	if filters.Read != nil {
+		if *filters.Read {
+			filter["read_at"] = bson.M{"$ne": nil}
+		} else {
+			filter["read_at"] = nil
+		}
+	}
+
+	filter = withTenantScope(ctx, filter, "tenant_id")
+
+	notificationDTOs, paging, err := searchCollection[NotificationDTO](ctx, r.notificationCollection(ctx), filter, filters.PagingFilter, notificationSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Notification]{}, err
+	}
+
+	result := domain.PagingResult[domain.Notification]{
+		Result: mapNotificationDTOsToNotifications(notificationDTOs),
+		Paging: paging,
+	}
+
+	return result, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID string, notificationIDs []string, readAt time.Time) (int, error) {
+	filter := bson.M{"user_id": userID, "read_at": nil}
+	if len(notificationIDs) > 0 {
+		filter["_id"] = inFilter(notificationIDs)
+	}
+	filter = withTenantScope(ctx, filter, "tenant_id")
+
+	result, err := r.notificationCollection(ctx).UpdateMany(ctx, filter, bson.M{"$set": bson.M{"read_at": readAt}})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.ModifiedCount), nil
+}
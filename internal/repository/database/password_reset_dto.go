@@ -0,0 +1,38 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type PasswordResetTokenDTO struct {
+	TokenID   string     `db:"token_id" bson:"_id"`
+	UserID    string     `db:"user_id" bson:"user_id"`
+	TokenHash string     `db:"token_hash" bson:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at" bson:"expires_at"`
+	UsedAt    *time.Time `db:"used_at" bson:"used_at"`
+	CreatedAt time.Time  `db:"created_at" bson:"created_at"`
+}
+
+func mapPasswordResetTokenToPasswordResetTokenDTO(resetToken domain.PasswordResetToken) PasswordResetTokenDTO {
+	return PasswordResetTokenDTO{
+		TokenID:   resetToken.TokenID,
+		UserID:    resetToken.UserID,
+		TokenHash: resetToken.TokenHash,
+		ExpiresAt: resetToken.ExpiresAt,
+		UsedAt:    resetToken.UsedAt,
+		CreatedAt: resetToken.CreatedAt,
+	}
+}
+
+func mapPasswordResetTokenDTOToPasswordResetToken(resetTokenDTO PasswordResetTokenDTO) domain.PasswordResetToken {
+	return domain.PasswordResetToken{
+		TokenID:   resetTokenDTO.TokenID,
+		UserID:    resetTokenDTO.UserID,
+		TokenHash: resetTokenDTO.TokenHash,
+		ExpiresAt: resetTokenDTO.ExpiresAt,
+		UsedAt:    resetTokenDTO.UsedAt,
+		CreatedAt: resetTokenDTO.CreatedAt,
+	}
+}
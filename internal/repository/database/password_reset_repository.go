@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type passwordResetRepository struct {
+	client *mongo.Client
+}
+
+func NewPasswordResetRepository(client *mongo.Client) domain.PasswordResetRepository {
+	return &passwordResetRepository{
+		client: client,
+	}
+}
+
+func (r *passwordResetRepository) passwordResetCollection(ctx context.Context) *mongo.Collection {
+	passwordResetCollection := GetCollection(r.client, "password_resets")
+	return passwordResetCollection
+}
+
+func (r *passwordResetRepository) Create(ctx context.Context, resetToken domain.PasswordResetToken) (string, error) {
+	resetTokenDTO := mapPasswordResetTokenToPasswordResetTokenDTO(resetToken)
+
+	_, err := r.passwordResetCollection(ctx).InsertOne(ctx, resetTokenDTO)
+	if err != nil {
+		return "", err
+	}
+
+	return resetToken.TokenID, nil
+}
+
+func (r *passwordResetRepository) GetByID(ctx context.Context, tokenID string) (*domain.PasswordResetToken, error) {
+	var resetTokenDTO PasswordResetTokenDTO
+	err := r.passwordResetCollection(ctx).FindOne(ctx, bson.M{"_id": tokenID}).Decode(&resetTokenDTO)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.NewNotFoundError("no reset token found with this id", map[string]any{"token_id": tokenID})
+		}
+		return nil, err
+	}
+
+	resetToken := mapPasswordResetTokenDTOToPasswordResetToken(resetTokenDTO)
+	return &resetToken, nil
+}
+
+// MarkUsed only matches tokens without used_at, so concurrent resets can't consume the same token twice.
+func (r *passwordResetRepository) MarkUsed(ctx context.Context, tokenID string, usedAt time.Time) (bool, error) {
+	result, err := r.passwordResetCollection(ctx).UpdateOne(
+		ctx,
+		bson.M{"_id": tokenID, "used_at": nil},
+		bson.M{"$set": bson.M{"used_at": usedAt}},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
@@ -7,22 +7,24 @@ import (
 )
 
 type ProductDTO struct {
-	ProductID    string    `db:"product_id"`
-	Name         string    `db:"name"`
-	Description  string    `db:"description"`
-	Brand        string    `db:"brand"`
-	Model        string    `db:"model"`
-	Value        float64   `db:"value"`
-	SerialNumber string    `db:"serial_number"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
-	CreatedBy    string    `db:"created_by"`
-	UpdatedBy    string    `db:"updated_by"`
+	ProductID    string    `db:"product_id" bson:"_id"`
+	TenantID     string    `db:"tenant_id" bson:"tenant_id"`
+	Name         string    `db:"name" bson:"name"`
+	Description  string    `db:"description" bson:"description"`
+	Brand        string    `db:"brand" bson:"brand"`
+	Model        string    `db:"model" bson:"model"`
+	Value        float64   `db:"value" bson:"value"`
+	SerialNumber string    `db:"serial_number" bson:"serial_number"`
+	CreatedAt    time.Time `db:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at" bson:"updated_at"`
+	CreatedBy    string    `db:"created_by" bson:"created_by"`
+	UpdatedBy    string    `db:"updated_by" bson:"updated_by"`
 }
 
 func mapProductToProductDTO(product domain.Product) ProductDTO {
 	return ProductDTO{
 		ProductID:    product.ProductID,
+		TenantID:     product.TenantID,
 		Name:         product.Name,
 		Description:  product.Description,
 		Brand:        product.Brand,
@@ -39,6 +41,7 @@ func mapProductToProductDTO(product domain.Product) ProductDTO {
 func mapProductDTOToProduct(productDTO ProductDTO) domain.Product {
 	return domain.Product{
 		ProductID:    productDTO.ProductID,
+		TenantID:     productDTO.TenantID,
 		Name:         productDTO.Name,
 		Description:  productDTO.Description,
 		Brand:        productDTO.Brand,
@@ -2,7 +2,6 @@ package database
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -21,7 +20,7 @@ func NewProductRepository(client *mongo.Client) domain.ProductRepository {
 }
 
 func (db *productRepository) productCollection(ctx context.Context) *mongo.Collection {
-	productCollection := GetCollection(db.client, "user")
+	productCollection := GetCollection(db.client, "products")
 	return productCollection
 }
 
@@ -43,9 +42,9 @@ func (r *productRepository) GetProductByID(ctx context.Context, productID string
 	}
 
 	var productDTO ProductDTO
-	err := r.productCollection(ctx).FindOne(context.TODO(), bson.D{{"_id", productID}}).Decode(&productDTO)
+	err := r.productCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": productID}, "tenant_id")).Decode(&productDTO)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, domain.NewNotFoundError("no product found with this id", map[string]any{"product_id": productID})
 		}
 		return nil, err
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ReportDTO struct {
+	ReportID       string    `db:"report_id" bson:"_id"`
+	TenantID       string    `db:"tenant_id" bson:"tenant_id"`
+	ReportName     string    `db:"report_name" bson:"report_name"`
+	ReportTemplate string    `db:"report_template" bson:"report_template"`
+	FileName       string    `db:"file_name" bson:"file_name"`
+	Size           int       `db:"size" bson:"size"`
+	Version        int       `db:"version" bson:"version"`
+	Active         bool      `db:"active" bson:"active"`
+	CreatedBy      string    `db:"created_by" bson:"created_by"`
+	CreatedAt      time.Time `db:"created_at" bson:"created_at"`
+	UpdatedBy      string    `db:"updated_by" bson:"updated_by"`
+	UpdatedAt      time.Time `db:"updated_at" bson:"updated_at"`
+}
+
+func mapReportToReportDTO(report domain.Report) ReportDTO {
+	return ReportDTO{
+		ReportID:       report.ReportID,
+		TenantID:       report.TenantID,
+		ReportName:     report.ReportName,
+		ReportTemplate: report.ReportTemplate,
+		FileName:       report.FileName,
+		Size:           report.Size,
+		Version:        report.Version,
+		Active:         report.Active,
+		CreatedBy:      report.CreatedBy,
+		CreatedAt:      report.CreatedAt,
+		UpdatedBy:      report.UpdatedBy,
+		UpdatedAt:      report.UpdatedAt,
+	}
+}
+
+func mapReportDTOToReport(reportDTO ReportDTO) domain.Report {
+	return domain.Report{
+		ReportID:       reportDTO.ReportID,
+		TenantID:       reportDTO.TenantID,
+		ReportName:     reportDTO.ReportName,
+		ReportTemplate: reportDTO.ReportTemplate,
+		FileName:       reportDTO.FileName,
+		Size:           reportDTO.Size,
+		Version:        reportDTO.Version,
+		Active:         reportDTO.Active,
+		CreatedBy:      reportDTO.CreatedBy,
+		CreatedAt:      reportDTO.CreatedAt,
+		UpdatedBy:      reportDTO.UpdatedBy,
+		UpdatedAt:      reportDTO.UpdatedAt,
+	}
+}
+
+func mapReportDTOsToReports(reportDTOs []ReportDTO) []domain.Report {
+	reports := make([]domain.Report, 0, len(reportDTOs))
+	for _, reportDTO := range reportDTOs {
+		reports = append(reports, mapReportDTOToReport(reportDTO))
+	}
+	return reports
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type ReportJobDTO struct {
+	JobID       string     `db:"job_id" bson:"_id"`
+	TenantID    string     `db:"tenant_id" bson:"tenant_id"`
+	TicketID    string     `db:"ticket_id" bson:"ticket_id"`
+	Format      string     `db:"format" bson:"format"`
+	Status      string     `db:"status" bson:"status"`
+	Version     int        `db:"version" bson:"version"`
+	ArtifactKey string     `db:"artifact_key" bson:"artifact_key"`
+	FileName    string     `db:"file_name" bson:"file_name"`
+	Size        int        `db:"size" bson:"size"`
+	Error       string     `db:"error" bson:"error"`
+	CreatedBy   string     `db:"created_by" bson:"created_by"`
+	CreatedAt   time.Time  `db:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at" bson:"updated_at"`
+	CompletedAt *time.Time `db:"completed_at" bson:"completed_at"`
+}
+
+func mapReportJobToReportJobDTO(job domain.ReportJob) ReportJobDTO {
+	return ReportJobDTO{
+		JobID:       job.JobID,
+		TenantID:    job.TenantID,
+		TicketID:    job.TicketID,
+		Format:      string(job.Format),
+		Status:      string(job.Status),
+		Version:     job.Version,
+		ArtifactKey: job.ArtifactKey,
+		FileName:    job.FileName,
+		Size:        job.Size,
+		Error:       job.Error,
+		CreatedBy:   job.CreatedBy,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		CompletedAt: job.CompletedAt,
+	}
+}
+
+func mapReportJobDTOToReportJob(jobDTO ReportJobDTO) domain.ReportJob {
+	return domain.ReportJob{
+		JobID:       jobDTO.JobID,
+		TenantID:    jobDTO.TenantID,
+		TicketID:    jobDTO.TicketID,
+		Format:      domain.ReportFormat(jobDTO.Format),
+		Status:      domain.ReportJobStatus(jobDTO.Status),
+		Version:     jobDTO.Version,
+		ArtifactKey: jobDTO.ArtifactKey,
+		FileName:    jobDTO.FileName,
+		Size:        jobDTO.Size,
+		Error:       jobDTO.Error,
+		CreatedBy:   jobDTO.CreatedBy,
+		CreatedAt:   jobDTO.CreatedAt,
+		UpdatedAt:   jobDTO.UpdatedAt,
+		CompletedAt: jobDTO.CompletedAt,
+	}
+}
+
+func mapReportJobDTOsToReportJobs(jobDTOs []ReportJobDTO) []domain.ReportJob {
+	jobs := make([]domain.ReportJob, 0, len(jobDTOs))
+	for _, jobDTO := range jobDTOs {
+		jobs = append(jobs, mapReportJobDTOToReportJob(jobDTO))
+	}
+	return jobs
+}
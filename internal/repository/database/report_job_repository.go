@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var reportJobSortableFields = []string{"created_at", "updated_at", "version"}
+
+type reportJobRepository struct {
+	client *mongo.Client
+}
+
+func NewReportJobRepository(client *mongo.Client) domain.ReportJobRepository {
+	return &reportJobRepository{
+		client: client,
+	}
+}
+
+func (r *reportJobRepository) reportJobCollection(ctx context.Context) *mongo.Collection {
+	reportJobCollection := GetCollection(r.client, "report_jobs")
+	return reportJobCollection
+}
+
+func (r *reportJobRepository) Create(ctx context.Context, job domain.ReportJob) (string, error) {
+	jobDTO := mapReportJobToReportJobDTO(job)
+
+	_, err := r.reportJobCollection(ctx).InsertOne(ctx, jobDTO)
+	if err != nil {
+		return "", err
+	}
+
+	return job.JobID, nil
+}
+
+func (r *reportJobRepository) GetByID(ctx context.Context, jobID string) (*domain.ReportJob, error) {
+	var jobDTO ReportJobDTO
+	err := r.reportJobCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": jobID}, "tenant_id")).Decode(&jobDTO)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.NewNotFoundError("no report job found with this id", map[string]any{"job_id": jobID})
+		}
+		return nil, err
+	}
+
+	job := mapReportJobDTOToReportJob(jobDTO)
+	return &job, nil
+}
+
+func (r *reportJobRepository) Update(ctx context.Context, job domain.ReportJob) error {
+	jobDTO := mapReportJobToReportJobDTO(job)
+	filter := withTenantScope(ctx, bson.M{"_id": job.JobID}, "tenant_id")
+
+	result, err := r.reportJobCollection(ctx).ReplaceOne(ctx, filter, jobDTO)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.NewNotFoundError("no report job found with this id", map[string]any{"job_id": job.JobID})
+	}
+
+	return nil
+}
+
+func (r *reportJobRepository) Search(ctx context.Context, filters domain.ReportJobFilters) (domain.PagingResult[domain.ReportJob], error) {
+	filter := bson.M{}
+	if len(filters.JobID) > 0 {
+		filter["_id"] = inFilter(filters.JobID)
+	}
+	if len(filters.TenantID) > 0 {
+		filter["tenant_id"] = inFilter(filters.TenantID)
+	}
+	if len(filters.TicketID) > 0 {
+		filter["ticket_id"] = inFilter(filters.TicketID)
+	}
+	if len(filters.Status) > 0 {
+		filter["status"] = inFilter(filters.Status)
+	}
+	filter = withTenantScope(ctx, filter, "tenant_id")
+
+	jobResults, paging, err := searchCollection[ReportJobDTO](ctx, r.reportJobCollection(ctx), filter, filters.PagingFilter, reportJobSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.ReportJob]{}, err
+	}
+
+	return domain.PagingResult[domain.ReportJob]{
+		Result: mapReportJobDTOsToReportJobs(jobResults),
+		Paging: paging,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var reportSortableFields = []string{"created_at", "updated_at", "report_name", "version"}
+
+type reportRepository struct {
+	client *mongo.Client
+}
+
+func NewReportRepository(client *mongo.Client) domain.ReportRepository {
+	return &reportRepository{
+		client: client,
+	}
+}
+
+func (r *reportRepository) reportCollection(ctx context.Context) *mongo.Collection {
+	reportCollection := GetCollection(r.client, "reports")
+	return reportCollection
+}
+
+func (r *reportRepository) Create(ctx context.Context, report domain.Report) (string, error) {
+	reportDTO := mapReportToReportDTO(report)
+
+	_, err := r.reportCollection(ctx).InsertOne(ctx, reportDTO)
+	if err != nil {
+		return "", err
+	}
+
+	return report.ReportID, nil
+}
+
+func (r *reportRepository) GetByID(ctx context.Context, reportID string) (*domain.Report, error) {
+	var reportDTO ReportDTO
+	err := r.reportCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": reportID}, "tenant_id")).Decode(&reportDTO)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.NewNotFoundError("no report found with this id", map[string]any{"report_id": reportID})
+		}
+		return nil, err
+	}
+
+	report := mapReportDTOToReport(reportDTO)
+	return &report, nil
+}
+
+func (r *reportRepository) Update(ctx context.Context, report domain.Report) error {
+	reportDTO := mapReportToReportDTO(report)
+	filter := withTenantScope(ctx, bson.M{"_id": report.ReportID}, "tenant_id")
+
+	result, err := r.reportCollection(ctx).ReplaceOne(ctx, filter, reportDTO)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.NewNotFoundError("no report found with this id", map[string]any{"report_id": report.ReportID})
+	}
+
+	return nil
+}
+
+func (r *reportRepository) Search(ctx context.Context, filters domain.ReportFilters) (domain.PagingResult[domain.Report], error) {
+	filter := bson.M{}
+	if len(filters.ReportID) > 0 {
+		filter["_id"] = inFilter(filters.ReportID)
+	}
+	if len(filters.TenantID) > 0 {
+		filter["tenant_id"] = inFilter(filters.TenantID)
+	}
+	if len(filters.ReportName) > 0 {
+		filter["report_name"] = inFilter(filters.ReportName)
+	}
+	if filters.Active != nil {
+		filter["active"] = *filters.Active
+	}
+	filter = withTenantScope(ctx, filter, "tenant_id")
+
+	reportResults, paging, err := searchCollection[ReportDTO](ctx, r.reportCollection(ctx), filter, filters.PagingFilter, reportSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Report]{}, err
+	}
+
+	return domain.PagingResult[domain.Report]{
+		Result: mapReportDTOsToReports(reportResults),
+		Paging: paging,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type SessionDTO struct {
+	SessionID        string     `db:"session_id" bson:"_id"`
+	UserID           string     `db:"user_id" bson:"user_id"`
+	RefreshTokenHash string     `db:"refresh_token_hash" bson:"refresh_token_hash"`
+	ExpiresAt        time.Time  `db:"expires_at" bson:"expires_at"`
+	RevokedAt        *time.Time `db:"revoked_at" bson:"revoked_at"`
+	CreatedAt        time.Time  `db:"created_at" bson:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at" bson:"updated_at"`
+}
+
+func mapSessionToSessionDTO(session domain.Session) SessionDTO {
+	return SessionDTO{
+		SessionID:        session.SessionID,
+		UserID:           session.UserID,
+		RefreshTokenHash: session.RefreshTokenHash,
+		ExpiresAt:        session.ExpiresAt,
+		RevokedAt:        session.RevokedAt,
+		CreatedAt:        session.CreatedAt,
+		UpdatedAt:        session.UpdatedAt,
+	}
+}
+
+func mapSessionDTOToSession(sessionDTO SessionDTO) domain.Session {
+	return domain.Session{
+		SessionID:        sessionDTO.SessionID,
+		UserID:           sessionDTO.UserID,
+		RefreshTokenHash: sessionDTO.RefreshTokenHash,
+		ExpiresAt:        sessionDTO.ExpiresAt,
+		RevokedAt:        sessionDTO.RevokedAt,
+		CreatedAt:        sessionDTO.CreatedAt,
+		UpdatedAt:        sessionDTO.UpdatedAt,
+	}
+}
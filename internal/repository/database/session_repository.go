@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type sessionRepository struct {
+	client *mongo.Client
+}
+
+func NewSessionRepository(client *mongo.Client) domain.SessionRepository {
+	return &sessionRepository{
+		client: client,
+	}
+}
+
+func (r *sessionRepository) sessionCollection(ctx context.Context) *mongo.Collection {
+	sessionCollection := GetCollection(r.client, "sessions")
+	return sessionCollection
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session domain.Session) (string, error) {
+	sessionDTO := mapSessionToSessionDTO(session)
+
+	_, err := r.sessionCollection(ctx).InsertOne(ctx, sessionDTO)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID, nil
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, sessionID string) (*domain.Session, error) {
+	var sessionDTO SessionDTO
+	err := r.sessionCollection(ctx).FindOne(ctx, bson.M{"_id": sessionID}).Decode(&sessionDTO)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.NewNotFoundError("no session found with this id", map[string]any{"session_id": sessionID})
+		}
+		return nil, err
+	}
+
+	session := mapSessionDTOToSession(sessionDTO)
+	return &session, nil
+}
+
+func (r *sessionRepository) Update(ctx context.Context, session domain.Session) error {
+	sessionDTO := mapSessionToSessionDTO(session)
+
+	_, err := r.sessionCollection(ctx).ReplaceOne(ctx, bson.M{"_id": session.SessionID}, sessionDTO)
+	return err
+}
+
+func (r *sessionRepository) RevokeByUserID(ctx context.Context, userID string, revokedAt time.Time) error {
+	filter := bson.M{
+		"user_id":    userID,
+		"revoked_at": nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		},
+	}
+
+	_, err := r.sessionCollection(ctx).UpdateMany(ctx, filter, update)
+	return err
+}
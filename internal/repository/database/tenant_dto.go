@@ -7,34 +7,40 @@ import (
 )
 
 type TenantDTO struct {
-	TenantID      string    `db:"tenant_id"`
-	CompanyName   string    `db:"company_name"`
-	LegalName     string    `db:"legal_name"`
-	Document      string    `db:"document"`
-	DocumentType  string    `db:"document_type"`
-	BusinessPhone string    `db:"business_phone"`
-	BusinessEmail string    `db:"business_email"`
-	CreatedBy     string    `db:"created_by"`
-	CreatedAt     time.Time `db:"created_at"`
-	UpdatedBy     string    `db:"updated_by"`
-	UpdatedAt     time.Time `db:"updated_at"`
-	Active        bool      `db:"active"`
+	TenantID                 string    `db:"tenant_id" bson:"_id"`
+	CompanyName              string    `db:"company_name" bson:"company_name"`
+	LegalName                string    `db:"legal_name" bson:"legal_name"`
+	Document                 string    `db:"document" bson:"document"`
+	DocumentType             string    `db:"document_type" bson:"document_type"`
+	BusinessPhone            string    `db:"business_phone" bson:"business_phone"`
+	BusinessEmail            string    `db:"business_email" bson:"business_email"`
+	AllowedContentTypes      []string  `db:"allowed_content_types" bson:"allowed_content_types"`
+	MaxAttachmentSize        int       `db:"max_attachment_size" bson:"max_attachment_size"`
+	MaxTicketAttachmentsSize int       `db:"max_ticket_attachments_size" bson:"max_ticket_attachments_size"`
+	CreatedBy                string    `db:"created_by" bson:"created_by"`
+	CreatedAt                time.Time `db:"created_at" bson:"created_at"`
+	UpdatedBy                string    `db:"updated_by" bson:"updated_by"`
+	UpdatedAt                time.Time `db:"updated_at" bson:"updated_at"`
+	Active                   bool      `db:"active" bson:"active"`
 }
 
 func mapTenantToTenantDTO(tenant domain.Tenant) TenantDTO {
 	return TenantDTO{
-		TenantID:      tenant.TenantID,
-		CompanyName:   tenant.CompanyName,
-		LegalName:     tenant.LegalName,
-		Document:      tenant.Document,
-		DocumentType:  string(tenant.DocumentType),
-		BusinessPhone: tenant.BusinessContact.PhoneNumber,
-		BusinessEmail: tenant.BusinessContact.Email,
-		CreatedBy:     tenant.CreatedBy,
-		CreatedAt:     tenant.CreatedAt,
-		UpdatedBy:     tenant.UpdatedBy,
-		UpdatedAt:     tenant.UpdatedAt,
-		Active:        tenant.Active,
+		TenantID:                 tenant.TenantID,
+		CompanyName:              tenant.CompanyName,
+		LegalName:                tenant.LegalName,
+		Document:                 tenant.Document,
+		DocumentType:             string(tenant.DocumentType),
+		BusinessPhone:            tenant.BusinessContact.PhoneNumber,
+		BusinessEmail:            tenant.BusinessContact.Email,
+		AllowedContentTypes:      tenant.AttachmentPolicy.AllowedContentTypes,
+		MaxAttachmentSize:        tenant.AttachmentPolicy.MaxFileSize,
+		MaxTicketAttachmentsSize: tenant.AttachmentPolicy.MaxTicketSize,
+		CreatedBy:                tenant.CreatedBy,
+		CreatedAt:                tenant.CreatedAt,
+		UpdatedBy:                tenant.UpdatedBy,
+		UpdatedAt:                tenant.UpdatedAt,
+		Active:                   tenant.Active,
 	}
 }
 
@@ -49,6 +55,11 @@ func mapTenantDTOToTenant(tenantDTO TenantDTO) domain.Tenant {
 			PhoneNumber: tenantDTO.BusinessPhone,
 			Email:       tenantDTO.BusinessEmail,
 		},
+		AttachmentPolicy: domain.AttachmentPolicy{
+			AllowedContentTypes: tenantDTO.AllowedContentTypes,
+			MaxFileSize:         tenantDTO.MaxAttachmentSize,
+			MaxTicketSize:       tenantDTO.MaxTicketAttachmentsSize,
+		},
 		CreatedBy: tenantDTO.CreatedBy,
 		CreatedAt: tenantDTO.CreatedAt,
 		UpdatedBy: tenantDTO.UpdatedBy,
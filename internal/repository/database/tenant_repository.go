@@ -2,13 +2,14 @@ package database
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/icrxz/crm-api-core/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+var tenantSortableFields = []string{"created_at", "updated_at", "company_name", "legal_name"}
+
 type tenantRepository struct {
 	client *mongo.Client
 }
@@ -20,8 +21,8 @@ func NewTenantRepository(client *mongo.Client) domain.TenantRepository {
 }
 
 func (db *tenantRepository) tenantCollection(ctx context.Context) *mongo.Collection {
-	userCollection := GetCollection(db.client, "user")
-	return userCollection
+	tenantCollection := GetCollection(db.client, "tenants")
+	return tenantCollection
 }
 
 func (db *tenantRepository) Create(ctx context.Context, tenant domain.Tenant) (string, error) {
@@ -41,7 +42,7 @@ func (db *tenantRepository) Delete(ctx context.Context, tenantID string) error {
 		return domain.NewValidationError("tenantID is required", map[string]any{"tenant_id": tenantID})
 	}
 
-	_, err := db.tenantCollection(ctx).DeleteOne(ctx, bson.D{{"_id", tenantID}})
+	_, err := db.tenantCollection(ctx).DeleteOne(ctx, withTenantScope(ctx, bson.M{"_id": tenantID}, "_id"))
 	if err != nil {
 		return err
 	}
@@ -51,9 +52,9 @@ func (db *tenantRepository) Delete(ctx context.Context, tenantID string) error {
 
 func (db *tenantRepository) GetByID(ctx context.Context, tenantID string) (*domain.Tenant, error) {
 	var tenantDTO TenantDTO
-	err := db.tenantCollection(ctx).FindOne(context.TODO(), bson.D{{"_id", tenantID}}).Decode(&tenantDTO)
+	err := db.tenantCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": tenantID}, "_id")).Decode(&tenantDTO)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, domain.NewNotFoundError("no tenant found with this id", map[string]any{"tenant_id": tenantID})
 		}
 		return nil, err
@@ -65,32 +66,30 @@ func (db *tenantRepository) GetByID(ctx context.Context, tenantID string) (*doma
 }
 
 func (db *tenantRepository) Search(ctx context.Context, filters domain.TenantFilters) (domain.PagingResult[domain.Tenant], error) {
-
 	filter := bson.M{}
 	if len(filters.TenantID) > 0 {
-		filter["_id"] = filters.TenantID
+		filter["_id"] = inFilter(filters.TenantID)
 	}
 	if len(filters.CompanyName) > 0 {
-		filter["company_name"] = filters.CompanyName
+		filter["company_name"] = inFilter(filters.CompanyName)
 	}
 	if len(filters.Document) > 0 {
-		filter["document"] = filters.Document
+		filter["document"] = inFilter(filters.Document)
 	}
+	if filters.Active != nil {
+		filter["active"] = *filters.Active
+	}
+
+	filter = withTenantScope(ctx, filter, "_id")
 
-	var tenantsResult TenantDTO
-	err := db.tenantCollection(ctx).FindOne(ctx, filter).Decode(&tenantsResult)
+	tenantsResult, paging, err := searchCollection[TenantDTO](ctx, db.tenantCollection(ctx), filter, filters.PagingFilter, tenantSortableFields)
 	if err != nil {
 		return domain.PagingResult[domain.Tenant]{}, err
 	}
-	tenants := mapTenantDTOsToTenants([]TenantDTO{tenantsResult})
 
 	result := domain.PagingResult[domain.Tenant]{
-		Result: tenants,
-		Paging: domain.Paging{
-			Total:  len(tenants),
-			Limit:  filters.Limit,
-			Offset: filters.Offset,
-		},
+		Result: mapTenantDTOsToTenants(tenantsResult),
+		Paging: paging,
 	}
 
 	return result, nil
@@ -99,14 +98,15 @@ func (db *tenantRepository) Search(ctx context.Context, filters domain.TenantFil
 func (db *tenantRepository) Update(ctx context.Context, tenant domain.Tenant) error {
 	tenantDTO := mapTenantToTenantDTO(tenant)
 
-	filter := bson.M{
-		"_id": tenant.TenantID,
-	}
+	filter := withTenantScope(ctx, bson.M{"_id": tenant.TenantID}, "_id")
 
-	result := db.tenantCollection(ctx).FindOneAndUpdate(ctx, filter, tenantDTO)
+	result, err := db.tenantCollection(ctx).ReplaceOne(ctx, filter, tenantDTO)
+	if err != nil {
+		return err
+	}
 
-	if result.Err() != nil {
-		return result.Err()
+	if result.MatchedCount == 0 {
+		return domain.NewNotFoundError("no tenant found with this id", map[string]any{"tenant_id": tenant.TenantID})
 	}
 
 	return nil
@@ -7,26 +7,26 @@ import (
 )
 
 type TicketDTO struct {
-	TicketID          string     `db:"ticket_id"`
-	TenantID          string     `db:"tenant_id"`
-	CustomerID        string     `db:"customer_id"`
-	LeadID            *string    `db:"lead_id"`
-	OwnerID           *string    `db:"owner_id"`
-	OriginChannel     string     `db:"origin"`
-	Type              string     `db:"type"`
-	Subject           string     `db:"subject"`
-	Priority          string     `db:"priority"`
-	Status            string     `db:"status"`
-	DueDate           time.Time  `db:"due_date"`
-	CreatedBy         string     `db:"created_by"`
-	CreatedAt         time.Time  `db:"created_at"`
-	UpdatedBy         string     `db:"updated_by"`
-	UpdatedAt         time.Time  `db:"updated_at"`
-	ExternalReference string     `db:"external_reference"`
-	ProductID         string     `db:"product_id"`
-	Region            int        `db:"region"`
-	ClosedAt          *time.Time `db:"closed_at"`
-	TargetDate        *time.Time `db:"target_date"`
+	TicketID          string     `db:"ticket_id" bson:"_id"`
+	TenantID          string     `db:"tenant_id" bson:"tenant_id"`
+	CustomerID        string     `db:"customer_id" bson:"customer_id"`
+	LeadID            *string    `db:"lead_id" bson:"lead_id"`
+	OwnerID           *string    `db:"owner_id" bson:"owner_id"`
+	OriginChannel     string     `db:"origin" bson:"origin"`
+	Type              string     `db:"type" bson:"type"`
+	Subject           string     `db:"subject" bson:"subject"`
+	Priority          string     `db:"priority" bson:"priority"`
+	Status            string     `db:"status" bson:"status"`
+	DueDate           time.Time  `db:"due_date" bson:"due_date"`
+	CreatedBy         string     `db:"created_by" bson:"created_by"`
+	CreatedAt         time.Time  `db:"created_at" bson:"created_at"`
+	UpdatedBy         string     `db:"updated_by" bson:"updated_by"`
+	UpdatedAt         time.Time  `db:"updated_at" bson:"updated_at"`
+	ExternalReference string     `db:"external_reference" bson:"external_reference"`
+	ProductID         string     `db:"product_id" bson:"product_id"`
+	Region            int        `db:"region" bson:"region"`
+	ClosedAt          *time.Time `db:"closed_at" bson:"closed_at"`
+	TargetDate        *time.Time `db:"target_date" bson:"target_date"`
 }
 
 func mapTicketToTicketDTO(crmTicket domain.Ticket) TicketDTO {
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type TicketEventDTO struct {
+	EventID   string                 `db:"event_id" bson:"_id"`
+	TicketID  string                 `db:"ticket_id" bson:"ticket_id"`
+	TenantID  string                 `db:"tenant_id" bson:"tenant_id"`
+	Action    string                 `db:"action" bson:"action"`
+	Changes   []TicketFieldChangeDTO `db:"changes" bson:"changes"`
+	CreatedBy string                 `db:"created_by" bson:"created_by"`
+	CreatedAt time.Time              `db:"created_at" bson:"created_at"`
+}
+
+type TicketFieldChangeDTO struct {
+	Field    string `db:"field" bson:"field"`
+	OldValue string `db:"old_value" bson:"old_value"`
+	NewValue string `db:"new_value" bson:"new_value"`
+}
+
+func mapTicketEventToTicketEventDTO(event domain.TicketEvent) TicketEventDTO {
+	changes := make([]TicketFieldChangeDTO, 0, len(event.Changes))
+	for _, change := range event.Changes {
+		changes = append(changes, TicketFieldChangeDTO{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	return TicketEventDTO{
+		EventID:   event.EventID,
+		TicketID:  event.TicketID,
+		TenantID:  event.TenantID,
+		Action:    string(event.Action),
+		Changes:   changes,
+		CreatedBy: event.CreatedBy,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+func mapTicketEventDTOToTicketEvent(eventDTO TicketEventDTO) domain.TicketEvent {
+	changes := make([]domain.TicketFieldChange, 0, len(eventDTO.Changes))
+	for _, change := range eventDTO.Changes {
+		changes = append(changes, domain.TicketFieldChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	return domain.TicketEvent{
+		EventID:   eventDTO.EventID,
+		TicketID:  eventDTO.TicketID,
+		TenantID:  eventDTO.TenantID,
+		Action:    domain.TicketAction(eventDTO.Action),
+		Changes:   changes,
+		CreatedBy: eventDTO.CreatedBy,
+		CreatedAt: eventDTO.CreatedAt,
+	}
+}
+
+func mapTicketEventDTOsToTicketEvents(eventDTOs []TicketEventDTO) []domain.TicketEvent {
+	events := make([]domain.TicketEvent, 0, len(eventDTOs))
+	for _, eventDTO := range eventDTOs {
+		events = append(events, mapTicketEventDTOToTicketEvent(eventDTO))
+	}
+	return events
+}
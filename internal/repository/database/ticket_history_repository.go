@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ticketHistorySortableFields = []string{"created_at", "action"}
+
+type ticketHistoryRepository struct {
+	client *mongo.Client
+}
+
+func NewTicketHistoryRepository(client *mongo.Client) domain.TicketHistoryRepository {
+	return &ticketHistoryRepository{
+		client: client,
+	}
+}
+
+func (r *ticketHistoryRepository) ticketHistoryCollection(ctx context.Context) *mongo.Collection {
+	historyCollection := GetCollection(r.client, "ticket_history")
+	return historyCollection
+}
+
+func (r *ticketHistoryRepository) Create(ctx context.Context, event domain.TicketEvent) (string, error) {
+	eventDTO := mapTicketEventToTicketEventDTO(event)
+
+	_, err := r.ticketHistoryCollection(ctx).InsertOne(ctx, eventDTO)
+	if err != nil {
+		return "", err
+	}
+
+	return event.EventID, nil
+}
+
+func (r *ticketHistoryRepository) Search(ctx context.Context, filters domain.TicketHistoryFilters) (domain.PagingResult[domain.TicketEvent], error) {
+	filter := withTenantScope(ctx, bson.M{"ticket_id": filters.TicketID}, "tenant_id")
+
+	eventDTOs, paging, err := searchCollection[TicketEventDTO](ctx, r.ticketHistoryCollection(ctx), filter, filters.PagingFilter, ticketHistorySortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.TicketEvent]{}, err
+	}
+
+	result := domain.PagingResult[domain.TicketEvent]{
+		Result: mapTicketEventDTOsToTicketEvents(eventDTOs),
+		Paging: paging,
+	}
+
+	return result, nil
+}
@@ -2,13 +2,14 @@ package database
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/icrxz/crm-api-core/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+var ticketSortableFields = []string{"created_at", "updated_at", "due_date", "target_date", "status", "priority"}
+
 type ticketRepository struct {
 	client *mongo.Client
 }
@@ -20,8 +21,8 @@ func NewTicketRepository(client *mongo.Client) domain.TicketRepository {
 }
 
 func (r *ticketRepository) ticketCollection(ctx context.Context) *mongo.Collection {
-	userCollection := GetCollection(r.client, "user")
-	return userCollection
+	ticketCollection := GetCollection(r.client, "tickets")
+	return ticketCollection
 }
 
 func (r *ticketRepository) Create(ctx context.Context, crmTicket domain.Ticket) (string, error) {
@@ -42,9 +43,9 @@ func (r *ticketRepository) GetByID(ctx context.Context, ticketID string) (*domai
 	}
 
 	var crmTicketDTO TicketDTO
-	err := r.ticketCollection(ctx).FindOne(context.TODO(), bson.D{{"_id", ticketID}}).Decode(&crmTicketDTO)
+	err := r.ticketCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": ticketID}, "tenant_id")).Decode(&crmTicketDTO)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, domain.NewNotFoundError("no ticket found with this id", map[string]any{"ticket_id": ticketID})
 		}
 		return nil, err
@@ -55,44 +56,54 @@ func (r *ticketRepository) GetByID(ctx context.Context, ticketID string) (*domai
 }
 
 func (r *ticketRepository) Search(ctx context.Context, filters domain.TicketFilters) (domain.PagingResult[domain.Ticket], error) {
-
 	filter := bson.M{}
 
 	if len(filters.TenantID) > 0 {
-		filter["tenant_id"] = filters.TenantID
+		filter["tenant_id"] = inFilter(filters.TenantID)
 	}
 	if len(filters.OwnerID) > 0 {
-		filter["owner_id"] = filters.OwnerID
+		filter["owner_id"] = inFilter(filters.OwnerID)
 	}
 	if len(filters.CustomerID) > 0 {
-		filter["customer_id"] = filters.CustomerID
+		filter["customer_id"] = inFilter(filters.CustomerID)
 	}
 	if len(filters.LeadID) > 0 {
-		filter["lead_id"] = filters.LeadID
+		filter["lead_id"] = inFilter(filters.LeadID)
 	}
 	if len(filters.Status) > 0 {
-		filter["status"] = filters.Status
+		filter["status"] = inFilter(filters.Status)
 	}
 	if len(filters.Region) > 0 {
-		filter["region"] = filters.Region
+		regionFilter, err := intInFilter(filters.Region)
+		if err != nil {
+			return domain.PagingResult[domain.Ticket]{}, err
+		}
+		filter["region"] = regionFilter
+	}
+	if filters.CreatedFrom != nil || filters.CreatedTo != nil {
+		createdAtFilter := bson.M{}
+		if filters.CreatedFrom != nil {
+			createdAtFilter["$gte"] = *filters.CreatedFrom
+		}
+		if filters.CreatedTo != nil {
+			createdAtFilter["$lt"] = *filters.CreatedTo
+		}
+		filter["created_at"] = createdAtFilter
+	}
+	if filters.DueBefore != nil {
+		filter["due_date"] = bson.M{"$lt": *filters.DueBefore}
 	}
 
-	var crmTicketsDTO []TicketDTO
-
-	err := r.ticketCollection(ctx).FindOne(ctx, filter).Decode(&crmTicketsDTO)
+	filter = withTenantScope(ctx, filter, "tenant_id")
 
+	crmTicketsDTO, paging, err := searchCollection[TicketDTO](ctx, r.ticketCollection(ctx), filter, filters.PagingFilter, ticketSortableFields)
 	if err != nil {
 		return domain.PagingResult[domain.Ticket]{}, err
 	}
-	crmTickets := mapTicketDTOsToTickets(crmTicketsDTO)
 
 	result := domain.PagingResult[domain.Ticket]{
-		Result: crmTickets,
-		Paging: domain.Paging{
-			Total:  len(crmTicketsDTO),
-			Limit:  filters.Limit,
-			Offset: filters.Offset,
-		},
+		Result: mapTicketDTOsToTickets(crmTicketsDTO),
+		Paging: paging,
 	}
 
 	return result, nil
@@ -101,14 +112,15 @@ func (r *ticketRepository) Search(ctx context.Context, filters domain.TicketFilt
 func (r *ticketRepository) Update(ctx context.Context, crmTicket domain.Ticket) error {
 	crmTicketDTO := mapTicketToTicketDTO(crmTicket)
 
-	filter := bson.M{
-		"_id": crmTicket.TicketID,
-	}
+	filter := withTenantScope(ctx, bson.M{"_id": crmTicket.TicketID}, "tenant_id")
 
-	result := r.ticketCollection(ctx).FindOneAndUpdate(ctx, filter, crmTicketDTO)
+	result, err := r.ticketCollection(ctx).ReplaceOne(ctx, filter, crmTicketDTO)
+	if err != nil {
+		return err
+	}
 
-	if result.Err() != nil {
-		return result.Err()
+	if result.MatchedCount == 0 {
+		return domain.NewNotFoundError("no ticket found with this id", map[string]any{"ticket_id": crmTicket.TicketID})
 	}
 
 	return nil
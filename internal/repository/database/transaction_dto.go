@@ -7,22 +7,24 @@ import (
 )
 
 type TransactionDTO struct {
-	TransactionID string    `db:"transaction_id"`
-	TicketID      string    `db:"ticket_id"`
-	Type          string    `db:"type"`
-	Value         float64   `db:"amount"`
-	Status        string    `db:"status"`
-	AttachmentID  string    `db:"attachment_id"`
-	CreatedAt     time.Time `db:"created_at"`
-	CreatedBy     string    `db:"created_by"`
-	UpdatedAt     time.Time `db:"updated_at"`
-	UpdatedBy     string    `db:"updated_by"`
-	Description   *string   `db:"description"`
+	TransactionID string    `db:"transaction_id" bson:"_id"`
+	TenantID      string    `db:"tenant_id" bson:"tenant_id"`
+	TicketID      string    `db:"ticket_id" bson:"ticket_id"`
+	Type          string    `db:"type" bson:"type"`
+	Value         float64   `db:"value" bson:"value"`
+	Status        string    `db:"status" bson:"status"`
+	AttachmentID  string    `db:"attachment_id" bson:"attachment_id"`
+	CreatedAt     time.Time `db:"created_at" bson:"created_at"`
+	CreatedBy     string    `db:"created_by" bson:"created_by"`
+	UpdatedAt     time.Time `db:"updated_at" bson:"updated_at"`
+	UpdatedBy     string    `db:"updated_by" bson:"updated_by"`
+	Description   *string   `db:"description" bson:"description"`
 }
 
 func mapTransactionToTransactionDTO(transaction domain.Transaction) TransactionDTO {
 	return TransactionDTO{
 		TransactionID: transaction.TransactionID,
+		TenantID:      transaction.TenantID,
 		TicketID:      transaction.TicketID,
 		Type:          string(transaction.Type),
 		Value:         transaction.Value,
@@ -44,6 +46,7 @@ func mapTransactionDTOToTransaction(transactionDTO TransactionDTO) domain.Transa
 
 	return domain.Transaction{
 		TransactionID: transactionDTO.TransactionID,
+		TenantID:      transactionDTO.TenantID,
 		TicketID:      transactionDTO.TicketID,
 		Type:          domain.TransactionType(transactionDTO.Type),
 		Value:         transactionDTO.Value,
@@ -2,13 +2,14 @@ package database
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"github.com/icrxz/crm-api-core/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+var transactionSortableFields = []string{"created_at", "updated_at", "value", "status", "type"}
+
 type transactionRepository struct {
 	client *mongo.Client
 }
@@ -20,8 +21,8 @@ func NewTransactionRepository(client *mongo.Client) domain.TransactionRepository
 }
 
 func (r *transactionRepository) transactionCollection(ctx context.Context) *mongo.Collection {
-	userCollection := GetCollection(r.client, "user")
-	return userCollection
+	transactionCollection := GetCollection(r.client, "transactions")
+	return transactionCollection
 }
 
 func (r *transactionRepository) CreateTransaction(ctx context.Context, transaction domain.Transaction) (string, error) {
@@ -42,9 +43,9 @@ func (r *transactionRepository) GetTransaction(ctx context.Context, transactionI
 	}
 
 	var transactionDTO TransactionDTO
-	err := r.transactionCollection(ctx).FindOne(context.TODO(), bson.D{{"_id", transactionID}}).Decode(&transactionDTO)
+	err := r.transactionCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": transactionID}, "tenant_id")).Decode(&transactionDTO)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, mongo.ErrNoDocuments) {
 			return domain.Transaction{}, domain.NewNotFoundError("no transaction found with this id", map[string]any{"transaction_id": transactionID})
 		}
 		return domain.Transaction{}, err
@@ -55,36 +56,44 @@ func (r *transactionRepository) GetTransaction(ctx context.Context, transactionI
 
 func (r *transactionRepository) UpdateTransaction(ctx context.Context, transaction domain.Transaction) error {
 	transactionDTO := mapTransactionToTransactionDTO(transaction)
-	filter := bson.M{
-		"_id": transaction.TransactionID,
+	filter := withTenantScope(ctx, bson.M{"_id": transaction.TransactionID}, "tenant_id")
+	result, err := r.transactionCollection(ctx).ReplaceOne(ctx, filter, transactionDTO)
+	if err != nil {
+		return err
 	}
-	result := r.transactionCollection(ctx).FindOneAndUpdate(ctx, filter, transactionDTO)
 
-	if result.Err() != nil {
-		return result.Err()
+	if result.MatchedCount == 0 {
+		return domain.NewNotFoundError("no transaction found with this id", map[string]any{"transaction_id": transaction.TransactionID})
 	}
+
 	return nil
 }
 
-func (r *transactionRepository) SearchTransactions(ctx context.Context, filters domain.TransactionFilters) ([]domain.Transaction, error) {
-
+func (r *transactionRepository) SearchTransactions(ctx context.Context, filters domain.TransactionFilters) (domain.PagingResult[domain.Transaction], error) {
 	filter := bson.M{}
 	if len(filters.Status) > 0 {
-		filter["status"] = filters.Status
+		filter["status"] = inFilter(filters.Status)
 	}
-	if len(filters.Status) > 0 {
-		filter["ticket_ids"] = filters.TicketIDs
+	if len(filters.TicketIDs) > 0 {
+		filter["ticket_id"] = inFilter(filters.TicketIDs)
 	}
-	if len(filters.Status) > 0 {
-		filter["type"] = filters.Types
+	if len(filters.Types) > 0 {
+		filter["type"] = inFilter(filters.Types)
+	}
+	if len(filters.TenantIDs) > 0 {
+		filter["tenant_id"] = inFilter(filters.TenantIDs)
 	}
-	var transactionResults []TransactionDTO
-	err := r.transactionCollection(ctx).FindOne(ctx, filter).Decode(&transactionResults)
+	filter = withTenantScope(ctx, filter, "tenant_id")
+
+	transactionResults, paging, err := searchCollection[TransactionDTO](ctx, r.transactionCollection(ctx), filter, filters.PagingFilter, transactionSortableFields)
 	if err != nil {
-		return nil, err
+		return domain.PagingResult[domain.Transaction]{}, err
 	}
 
-	transactions := mapTransactionDTOsToTransactions(transactionResults)
+	result := domain.PagingResult[domain.Transaction]{
+		Result: mapTransactionDTOsToTransactions(transactionResults),
+		Paging: paging,
+	}
 
-	return transactions, nil
+	return result, nil
 }
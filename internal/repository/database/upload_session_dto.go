@@ -0,0 +1,68 @@
+package database
+
+import (
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type UploadSessionDTO struct {
+	SessionID     string     `db:"session_id" bson:"_id"`
+	TenantID      string     `db:"tenant_id" bson:"tenant_id"`
+	TicketID      string     `db:"ticket_id" bson:"ticket_id"`
+	CommentID     string     `db:"comment_id" bson:"comment_id"`
+	Key           string     `db:"key" bson:"key"`
+	FileName      string     `db:"file_name" bson:"file_name"`
+	FileExtension string     `db:"file_extension" bson:"file_extension"`
+	ContentType   string     `db:"content_type" bson:"content_type"`
+	Size          int        `db:"size" bson:"size"`
+	Visibility    string     `db:"visibility" bson:"visibility"`
+	Status        string     `db:"status" bson:"status"`
+	AttachmentID  string     `db:"attachment_id" bson:"attachment_id"`
+	CreatedBy     string     `db:"created_by" bson:"created_by"`
+	CreatedAt     time.Time  `db:"created_at" bson:"created_at"`
+	ExpiresAt     time.Time  `db:"expires_at" bson:"expires_at"`
+	CompletedAt   *time.Time `db:"completed_at" bson:"completed_at"`
+}
+
+func mapUploadSessionToUploadSessionDTO(session domain.UploadSession) UploadSessionDTO {
+	return UploadSessionDTO{
+		SessionID:     session.SessionID,
+		TenantID:      session.TenantID,
+		TicketID:      session.TicketID,
+		CommentID:     session.CommentID,
+		Key:           session.Key,
+		FileName:      session.FileName,
+		FileExtension: session.FileExtension,
+		ContentType:   session.ContentType,
+		Size:          session.Size,
+		Visibility:    string(session.Visibility),
+		Status:        string(session.Status),
+		AttachmentID:  session.AttachmentID,
+		CreatedBy:     session.CreatedBy,
+		CreatedAt:     session.CreatedAt,
+		ExpiresAt:     session.ExpiresAt,
+		CompletedAt:   session.CompletedAt,
+	}
+}
+
+func mapUploadSessionDTOToUploadSession(sessionDTO UploadSessionDTO) domain.UploadSession {
+	return domain.UploadSession{
+		SessionID:     sessionDTO.SessionID,
+		TenantID:      sessionDTO.TenantID,
+		TicketID:      sessionDTO.TicketID,
+		CommentID:     sessionDTO.CommentID,
+		Key:           sessionDTO.Key,
+		FileName:      sessionDTO.FileName,
+		FileExtension: sessionDTO.FileExtension,
+		ContentType:   sessionDTO.ContentType,
+		Size:          sessionDTO.Size,
+		Visibility:    domain.Visibility(sessionDTO.Visibility),
+		Status:        domain.UploadSessionStatus(sessionDTO.Status),
+		AttachmentID:  sessionDTO.AttachmentID,
+		CreatedBy:     sessionDTO.CreatedBy,
+		CreatedAt:     sessionDTO.CreatedAt,
+		ExpiresAt:     sessionDTO.ExpiresAt,
+		CompletedAt:   sessionDTO.CompletedAt,
+	}
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type uploadSessionRepository struct {
+	client *mongo.Client
+}
+
+func NewUploadSessionRepository(client *mongo.Client) domain.UploadSessionRepository {
+	return &uploadSessionRepository{
+		client: client,
+	}
+}
+
+func (r *uploadSessionRepository) uploadSessionCollection(ctx context.Context) *mongo.Collection {
+	uploadSessionCollection := GetCollection(r.client, "upload_sessions")
+	return uploadSessionCollection
+}
+
+func (r *uploadSessionRepository) Create(ctx context.Context, session domain.UploadSession) (string, error) {
+	sessionDTO := mapUploadSessionToUploadSessionDTO(session)
+
+	_, err := r.uploadSessionCollection(ctx).InsertOne(ctx, sessionDTO)
+	if err != nil {
+		return "", err
+	}
+
+	return session.SessionID, nil
+}
+
+func (r *uploadSessionRepository) GetByID(ctx context.Context, sessionID string) (*domain.UploadSession, error) {
+	var sessionDTO UploadSessionDTO
+	err := r.uploadSessionCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": sessionID}, "tenant_id")).Decode(&sessionDTO)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.NewNotFoundError("no upload session found with this id", map[string]any{"session_id": sessionID})
+		}
+		return nil, err
+	}
+
+	session := mapUploadSessionDTOToUploadSession(sessionDTO)
+	return &session, nil
+}
+
+func (r *uploadSessionRepository) Update(ctx context.Context, session domain.UploadSession) error {
+	sessionDTO := mapUploadSessionToUploadSessionDTO(session)
+	filter := withTenantScope(ctx, bson.M{"_id": session.SessionID}, "tenant_id")
+
+	result, err := r.uploadSessionCollection(ctx).ReplaceOne(ctx, filter, sessionDTO)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.NewNotFoundError("no upload session found with this id", map[string]any{"session_id": session.SessionID})
+	}
+
+	return nil
+}
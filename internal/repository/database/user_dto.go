@@ -7,54 +7,60 @@ import (
 )
 
 type UserDTO struct {
-	UserID    string    `db:"user_id"`
-	Username  string    `db:"username"`
-	FirstName string    `db:"first_name"`
-	LastName  string    `db:"last_name"`
-	Email     string    `db:"email"`
-	Password  string    `db:"password"`
-	Role      string    `db:"role"`
-	Region    int       `db:"region"`
-	Active    bool      `db:"active"`
-	CreatedAt time.Time `db:"created_at"`
-	CreatedBy string    `db:"created_by"`
-	UpdatedAt time.Time `db:"updated_at"`
-	UpdatedBy string    `db:"updated_by"`
+	UserID             string    `db:"user_id" bson:"_id"`
+	TenantID           string    `db:"tenant_id" bson:"tenant_id"`
+	Username           string    `db:"username" bson:"username"`
+	FirstName          string    `db:"first_name" bson:"first_name"`
+	LastName           string    `db:"last_name" bson:"last_name"`
+	Email              string    `db:"email" bson:"email"`
+	Password           string    `db:"password" bson:"password"`
+	Role               string    `db:"role" bson:"role"`
+	Region             int       `db:"region" bson:"region"`
+	Active             bool      `db:"active" bson:"active"`
+	MustChangePassword bool      `db:"must_change_password" bson:"must_change_password"`
+	CreatedAt          time.Time `db:"created_at" bson:"created_at"`
+	CreatedBy          string    `db:"created_by" bson:"created_by"`
+	UpdatedAt          time.Time `db:"updated_at" bson:"updated_at"`
+	UpdatedBy          string    `db:"updated_by" bson:"updated_by"`
 }
 
 func mapUserToUserDTO(user domain.User) UserDTO {
 	return UserDTO{
-		UserID:    user.UserID,
-		Username:  user.Username,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Email:     user.Email,
-		Role:      string(user.Role),
-		Region:    user.Region,
-		Password:  user.Password,
-		Active:    user.Active,
-		CreatedAt: user.CreatedAt,
-		CreatedBy: user.CreatedBy,
-		UpdatedAt: user.UpdatedAt,
-		UpdatedBy: user.UpdatedBy,
+		UserID:             user.UserID,
+		TenantID:           user.TenantID,
+		Username:           user.Username,
+		FirstName:          user.FirstName,
+		LastName:           user.LastName,
+		Email:              user.Email,
+		Role:               string(user.Role),
+		Region:             user.Region,
+		Password:           user.Password,
+		Active:             user.Active,
+		MustChangePassword: user.MustChangePassword,
+		CreatedAt:          user.CreatedAt,
+		CreatedBy:          user.CreatedBy,
+		UpdatedAt:          user.UpdatedAt,
+		UpdatedBy:          user.UpdatedBy,
 	}
 }
 
 func mapUserDTOToUser(userDTO UserDTO) domain.User {
 	return domain.User{
-		UserID:    userDTO.UserID,
-		Username:  userDTO.Username,
-		FirstName: userDTO.FirstName,
-		LastName:  userDTO.LastName,
-		Email:     userDTO.Email,
-		Role:      domain.UserRole(userDTO.Role),
-		Region:    userDTO.Region,
-		CreatedAt: userDTO.CreatedAt,
-		CreatedBy: userDTO.CreatedBy,
-		UpdatedAt: userDTO.UpdatedAt,
-		UpdatedBy: userDTO.UpdatedBy,
-		Password:  userDTO.Password,
-		Active:    userDTO.Active,
+		UserID:             userDTO.UserID,
+		TenantID:           userDTO.TenantID,
+		Username:           userDTO.Username,
+		FirstName:          userDTO.FirstName,
+		LastName:           userDTO.LastName,
+		Email:              userDTO.Email,
+		Role:               domain.UserRole(userDTO.Role),
+		Region:             userDTO.Region,
+		CreatedAt:          userDTO.CreatedAt,
+		CreatedBy:          userDTO.CreatedBy,
+		UpdatedAt:          userDTO.UpdatedAt,
+		UpdatedBy:          userDTO.UpdatedBy,
+		Password:           userDTO.Password,
+		Active:             userDTO.Active,
+		MustChangePassword: userDTO.MustChangePassword,
 	}
 }
 
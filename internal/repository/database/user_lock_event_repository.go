@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type userLockEventRepository struct {
+	client *mongo.Client
+}
+
+func NewUserLockEventRepository(client *mongo.Client) domain.UserLockEventRepository {
+	return &userLockEventRepository{
+		client: client,
+	}
+}
+
+func (r *userLockEventRepository) userLockEventCollection(ctx context.Context) *mongo.Collection {
+	userLockEventCollection := GetCollection(r.client, "user_lock_events")
+	return userLockEventCollection
+}
+
+func (r *userLockEventRepository) Create(ctx context.Context, event domain.UserLockEvent) (string, error) {
+	eventDTO := mapUserLockEventToUserLockEventDTO(event)
+
+	_, err := r.userLockEventCollection(ctx).InsertOne(ctx, eventDTO)
+	if err != nil {
+		return "", err
+	}
+
+	return event.EventID, nil
+}
+
+func (r *userLockEventRepository) GetByUserID(ctx context.Context, userID string) ([]domain.UserLockEvent, error) {
+	if userID == "" {
+		return nil, domain.NewValidationError("userID is required", nil)
+	}
+
+	filter := withTenantScope(ctx, bson.M{"user_id": userID}, "tenant_id")
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.userLockEventCollection(ctx).Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventDTOs []UserLockEventDTO
+	if err = cursor.All(ctx, &eventDTOs); err != nil {
+		return nil, err
+	}
+
+	return mapUserLockEventDTOsToUserLockEvents(eventDTOs), nil
+}
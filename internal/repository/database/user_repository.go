@@ -2,11 +2,14 @@ package database
 
 import (
 	"context"
+	"errors"
 	"github.com/icrxz/crm-api-core/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+var userSortableFields = []string{"created_at", "updated_at", "username", "first_name", "email", "role", "region"}
+
 type userDatabase struct {
 	client *mongo.Client
 }
@@ -35,8 +38,11 @@ func (db *userDatabase) Create(ctx context.Context, user domain.User) (string, e
 func (db *userDatabase) GetByID(ctx context.Context, userID string) (*domain.User, error) {
 	var userDTO UserDTO
 
-	err := db.userCollection(ctx).FindOne(context.TODO(), bson.D{{"_id", userID}}).Decode(&userDTO)
+	err := db.userCollection(ctx).FindOne(ctx, withTenantScope(ctx, bson.M{"_id": userID}, "tenant_id")).Decode(&userDTO)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.NewNotFoundError("no user found with this id", map[string]any{"user_id": userID})
+		}
 		return nil, err
 	}
 
@@ -45,51 +51,63 @@ func (db *userDatabase) GetByID(ctx context.Context, userID string) (*domain.Use
 	return &user, nil
 }
 
-func (db *userDatabase) Search(ctx context.Context, filters domain.UserFilters) ([]domain.User, error) {
-
+func (db *userDatabase) Search(ctx context.Context, filters domain.UserFilters) (domain.PagingResult[domain.User], error) {
 	filter := bson.M{}
 	if len(filters.Role) > 0 {
-		filter["role"] = filters.Role
+		filter["role"] = inFilter(filters.Role)
 	}
 	if len(filters.Email) > 0 {
-		filter["email"] = filters.Email
+		filter["email"] = inFilter(filters.Email)
 	}
 	if len(filters.Username) > 0 {
-		filter["username"] = filters.Username
+		filter["username"] = inFilter(filters.Username)
 	}
 	if len(filters.FirstName) > 0 {
-		filter["first_name"] = filters.FirstName
+		filter["first_name"] = inFilter(filters.FirstName)
+	}
+	if len(filters.Region) > 0 {
+		regionFilter, err := intInFilter(filters.Region)
+		if err != nil {
+			return domain.PagingResult[domain.User]{}, err
+		}
+		filter["region"] = regionFilter
 	}
-	if *filters.Active {
-		filter["active"] = true
+	if filters.Active != nil {
+		filter["active"] = *filters.Active
 	}
 	if len(filters.UserID) > 0 {
-		filter["_id"] = filters.UserID
+		filter["_id"] = inFilter(filters.UserID)
 	}
+	if len(filters.TenantID) > 0 {
+		filter["tenant_id"] = inFilter(filters.TenantID)
+	}
+	filter = withTenantScope(ctx, filter, "tenant_id")
 
-	var usersResult UserDTO
-	err := db.userCollection(ctx).FindOne(ctx, filter).Decode(&usersResult)
-
+	usersResult, paging, err := searchCollection[UserDTO](ctx, db.userCollection(ctx), filter, filters.PagingFilter, userSortableFields)
 	if err != nil {
-		return nil, err
+		return domain.PagingResult[domain.User]{}, err
 	}
 
-	users := mapUserDTOsToUsers([]UserDTO{usersResult})
+	result := domain.PagingResult[domain.User]{
+		Result: mapUserDTOsToUsers(usersResult),
+		Paging: paging,
+	}
 
-	return users, nil
+	return result, nil
 }
 
 func (db *userDatabase) Update(ctx context.Context, userToUpdate domain.User) error {
 	userDTO := mapUserToUserDTO(userToUpdate)
 
-	filter := bson.M{
-		"_id": userToUpdate.UserID,
-	}
+	filter := withTenantScope(ctx, bson.M{"_id": userToUpdate.UserID}, "tenant_id")
 
-	result := db.userCollection(ctx).FindOneAndUpdate(ctx, filter, userDTO)
+	result, err := db.userCollection(ctx).ReplaceOne(ctx, filter, userDTO)
+	if err != nil {
+		return err
+	}
 
-	if result.Err() != nil {
-		return result.Err()
+	if result.MatchedCount == 0 {
+		return domain.NewNotFoundError("no user found with this id", map[string]any{"user_id": userToUpdate.UserID})
 	}
 
 	return nil
@@ -100,7 +118,7 @@ func (db *userDatabase) Delete(ctx context.Context, userID string) error {
 		return domain.NewValidationError("userID cannot be empty", nil)
 	}
 
-	_, err := db.userCollection(ctx).DeleteOne(ctx, bson.D{{"_id", userID}})
+	_, err := db.userCollection(ctx).DeleteOne(ctx, withTenantScope(ctx, bson.M{"_id": userID}, "tenant_id"))
 	if err != nil {
 		return err
 	}
@@ -1,8 +1,119 @@
 package database
 
-import "go.mongodb.org/mongo-driver/mongo"
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultSortField = "created_at"
 
 func GetCollection(client *mongo.Client, collectionName string) *mongo.Collection {
 	collection := client.Database("golangAPI").Collection(collectionName)
 	return collection
 }
+
+// withTenantScope restricts the filter to the caller's tenant, keeping any tenant filter already requested.
+func withTenantScope(ctx context.Context, filter bson.M, field string) bson.M {
+	tenantID, scoped := domain.TenantScopeFromContext(ctx)
+	if !scoped {
+		return filter
+	}
+
+	var tenantFilter any = tenantID
+	if tenantID == "" {
+		// contexts without a scope match no tenant
+		tenantFilter = bson.M{"$in": bson.A{}}
+	}
+
+	if requested, ok := filter[field]; ok {
+		delete(filter, field)
+		filter["$and"] = bson.A{bson.M{field: requested}, bson.M{field: tenantFilter}}
+		return filter
+	}
+
+	filter[field] = tenantFilter
+	return filter
+}
+
+func inFilter[T any](values []T) bson.M {
+	return bson.M{"$in": values}
+}
+
+func intInFilter(values []string) (bson.M, error) {
+	parsedValues := make([]int, 0, len(values))
+	for _, value := range values {
+		parsedValue, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, domain.NewValidationError("filter value must be a number", map[string]any{"value": value})
+		}
+		parsedValues = append(parsedValues, parsedValue)
+	}
+
+	return inFilter(parsedValues), nil
+}
+
+func buildFindOptions(paging domain.PagingFilter, sortableFields []string) (*options.FindOptions, error) {
+	sortField := defaultSortField
+	if paging.SortBy != "" {
+		if !slices.Contains(sortableFields, paging.SortBy) {
+			return nil, domain.NewValidationError("invalid sort field", map[string]any{
+				"sort":            paging.SortBy,
+				"sortable_fields": sortableFields,
+			})
+		}
+		sortField = paging.SortBy
+	}
+
+	sortDirection := -1
+	if paging.SortDirection == domain.ASC {
+		sortDirection = 1
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: sortField, Value: sortDirection}, {Key: "_id", Value: sortDirection}}).
+		SetSkip(int64(paging.Offset))
+
+	if paging.Limit > 0 {
+		findOptions.SetLimit(int64(paging.Limit))
+	}
+
+	return findOptions, nil
+}
+
+func buildPaging(total int64, paging domain.PagingFilter) domain.Paging {
+	return domain.Paging{
+		Total:  int(total),
+		Limit:  paging.Limit,
+		Offset: paging.Offset,
+	}
+}
+
+func searchCollection[T any](ctx context.Context, collection *mongo.Collection, filter bson.M, paging domain.PagingFilter, sortableFields []string) ([]T, domain.Paging, error) {
+	findOptions, err := buildFindOptions(paging, sortableFields)
+	if err != nil {
+		return nil, domain.Paging{}, err
+	}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, domain.Paging{}, err
+	}
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, domain.Paging{}, err
+	}
+
+	results := make([]T, 0)
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, domain.Paging{}, err
+	}
+
+	return results, buildPaging(total, paging), nil
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type attachmentRepository struct {
+	attachments *store[domain.Attachment]
+}
+
+func NewAttachmentRepository() domain.AttachmentRepository {
+	return &attachmentRepository{
+		attachments: newStore[domain.Attachment](),
+	}
+}
+
+func (r *attachmentRepository) Save(ctx context.Context, attachment domain.Attachment) error {
+	r.attachments.put(attachment.AttachmentID, attachment)
+
+	return nil
+}
+
+func (r *attachmentRepository) SaveBatch(ctx context.Context, attachments []domain.Attachment) error {
+	for _, attachment := range attachments {
+		if err := r.Save(ctx, attachment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *attachmentRepository) GetByID(ctx context.Context, attachmentID string) (domain.Attachment, error) {
+	if attachmentID == "" {
+		return domain.Attachment{}, domain.NewValidationError("attachment_id is required", nil)
+	}
+
+	attachment, ok := r.attachments.get(attachmentID)
+	if !ok || !domain.IsInTenantScope(ctx, attachment.TenantID) {
+		return domain.Attachment{}, domain.NewNotFoundError("attachment not found", map[string]any{"attachment_id": attachmentID})
+	}
+
+	return attachment, nil
+}
+
+func (r *attachmentRepository) GetByCommentID(ctx context.Context, commentID string) ([]domain.Attachment, error) {
+	if commentID == "" {
+		return nil, domain.NewValidationError("comment_id is required", nil)
+	}
+
+	attachments := r.attachments.filter(func(attachment domain.Attachment) bool {
+		return domain.IsInTenantScope(ctx, attachment.TenantID) &&
+			attachment.CommentID == commentID
+	})
+
+	return attachments, nil
+}
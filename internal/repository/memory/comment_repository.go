@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var commentSortableFields = sortFields[domain.Comment]{
+	"created_at": func(a, b domain.Comment) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+}
+
+type commentRepository struct {
+	comments *store[domain.Comment]
+}
+
+func NewCommentRepository() domain.CommentRepository {
+	return &commentRepository{
+		comments: newStore[domain.Comment](),
+	}
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment domain.Comment) (string, error) {
+	comment.Attachments = nil
+	r.comments.put(comment.CommentID, comment)
+
+	return comment.CommentID, nil
+}
+
+func (r *commentRepository) GetByID(ctx context.Context, commentID string) (*domain.Comment, error) {
+	if commentID == "" {
+		return nil, domain.NewValidationError("commentID is required", nil)
+	}
+
+	comment, ok := r.comments.get(commentID)
+	if !ok || !domain.IsInTenantScope(ctx, comment.TenantID) {
+		return nil, domain.NewNotFoundError("no comment found with this id", map[string]any{"comment_id": commentID})
+	}
+
+	return &comment, nil
+}
+
+func (r *commentRepository) GetByTicketID(ctx context.Context, ticketID string) ([]domain.Comment, error) {
+	if ticketID == "" {
+		return nil, domain.NewValidationError("ticketID is required", nil)
+	}
+
+	comments := r.comments.filter(func(comment domain.Comment) bool {
+		return domain.IsInTenantScope(ctx, comment.TenantID) &&
+			comment.TicketID == ticketID &&
+			!comment.IsDeleted()
+	})
+
+	result, _, err := paginate(comments, domain.PagingFilter{SortDirection: domain.ASC}, commentSortableFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *commentRepository) Update(ctx context.Context, comment domain.Comment) error {
+	current, ok := r.comments.get(comment.CommentID)
+	if !ok || !domain.IsInTenantScope(ctx, current.TenantID) {
+		return domain.NewNotFoundError("no comment found with this id", map[string]any{"comment_id": comment.CommentID})
+	}
+
+	comment.Attachments = nil
+	r.comments.put(comment.CommentID, comment)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var commentRevisionSortableFields = sortFields[domain.CommentRevision]{
+	"revision": byField(func(r domain.CommentRevision) int { return r.Revision }),
+}
+
+type commentRevisionRepository struct {
+	revisions *store[domain.CommentRevision]
+}
+
+func NewCommentRevisionRepository() domain.CommentRevisionRepository {
+	return &commentRevisionRepository{
+		revisions: newStore[domain.CommentRevision](),
+	}
+}
+
+func (r *commentRevisionRepository) Create(ctx context.Context, revision domain.CommentRevision) (string, error) {
+	r.revisions.put(revision.RevisionID, revision)
+
+	return revision.RevisionID, nil
+}
+
+func (r *commentRevisionRepository) GetByCommentID(ctx context.Context, commentID string) ([]domain.CommentRevision, error) {
+	if commentID == "" {
+		return nil, domain.NewValidationError("commentID is required", nil)
+	}
+
+	revisions := r.revisions.filter(func(revision domain.CommentRevision) bool {
+		return domain.IsInTenantScope(ctx, revision.TenantID) &&
+			revision.CommentID == commentID
+	})
+
+	result, _, err := paginate(revisions, domain.PagingFilter{SortBy: "revision", SortDirection: domain.ASC}, commentRevisionSortableFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var customerSortableFields = sortFields[domain.Customer]{
+	"created_at":   func(a, b domain.Customer) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	"updated_at":   func(a, b domain.Customer) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+	"first_name":   byField(func(c domain.Customer) string { return c.FirstName }),
+	"company_name": byField(func(c domain.Customer) string { return c.CompanyName }),
+}
+
+type customerRepository struct {
+	customers *store[domain.Customer]
+}
+
+func NewCustomerRepository() domain.CustomerRepository {
+	return &customerRepository{
+		customers: newStore[domain.Customer](),
+	}
+}
+
+func (r *customerRepository) Create(ctx context.Context, customer domain.Customer) (string, error) {
+	customer.Tickets = nil
+	r.customers.put(customer.CustomerID, customer)
+
+	return customer.CustomerID, nil
+}
+
+func (r *customerRepository) GetByID(ctx context.Context, customerID string) (*domain.Customer, error) {
+	customer, ok := r.customers.get(customerID)
+	if !ok || !domain.IsInTenantScope(ctx, customer.TenantID) {
+		return nil, domain.NewNotFoundError("no customer found with this id", map[string]any{"customer_id": customerID})
+	}
+
+	return &customer, nil
+}
+
+func (r *customerRepository) Search(ctx context.Context, filters domain.CustomerFilters) (domain.PagingResult[domain.Customer], error) {
+	customers := r.customers.filter(func(customer domain.Customer) bool {
+		return domain.IsInTenantScope(ctx, customer.TenantID) &&
+			matchesAny(filters.CustomerID, customer.CustomerID) &&
+			matchesAny(filters.TenantID, customer.TenantID) &&
+			matchesAny(filters.OwnerID, customer.OwnerID) &&
+			matchesAny(filters.CustomerType, string(customer.Type)) &&
+			matchesAny(filters.Document, customer.Document)
+	})
+
+	result, paging, err := paginate(customers, filters.PagingFilter, customerSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Customer]{}, err
+	}
+
+	return domain.PagingResult[domain.Customer]{
+		Result: result,
+		Paging: paging,
+	}, nil
+}
+
+func (r *customerRepository) Update(ctx context.Context, customer domain.Customer) error {
+	if current, ok := r.customers.get(customer.CustomerID); !ok || !domain.IsInTenantScope(ctx, current.TenantID) {
+		return domain.NewNotFoundError("no customer found with this id", map[string]any{"customer_id": customer.CustomerID})
+	}
+
+	customer.Tickets = nil
+	r.customers.put(customer.CustomerID, customer)
+
+	return nil
+}
+
+func (r *customerRepository) Delete(ctx context.Context, customerID string) error {
+	if customerID == "" {
+		return domain.NewValidationError("customer id is required", map[string]any{"customer_id": customerID})
+	}
+
+	if current, ok := r.customers.get(customerID); ok && domain.IsInTenantScope(ctx, current.TenantID) {
+		r.customers.delete(customerID)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var leadSortableFields = sortFields[domain.Lead]{
+	"created_at":     func(a, b domain.Lead) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	"updated_at":     func(a, b domain.Lead) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+	"first_name":     byField(func(l domain.Lead) string { return l.FirstName }),
+	"company_name":   byField(func(l domain.Lead) string { return l.CompanyName }),
+	"shipping_state": byField(func(l domain.Lead) string { return l.ShippingAddress.State }),
+}
+
+type leadRepository struct {
+	leads *store[domain.Lead]
+}
+
+func NewLeadRepository() domain.LeadRepository {
+	return &leadRepository{
+		leads: newStore[domain.Lead](),
+	}
+}
+
+func (r *leadRepository) Create(ctx context.Context, lead domain.Lead) (string, error) {
+	lead.Tickets = nil
+	r.leads.put(lead.LeadID, lead)
+
+	return lead.LeadID, nil
+}
+
+func (r *leadRepository) GetByID(ctx context.Context, leadID string) (*domain.Lead, error) {
+	lead, ok := r.leads.get(leadID)
+	if !ok {
+		return nil, domain.NewNotFoundError("no lead found with this id", map[string]any{"lead_id": leadID})
+	}
+
+	return &lead, nil
+}
+
+func (r *leadRepository) Search(ctx context.Context, filters domain.LeadFilters) (domain.PagingResult[domain.Lead], error) {
+	leads := r.leads.filter(func(lead domain.Lead) bool {
+		return matchesAny(filters.LeadID, lead.LeadID) &&
+			matchesAny(filters.Document, lead.Document) &&
+			matchesAny(filters.LeadType, lead.LeadType) &&
+			matchesAny(filters.State, lead.ShippingAddress.State) &&
+			(filters.Active == nil || *filters.Active == lead.Active)
+	})
+
+	result, paging, err := paginate(leads, filters.PagingFilter, leadSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Lead]{}, err
+	}
+
+	return domain.PagingResult[domain.Lead]{
+		Result: result,
+		Paging: paging,
+	}, nil
+}
+
+func (r *leadRepository) Update(ctx context.Context, leadToUpdate domain.Lead) error {
+	if _, ok := r.leads.get(leadToUpdate.LeadID); !ok {
+		return domain.NewNotFoundError("no lead found with this id", map[string]any{"lead_id": leadToUpdate.LeadID})
+	}
+
+	leadToUpdate.Tickets = nil
+	r.leads.put(leadToUpdate.LeadID, leadToUpdate)
+
+	return nil
+}
+
+func (r *leadRepository) Delete(ctx context.Context, leadID string) error {
+	if leadID == "" {
+		return domain.NewValidationError("leadID is required", map[string]any{"lead_id": leadID})
+	}
+
+	r.leads.delete(leadID)
+
+	return nil
+}
+
+func (r *leadRepository) CreateBatch(ctx context.Context, leads []domain.Lead) ([]string, error) {
+	insertedIDs := make([]string, 0, len(leads))
+	for _, lead := range leads {
+		leadID, err := r.Create(ctx, lead)
+		if err != nil {
+			return nil, err
+		}
+		insertedIDs = append(insertedIDs, leadID)
+	}
+
+	return insertedIDs, nil
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type loginAttemptRepository struct {
+	attempts *store[domain.LoginAttempt]
+}
+
+func NewLoginAttemptRepository() domain.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		attempts: newStore[domain.LoginAttempt](),
+	}
+}
+
+func (r *loginAttemptRepository) Get(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	attempt, ok := r.attempts.get(key)
+	if !ok || attempt.IsExpired(time.Now().UTC()) {
+		return nil, domain.NewNotFoundError("no login attempt found with this key", map[string]any{"key": key})
+	}
+
+	return &attempt, nil
+}
+
+func (r *loginAttemptRepository) IncrementFailures(ctx context.Context, key string, failedAt, expiresAt time.Time) (*domain.LoginAttempt, error) {
+	// expired records are dropped first, so the store doesn't grow with keys that stopped failing
+	r.attempts.deleteIf(func(attempt domain.LoginAttempt) bool {
+		return attempt.IsExpired(failedAt)
+	})
+
+	var updated domain.LoginAttempt
+	r.attempts.upsert(key, domain.NewLoginAttempt(key), func(attempt *domain.LoginAttempt) {
+		attempt.Failures++
+		attempt.LastFailureAt = failedAt
+		attempt.UpdatedAt = failedAt
+		attempt.ExpiresAt = expiresAt
+		updated = *attempt
+	})
+
+	return &updated, nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, minFailures int, lockedUntil time.Time) (bool, error) {
+	_, locked := r.attempts.update(key, func(attempt *domain.LoginAttempt) bool {
+		if attempt.Failures < minFailures {
+			return false
+		}
+
+		attempt.LockedUntil = &lockedUntil
+		attempt.LockCount++
+		attempt.Failures = 0
+		attempt.UpdatedAt = time.Now().UTC()
+		return true
+	})
+
+	return locked, nil
+}
+
+func (r *loginAttemptRepository) ResetFailures(ctx context.Context, key string) error {
+	r.attempts.update(key, func(attempt *domain.LoginAttempt) bool {
+		if attempt.Failures == 0 {
+			return false
+		}
+
+		attempt.Failures = 0
+		attempt.UpdatedAt = time.Now().UTC()
+		return true
+	})
+
+	return nil
+}
+
+func (r *loginAttemptRepository) Delete(ctx context.Context, key string) error {
+	r.attempts.delete(key)
+
+	return nil
+}
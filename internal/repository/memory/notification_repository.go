@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var notificationSortableFields = sortFields[domain.Notification]{
+	"created_at": func(a, b domain.Notification) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	"read_at":    func(a, b domain.Notification) int { return compareOptionalTime(a.ReadAt, b.ReadAt) },
+	"type":       byField(func(n domain.Notification) domain.NotificationType { return n.Type }),
+}
+
+type notificationRepository struct {
+	notifications *store[domain.Notification]
+}
+
+func NewNotificationRepository() domain.NotificationRepository {
+	return &notificationRepository{
+		notifications: newStore[domain.Notification](),
+	}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification domain.Notification) (string, error) {
+	r.notifications.put(notification.NotificationID, notification)
+
+	return notification.NotificationID, nil
+}
+
+func (r *notificationRepository) Search(ctx context.Context, filters domain.NotificationFilters) (domain.PagingResult[domain.Notification], error) {
+	notifications := r.notifications.filter(func(notification domain.Notification) bool {
+		return domain.IsInTenantScope(ctx, notification.TenantID) &&
+			(filters.UserID == "" || notification.UserID == filters.UserID) &&
+			matchesAny(filters.TicketID, notification.TicketID) &&
+			matchesAny(filters.Type, string(notification.Type)) &&
+			(filters.Read == nil || notification.IsRead() == *filters.Read)
+	})
+
+	result, paging, err := paginate(notifications, filters.PagingFilter, notificationSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Notification]{}, err
+	}
+
+	return domain.PagingResult[domain.Notification]{
+		Result: result,
+		Paging: paging,
+	}, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID string, notificationIDs []string, readAt time.Time) (int, error) {
+	unread := r.notifications.filter(func(notification domain.Notification) bool {
+		return domain.IsInTenantScope(ctx, notification.TenantID) &&
+			notification.UserID == userID &&
+			!notification.IsRead() &&
+			(len(notificationIDs) == 0 || slices.Contains(notificationIDs, notification.NotificationID))
+	})
+
+	for _, notification := range unread {
+		notification.ReadAt = &readAt
+		r.notifications.put(notification.NotificationID, notification)
+	}
+
+	return len(unread), nil
+}
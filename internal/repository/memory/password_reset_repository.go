@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type passwordResetRepository struct {
+	resetTokens *store[domain.PasswordResetToken]
+}
+
+func NewPasswordResetRepository() domain.PasswordResetRepository {
+	return &passwordResetRepository{
+		resetTokens: newStore[domain.PasswordResetToken](),
+	}
+}
+
+func (r *passwordResetRepository) Create(ctx context.Context, resetToken domain.PasswordResetToken) (string, error) {
+	r.resetTokens.put(resetToken.TokenID, resetToken)
+
+	return resetToken.TokenID, nil
+}
+
+func (r *passwordResetRepository) GetByID(ctx context.Context, tokenID string) (*domain.PasswordResetToken, error) {
+	resetToken, ok := r.resetTokens.get(tokenID)
+	if !ok {
+		return nil, domain.NewNotFoundError("no reset token found with this id", map[string]any{"token_id": tokenID})
+	}
+
+	return &resetToken, nil
+}
+
+func (r *passwordResetRepository) MarkUsed(ctx context.Context, tokenID string, usedAt time.Time) (bool, error) {
+	exists, marked := r.resetTokens.update(tokenID, func(resetToken *domain.PasswordResetToken) bool {
+		if resetToken.UsedAt != nil {
+			return false
+		}
+		resetToken.UsedAt = &usedAt
+		return true
+	})
+	if !exists {
+		return false, domain.NewNotFoundError("no reset token found with this id", map[string]any{"token_id": tokenID})
+	}
+
+	return marked, nil
+}
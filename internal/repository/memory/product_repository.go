@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type productRepository struct {
+	products *store[domain.Product]
+}
+
+func NewProductRepository() domain.ProductRepository {
+	return &productRepository{
+		products: newStore[domain.Product](),
+	}
+}
+
+func (r *productRepository) CreateProduct(ctx context.Context, product domain.Product) (string, error) {
+	r.products.put(product.ProductID, product)
+
+	return product.ProductID, nil
+}
+
+func (r *productRepository) GetProductByID(ctx context.Context, productID string) (*domain.Product, error) {
+	if productID == "" {
+		return nil, domain.NewValidationError("product_id is required", nil)
+	}
+
+	product, ok := r.products.get(productID)
+	if !ok || !domain.IsInTenantScope(ctx, product.TenantID) {
+		return nil, domain.NewNotFoundError("no product found with this id", map[string]any{"product_id": productID})
+	}
+
+	return &product, nil
+}
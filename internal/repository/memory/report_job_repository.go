@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var reportJobSortableFields = sortFields[domain.ReportJob]{
+	"created_at": func(a, b domain.ReportJob) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	"updated_at": func(a, b domain.ReportJob) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+	"version":    byField(func(j domain.ReportJob) int { return j.Version }),
+}
+
+type reportJobRepository struct {
+	jobs *store[domain.ReportJob]
+}
+
+func NewReportJobRepository() domain.ReportJobRepository {
+	return &reportJobRepository{
+		jobs: newStore[domain.ReportJob](),
+	}
+}
+
+func (r *reportJobRepository) Create(ctx context.Context, job domain.ReportJob) (string, error) {
+	r.jobs.put(job.JobID, job)
+
+	return job.JobID, nil
+}
+
+func (r *reportJobRepository) GetByID(ctx context.Context, jobID string) (*domain.ReportJob, error) {
+	job, ok := r.jobs.get(jobID)
+	if !ok || !domain.IsInTenantScope(ctx, job.TenantID) {
+		return nil, domain.NewNotFoundError("no report job found with this id", map[string]any{"job_id": jobID})
+	}
+
+	return &job, nil
+}
+
+func (r *reportJobRepository) Update(ctx context.Context, job domain.ReportJob) error {
+	if current, ok := r.jobs.get(job.JobID); !ok || !domain.IsInTenantScope(ctx, current.TenantID) {
+		return domain.NewNotFoundError("no report job found with this id", map[string]any{"job_id": job.JobID})
+	}
+
+	r.jobs.put(job.JobID, job)
+
+	return nil
+}
+
+func (r *reportJobRepository) Search(ctx context.Context, filters domain.ReportJobFilters) (domain.PagingResult[domain.ReportJob], error) {
+	jobs := r.jobs.filter(func(job domain.ReportJob) bool {
+		return domain.IsInTenantScope(ctx, job.TenantID) &&
+			matchesAny(filters.JobID, job.JobID) &&
+			matchesAny(filters.TenantID, job.TenantID) &&
+			matchesAny(filters.TicketID, job.TicketID) &&
+			matchesAny(filters.Status, string(job.Status))
+	})
+
+	result, paging, err := paginate(jobs, filters.PagingFilter, reportJobSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.ReportJob]{}, err
+	}
+
+	return domain.PagingResult[domain.ReportJob]{
+		Result: result,
+		Paging: paging,
+	}, nil
+}
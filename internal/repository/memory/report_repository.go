@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var reportSortableFields = sortFields[domain.Report]{
+	"created_at":  func(a, b domain.Report) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	"updated_at":  func(a, b domain.Report) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+	"report_name": byField(func(r domain.Report) string { return r.ReportName }),
+	"version":     byField(func(r domain.Report) int { return r.Version }),
+}
+
+type reportRepository struct {
+	reports *store[domain.Report]
+}
+
+func NewReportRepository() domain.ReportRepository {
+	return &reportRepository{
+		reports: newStore[domain.Report](),
+	}
+}
+
+func (r *reportRepository) Create(ctx context.Context, report domain.Report) (string, error) {
+	r.reports.put(report.ReportID, report)
+
+	return report.ReportID, nil
+}
+
+func (r *reportRepository) GetByID(ctx context.Context, reportID string) (*domain.Report, error) {
+	report, ok := r.reports.get(reportID)
+	if !ok || !domain.IsInTenantScope(ctx, report.TenantID) {
+		return nil, domain.NewNotFoundError("no report found with this id", map[string]any{"report_id": reportID})
+	}
+
+	return &report, nil
+}
+
+func (r *reportRepository) Update(ctx context.Context, report domain.Report) error {
+	if current, ok := r.reports.get(report.ReportID); !ok || !domain.IsInTenantScope(ctx, current.TenantID) {
+		return domain.NewNotFoundError("no report found with this id", map[string]any{"report_id": report.ReportID})
+	}
+
+	r.reports.put(report.ReportID, report)
+
+	return nil
+}
+
+func (r *reportRepository) Search(ctx context.Context, filters domain.ReportFilters) (domain.PagingResult[domain.Report], error) {
+	reports := r.reports.filter(func(report domain.Report) bool {
+		return domain.IsInTenantScope(ctx, report.TenantID) &&
+			matchesAny(filters.ReportID, report.ReportID) &&
+			matchesAny(filters.TenantID, report.TenantID) &&
+			matchesAny(filters.ReportName, report.ReportName) &&
+			(filters.Active == nil || report.Active == *filters.Active)
+	})
+
+	result, paging, err := paginate(reports, filters.PagingFilter, reportSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Report]{}, err
+	}
+
+	return domain.PagingResult[domain.Report]{
+		Result: result,
+		Paging: paging,
+	}, nil
+}
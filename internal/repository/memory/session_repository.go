@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type sessionRepository struct {
+	sessions *store[domain.Session]
+}
+
+func NewSessionRepository() domain.SessionRepository {
+	return &sessionRepository{
+		sessions: newStore[domain.Session](),
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session domain.Session) (string, error) {
+	r.sessions.put(session.SessionID, session)
+
+	return session.SessionID, nil
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, sessionID string) (*domain.Session, error) {
+	session, ok := r.sessions.get(sessionID)
+	if !ok {
+		return nil, domain.NewNotFoundError("no session found with this id", map[string]any{"session_id": sessionID})
+	}
+
+	return &session, nil
+}
+
+func (r *sessionRepository) Update(ctx context.Context, session domain.Session) error {
+	if _, ok := r.sessions.get(session.SessionID); !ok {
+		return domain.NewNotFoundError("no session found with this id", map[string]any{"session_id": session.SessionID})
+	}
+
+	r.sessions.put(session.SessionID, session)
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeByUserID(ctx context.Context, userID string, revokedAt time.Time) error {
+	sessions := r.sessions.filter(func(session domain.Session) bool {
+		return session.UserID == userID && session.RevokedAt == nil
+	})
+
+	for _, session := range sessions {
+		session.RevokedAt = &revokedAt
+		session.UpdatedAt = revokedAt
+		r.sessions.put(session.SessionID, session)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const defaultSortField = "created_at"
+
+type store[T any] struct {
+	mu    sync.RWMutex
+	items map[string]T
+	order []string
+}
+
+func newStore[T any]() *store[T] {
+	return &store[T]{
+		items: make(map[string]T),
+	}
+}
+
+func (s *store[T]) put(id string, item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.items[id]; !exists {
+		s.order = append(s.order, id)
+	}
+	s.items[id] = item
+}
+
+func (s *store[T]) get(id string) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item, ok := s.items[id]
+	return item, ok
+}
+
+// update changes the item in place while holding the lock, so the change can depend on the item's
+// current state. It reports whether the item exists and whether apply changed it.
+func (s *store[T]) update(id string, apply func(item *T) bool) (bool, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, exists := s.items[id]
+	if !exists {
+		return false, false
+	}
+
+	if !apply(&item) {
+		return true, false
+	}
+	s.items[id] = item
+	return true, true
+}
+
+// upsert applies change to the item, or to initial when there is no item with the id yet, while
+// holding the lock.
+func (s *store[T]) upsert(id string, initial T, change func(item *T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, exists := s.items[id]
+	if !exists {
+		item = initial
+		s.order = append(s.order, id)
+	}
+
+	change(&item)
+	s.items[id] = item
+}
+
+func (s *store[T]) delete(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.items[id]; !exists {
+		return false
+	}
+
+	delete(s.items, id)
+	s.order = slices.DeleteFunc(s.order, func(itemID string) bool {
+		return itemID == id
+	})
+	return true
+}
+
+func (s *store[T]) deleteIf(match func(item T) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.order = slices.DeleteFunc(s.order, func(id string) bool {
+		if !match(s.items[id]) {
+			return false
+		}
+		delete(s.items, id)
+		return true
+	})
+}
+
+func (s *store[T]) filter(match func(item T) bool) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]T, 0)
+	for _, id := range s.order {
+		item := s.items[id]
+		if match(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+type sortFields[T any] map[string]func(a, b T) int
+
+func paginate[T any](items []T, paging domain.PagingFilter, sortable sortFields[T]) ([]T, domain.Paging, error) {
+	sortField := defaultSortField
+	if paging.SortBy != "" {
+		sortField = paging.SortBy
+	}
+
+	compare, ok := sortable[sortField]
+	if !ok {
+		return nil, domain.Paging{}, domain.NewValidationError("invalid sort field", map[string]any{"sort": paging.SortBy})
+	}
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		if paging.SortDirection == domain.ASC {
+			return compare(a, b)
+		}
+		return compare(b, a)
+	})
+
+	total := len(items)
+	start := min(paging.Offset, total)
+	end := total
+	if paging.Limit > 0 {
+		end = min(start+paging.Limit, total)
+	}
+
+	return items[start:end], domain.Paging{
+		Total:  total,
+		Limit:  paging.Limit,
+		Offset: paging.Offset,
+	}, nil
+}
+
+func matchesAny(values []string, value string) bool {
+	return len(values) == 0 || slices.Contains(values, value)
+}
+
+func compareTime(a, b time.Time) int {
+	return a.Compare(b)
+}
+
+func compareOptionalTime(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return a.Compare(*b)
+	}
+}
+
+func byField[T any, V cmp.Ordered](field func(item T) V) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(field(a), field(b))
+	}
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var tenantSortableFields = sortFields[domain.Tenant]{
+	"created_at":   func(a, b domain.Tenant) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	"updated_at":   func(a, b domain.Tenant) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+	"company_name": byField(func(t domain.Tenant) string { return t.CompanyName }),
+	"legal_name":   byField(func(t domain.Tenant) string { return t.LegalName }),
+}
+
+type tenantRepository struct {
+	tenants *store[domain.Tenant]
+}
+
+func NewTenantRepository() domain.TenantRepository {
+	return &tenantRepository{
+		tenants: newStore[domain.Tenant](),
+	}
+}
+
+func (r *tenantRepository) Create(ctx context.Context, tenant domain.Tenant) (string, error) {
+	tenant.Tickets = nil
+	r.tenants.put(tenant.TenantID, tenant)
+
+	return tenant.TenantID, nil
+}
+
+func (r *tenantRepository) GetByID(ctx context.Context, tenantID string) (*domain.Tenant, error) {
+	tenant, ok := r.tenants.get(tenantID)
+	if !ok || !domain.IsInTenantScope(ctx, tenant.TenantID) {
+		return nil, domain.NewNotFoundError("no tenant found with this id", map[string]any{"tenant_id": tenantID})
+	}
+
+	return &tenant, nil
+}
+
+func (r *tenantRepository) Search(ctx context.Context, filters domain.TenantFilters) (domain.PagingResult[domain.Tenant], error) {
+	tenants := r.tenants.filter(func(tenant domain.Tenant) bool {
+		return domain.IsInTenantScope(ctx, tenant.TenantID) &&
+			matchesAny(filters.TenantID, tenant.TenantID) &&
+			matchesAny(filters.CompanyName, tenant.CompanyName) &&
+			matchesAny(filters.Document, tenant.Document) &&
+			(filters.Active == nil || *filters.Active == tenant.Active)
+	})
+
+	result, paging, err := paginate(tenants, filters.PagingFilter, tenantSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Tenant]{}, err
+	}
+
+	return domain.PagingResult[domain.Tenant]{
+		Result: result,
+		Paging: paging,
+	}, nil
+}
+
+func (r *tenantRepository) Update(ctx context.Context, tenant domain.Tenant) error {
+	if current, ok := r.tenants.get(tenant.TenantID); !ok || !domain.IsInTenantScope(ctx, current.TenantID) {
+		return domain.NewNotFoundError("no tenant found with this id", map[string]any{"tenant_id": tenant.TenantID})
+	}
+
+	tenant.Tickets = nil
+	r.tenants.put(tenant.TenantID, tenant)
+
+	return nil
+}
+
+func (r *tenantRepository) Delete(ctx context.Context, tenantID string) error {
+	if tenantID == "" {
+		return domain.NewValidationError("tenantID is required", map[string]any{"tenant_id": tenantID})
+	}
+
+	if current, ok := r.tenants.get(tenantID); ok && domain.IsInTenantScope(ctx, current.TenantID) {
+		r.tenants.delete(tenantID)
+	}
+
+	return nil
+}
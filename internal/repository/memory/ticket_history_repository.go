@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var ticketHistorySortableFields = sortFields[domain.TicketEvent]{
+	"created_at": func(a, b domain.TicketEvent) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	"action":     byField(func(e domain.TicketEvent) domain.TicketAction { return e.Action }),
+}
+
+type ticketHistoryRepository struct {
+	events *store[domain.TicketEvent]
+}
+
+func NewTicketHistoryRepository() domain.TicketHistoryRepository {
+	return &ticketHistoryRepository{
+		events: newStore[domain.TicketEvent](),
+	}
+}
+
+func (r *ticketHistoryRepository) Create(ctx context.Context, event domain.TicketEvent) (string, error) {
+	r.events.put(event.EventID, event)
+
+	return event.EventID, nil
+}
+
+func (r *ticketHistoryRepository) Search(ctx context.Context, filters domain.TicketHistoryFilters) (domain.PagingResult[domain.TicketEvent], error) {
+	events := r.events.filter(func(event domain.TicketEvent) bool {
+		return domain.IsInTenantScope(ctx, event.TenantID) &&
+			event.TicketID == filters.TicketID
+	})
+
+	result, paging, err := paginate(events, filters.PagingFilter, ticketHistorySortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.TicketEvent]{}, err
+	}
+
+	return domain.PagingResult[domain.TicketEvent]{
+		Result: result,
+		Paging: paging,
+	}, nil
+}
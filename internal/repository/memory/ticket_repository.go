@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var ticketSortableFields = sortFields[domain.Ticket]{
+	"created_at": func(a, b domain.Ticket) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	"updated_at": func(a, b domain.Ticket) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+	"due_date":   func(a, b domain.Ticket) int { return compareTime(a.DueDate, b.DueDate) },
+	"target_date": func(a, b domain.Ticket) int {
+		return compareOptionalTime(a.TargetDate, b.TargetDate)
+	},
+	"status":   byField(func(t domain.Ticket) domain.TicketStatus { return t.Status }),
+	"priority": byField(func(t domain.Ticket) domain.TicketPriority { return t.Priority }),
+}
+
+type ticketRepository struct {
+	tickets *store[domain.Ticket]
+}
+
+func NewTicketRepository() domain.TicketRepository {
+	return &ticketRepository{
+		tickets: newStore[domain.Ticket](),
+	}
+}
+
+func (r *ticketRepository) Create(ctx context.Context, crmTicket domain.Ticket) (string, error) {
+	crmTicket.Comments = nil
+	crmTicket.Transactions = nil
+	r.tickets.put(crmTicket.TicketID, crmTicket)
+
+	return crmTicket.TicketID, nil
+}
+
+func (r *ticketRepository) GetByID(ctx context.Context, ticketID string) (*domain.Ticket, error) {
+	if ticketID == "" {
+		return nil, domain.NewValidationError("ticketID is required", nil)
+	}
+
+	crmTicket, ok := r.tickets.get(ticketID)
+	if !ok || !domain.IsInTenantScope(ctx, crmTicket.TenantID) {
+		return nil, domain.NewNotFoundError("no ticket found with this id", map[string]any{"ticket_id": ticketID})
+	}
+
+	return &crmTicket, nil
+}
+
+func (r *ticketRepository) Search(ctx context.Context, filters domain.TicketFilters) (domain.PagingResult[domain.Ticket], error) {
+	crmTickets := r.tickets.filter(func(crmTicket domain.Ticket) bool {
+		return domain.IsInTenantScope(ctx, crmTicket.TenantID) &&
+			matchesAny(filters.TenantID, crmTicket.TenantID) &&
+			matchesAny(filters.OwnerID, crmTicket.OwnerID) &&
+			matchesAny(filters.CustomerID, crmTicket.CustomerID) &&
+			matchesAny(filters.LeadID, crmTicket.LeadID) &&
+			matchesAny(filters.Status, string(crmTicket.Status)) &&
+			matchesAny(filters.Region, strconv.Itoa(crmTicket.Region)) &&
+			(filters.CreatedFrom == nil || !crmTicket.CreatedAt.Before(*filters.CreatedFrom)) &&
+			(filters.CreatedTo == nil || crmTicket.CreatedAt.Before(*filters.CreatedTo)) &&
+			(filters.DueBefore == nil || crmTicket.DueDate.Before(*filters.DueBefore))
+	})
+
+	result, paging, err := paginate(crmTickets, filters.PagingFilter, ticketSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Ticket]{}, err
+	}
+
+	return domain.PagingResult[domain.Ticket]{
+		Result: result,
+		Paging: paging,
+	}, nil
+}
+
+func (r *ticketRepository) Update(ctx context.Context, crmTicket domain.Ticket) error {
+	if current, ok := r.tickets.get(crmTicket.TicketID); !ok || !domain.IsInTenantScope(ctx, current.TenantID) {
+		return domain.NewNotFoundError("no ticket found with this id", map[string]any{"ticket_id": crmTicket.TicketID})
+	}
+
+	crmTicket.Comments = nil
+	crmTicket.Transactions = nil
+	r.tickets.put(crmTicket.TicketID, crmTicket)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var transactionSortableFields = sortFields[domain.Transaction]{
+	"created_at": func(a, b domain.Transaction) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	"updated_at": func(a, b domain.Transaction) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+	"value":      byField(func(t domain.Transaction) float64 { return t.Value }),
+	"status":     byField(func(t domain.Transaction) domain.TransactionStatus { return t.Status }),
+	"type":       byField(func(t domain.Transaction) domain.TransactionType { return t.Type }),
+}
+
+type transactionRepository struct {
+	transactions *store[domain.Transaction]
+}
+
+func NewTransactionRepository() domain.TransactionRepository {
+	return &transactionRepository{
+		transactions: newStore[domain.Transaction](),
+	}
+}
+
+func (r *transactionRepository) CreateTransaction(ctx context.Context, transaction domain.Transaction) (string, error) {
+	r.transactions.put(transaction.TransactionID, transaction)
+
+	return transaction.TransactionID, nil
+}
+
+func (r *transactionRepository) GetTransaction(ctx context.Context, transactionID string) (domain.Transaction, error) {
+	if transactionID == "" {
+		return domain.Transaction{}, domain.NewValidationError("transaction_id is required", nil)
+	}
+
+	transaction, ok := r.transactions.get(transactionID)
+	if !ok || !domain.IsInTenantScope(ctx, transaction.TenantID) {
+		return domain.Transaction{}, domain.NewNotFoundError("no transaction found with this id", map[string]any{"transaction_id": transactionID})
+	}
+
+	return transaction, nil
+}
+
+func (r *transactionRepository) UpdateTransaction(ctx context.Context, transaction domain.Transaction) error {
+	if current, ok := r.transactions.get(transaction.TransactionID); !ok || !domain.IsInTenantScope(ctx, current.TenantID) {
+		return domain.NewNotFoundError("no transaction found with this id", map[string]any{"transaction_id": transaction.TransactionID})
+	}
+
+	r.transactions.put(transaction.TransactionID, transaction)
+
+	return nil
+}
+
+func (r *transactionRepository) SearchTransactions(ctx context.Context, filters domain.TransactionFilters) (domain.PagingResult[domain.Transaction], error) {
+	transactions := r.transactions.filter(func(transaction domain.Transaction) bool {
+		return domain.IsInTenantScope(ctx, transaction.TenantID) &&
+			matchesAny(filters.TicketIDs, transaction.TicketID) &&
+			matchesAny(filters.TenantIDs, transaction.TenantID) &&
+			matchesAny(filters.Status, string(transaction.Status)) &&
+			matchesAny(filters.Types, string(transaction.Type))
+	})
+
+	result, paging, err := paginate(transactions, filters.PagingFilter, transactionSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.Transaction]{}, err
+	}
+
+	return domain.PagingResult[domain.Transaction]{
+		Result: result,
+		Paging: paging,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type uploadSessionRepository struct {
+	sessions *store[domain.UploadSession]
+}
+
+func NewUploadSessionRepository() domain.UploadSessionRepository {
+	return &uploadSessionRepository{
+		sessions: newStore[domain.UploadSession](),
+	}
+}
+
+func (r *uploadSessionRepository) Create(ctx context.Context, session domain.UploadSession) (string, error) {
+	r.sessions.put(session.SessionID, session)
+
+	return session.SessionID, nil
+}
+
+func (r *uploadSessionRepository) GetByID(ctx context.Context, sessionID string) (*domain.UploadSession, error) {
+	session, ok := r.sessions.get(sessionID)
+	if !ok || !domain.IsInTenantScope(ctx, session.TenantID) {
+		return nil, domain.NewNotFoundError("no upload session found with this id", map[string]any{"session_id": sessionID})
+	}
+
+	return &session, nil
+}
+
+func (r *uploadSessionRepository) Update(ctx context.Context, session domain.UploadSession) error {
+	if current, ok := r.sessions.get(session.SessionID); !ok || !domain.IsInTenantScope(ctx, current.TenantID) {
+		return domain.NewNotFoundError("no upload session found with this id", map[string]any{"session_id": session.SessionID})
+	}
+
+	r.sessions.put(session.SessionID, session)
+
+	return nil
+}
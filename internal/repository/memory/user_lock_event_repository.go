@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type userLockEventRepository struct {
+	events *store[domain.UserLockEvent]
+}
+
+func NewUserLockEventRepository() domain.UserLockEventRepository {
+	return &userLockEventRepository{
+		events: newStore[domain.UserLockEvent](),
+	}
+}
+
+func (r *userLockEventRepository) Create(ctx context.Context, event domain.UserLockEvent) (string, error) {
+	r.events.put(event.EventID, event)
+
+	return event.EventID, nil
+}
+
+func (r *userLockEventRepository) GetByUserID(ctx context.Context, userID string) ([]domain.UserLockEvent, error) {
+	if userID == "" {
+		return nil, domain.NewValidationError("userID is required", nil)
+	}
+
+	events := r.events.filter(func(event domain.UserLockEvent) bool {
+		return domain.IsInTenantScope(ctx, event.TenantID) && event.UserID == userID
+	})
+
+	return events, nil
+}
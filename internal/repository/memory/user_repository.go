@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+var userSortableFields = sortFields[domain.User]{
+	"created_at": func(a, b domain.User) int { return compareTime(a.CreatedAt, b.CreatedAt) },
+	"updated_at": func(a, b domain.User) int { return compareTime(a.UpdatedAt, b.UpdatedAt) },
+	"username":   byField(func(u domain.User) string { return u.Username }),
+	"first_name": byField(func(u domain.User) string { return u.FirstName }),
+	"email":      byField(func(u domain.User) string { return u.Email }),
+	"role":       byField(func(u domain.User) domain.UserRole { return u.Role }),
+	"region":     byField(func(u domain.User) int { return u.Region }),
+}
+
+type userRepository struct {
+	users *store[domain.User]
+}
+
+func NewUserRepository() domain.UserRepository {
+	return &userRepository{
+		users: newStore[domain.User](),
+	}
+}
+
+func (r *userRepository) Create(ctx context.Context, user domain.User) (string, error) {
+	user.Tickets = nil
+	r.users.put(user.UserID, user)
+
+	return user.UserID, nil
+}
+
+func (r *userRepository) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	user, ok := r.users.get(userID)
+	if !ok || !domain.IsInTenantScope(ctx, user.TenantID) {
+		return nil, domain.NewNotFoundError("no user found with this id", map[string]any{"user_id": userID})
+	}
+
+	return &user, nil
+}
+
+func (r *userRepository) Search(ctx context.Context, filters domain.UserFilters) (domain.PagingResult[domain.User], error) {
+	users := r.users.filter(func(user domain.User) bool {
+		return domain.IsInTenantScope(ctx, user.TenantID) &&
+			matchesAny(filters.UserID, user.UserID) &&
+			matchesAny(filters.TenantID, user.TenantID) &&
+			matchesAny(filters.Username, user.Username) &&
+			matchesAny(filters.FirstName, user.FirstName) &&
+			matchesAny(filters.Email, user.Email) &&
+			matchesAny(filters.Role, string(user.Role)) &&
+			matchesAny(filters.Region, strconv.Itoa(user.Region)) &&
+			(filters.Active == nil || *filters.Active == user.Active)
+	})
+
+	result, paging, err := paginate(users, filters.PagingFilter, userSortableFields)
+	if err != nil {
+		return domain.PagingResult[domain.User]{}, err
+	}
+
+	return domain.PagingResult[domain.User]{
+		Result: result,
+		Paging: paging,
+	}, nil
+}
+
+func (r *userRepository) Update(ctx context.Context, userToUpdate domain.User) error {
+	if current, ok := r.users.get(userToUpdate.UserID); !ok || !domain.IsInTenantScope(ctx, current.TenantID) {
+		return domain.NewNotFoundError("no user found with this id", map[string]any{"user_id": userToUpdate.UserID})
+	}
+
+	userToUpdate.Tickets = nil
+	r.users.put(userToUpdate.UserID, userToUpdate)
+
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, userID string) error {
+	if userID == "" {
+		return domain.NewValidationError("userID cannot be empty", nil)
+	}
+
+	if current, ok := r.users.get(userID); ok && domain.IsInTenantScope(ctx, current.TenantID) {
+		r.users.delete(userID)
+	}
+
+	return nil
+}
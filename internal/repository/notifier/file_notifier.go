@@ -0,0 +1,81 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type fileNotifier struct {
+	mu       sync.Mutex
+	filePath string
+}
+
+type fileNotification struct {
+	Type        string     `json:"type"`
+	UserID      string     `json:"user_id"`
+	Email       string     `json:"email"`
+	Link        string     `json:"link,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	TicketID    string     `json:"ticket_id,omitempty"`
+	CommentID   string     `json:"comment_id,omitempty"`
+	MentionedBy string     `json:"mentioned_by,omitempty"`
+	SentAt      time.Time  `json:"sent_at"`
+}
+
+func NewFileNotifier(filePath string) (domain.UserNotifier, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return nil, err
+	}
+
+	return &fileNotifier{
+		filePath: filePath,
+	}, nil
+}
+
+func (n *fileNotifier) NotifyPasswordReset(ctx context.Context, user domain.User, resetLink string, expiresAt time.Time) error {
+	return n.append(fileNotification{
+		Type:      "password_reset",
+		UserID:    user.UserID,
+		Email:     user.Email,
+		Link:      resetLink,
+		ExpiresAt: &expiresAt,
+		SentAt:    time.Now().UTC(),
+	})
+}
+
+func (n *fileNotifier) NotifyMention(ctx context.Context, user domain.User, comment domain.Comment) error {
+	return n.append(fileNotification{
+		Type:        "mention",
+		UserID:      user.UserID,
+		Email:       user.Email,
+		TicketID:    comment.TicketID,
+		CommentID:   comment.CommentID,
+		MentionedBy: comment.UpdatedBy,
+		SentAt:      time.Now().UTC(),
+	})
+}
+
+func (n *fileNotifier) append(notification fileNotification) error {
+	line, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
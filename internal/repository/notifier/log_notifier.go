@@ -0,0 +1,29 @@
+package notifier
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+type logNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier() domain.UserNotifier {
+	return &logNotifier{
+		logger: log.Default(),
+	}
+}
+
+func (n *logNotifier) NotifyPasswordReset(ctx context.Context, user domain.User, resetLink string, expiresAt time.Time) error {
+	n.logger.Printf("password reset requested for user %s <%s>: %s (expires at %s)", user.UserID, user.Email, resetLink, expiresAt.Format(time.RFC3339))
+	return nil
+}
+
+func (n *logNotifier) NotifyMention(ctx context.Context, user domain.User, comment domain.Comment) error {
+	n.logger.Printf("user %s <%s> was mentioned by %s in comment %s of ticket %s", user.UserID, user.Email, comment.UpdatedBy, comment.CommentID, comment.TicketID)
+	return nil
+}
@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/icrxz/crm-api-core/internal/domain"
+)
+
+const scanChunkSize = 64 * 1024
+
+// eicarSignature is the standard antivirus test file, so the scanner can be checked without real malware.
+var eicarSignature = signature{
+	name:    "EICAR-Test-File",
+	pattern: []byte(`X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`),
+}
+
+type signature struct {
+	name    string
+	pattern []byte
+}
+
+type signatureScanner struct {
+	signatures []signature
+	longest    int
+}
+
+// NewSignatureScanner looks for known byte patterns in the files. Besides the EICAR test signature,
+// patterns are read from signaturesFile, one `<name> <hex bytes>` per line; blank lines and lines
+// starting with # are ignored.
+func NewSignatureScanner(signaturesFile string) (domain.MalwareScanner, error) {
+	signatures := []signature{eicarSignature}
+	if signaturesFile != "" {
+		fileSignatures, err := readSignatures(signaturesFile)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, fileSignatures...)
+	}
+
+	longest := 0
+	for _, sig := range signatures {
+		longest = max(longest, len(sig.pattern))
+	}
+
+	return &signatureScanner{
+		signatures: signatures,
+		longest:    longest,
+	}, nil
+}
+
+// Scan reads the content in chunks, keeping the tail of the previous chunk so patterns split between
+// two reads are still found.
+func (s *signatureScanner) Scan(ctx context.Context, content io.Reader) (string, error) {
+	buffer := make([]byte, 0, scanChunkSize+s.longest)
+	chunk := make([]byte, scanChunkSize)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		n, err := content.Read(chunk)
+		buffer = append(buffer, chunk[:n]...)
+
+		for _, sig := range s.signatures {
+			if bytes.Contains(buffer, sig.pattern) {
+				return sig.name, nil
+			}
+		}
+
+		if err == io.EOF {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if keep := s.longest - 1; len(buffer) > keep {
+			buffer = append(buffer[:0], buffer[len(buffer)-keep:]...)
+		}
+	}
+}
+
+func readSignatures(signaturesFile string) ([]signature, error) {
+	file, err := os.Open(signaturesFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	signatures := make([]signature, 0)
+	lines := bufio.NewScanner(file)
+	for lineNumber := 1; lines.Scan(); lineNumber++ {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, pattern, found := strings.Cut(line, " ")
+		decodedPattern, err := hex.DecodeString(strings.TrimSpace(pattern))
+		if !found || err != nil || len(decodedPattern) == 0 {
+			return nil, fmt.Errorf("invalid signature at %s:%d", signaturesFile, lineNumber)
+		}
+
+		signatures = append(signatures, signature{name: name, pattern: decodedPattern})
+	}
+
+	return signatures, lines.Err()
+}
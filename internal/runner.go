@@ -6,86 +6,183 @@ import (
 	entrypoint2 "github.com/icrxz/crm-api-core/internal/entrypoint"
 	"github.com/icrxz/crm-api-core/internal/entrypoint/middleware"
 	rest2 "github.com/icrxz/crm-api-core/internal/entrypoint/rest"
-	bucket2 "github.com/icrxz/crm-api-core/internal/repository/bucket"
-	database2 "github.com/icrxz/crm-api-core/internal/repository/database"
 
 	"github.com/gin-gonic/gin"
 	"github.com/icrxz/crm-api-core/internal/application"
+	"github.com/icrxz/crm-api-core/internal/domain"
 )
 
 func RunApp() error {
-	_ = context.Background()
-
 	appConfig, err := config.Load()
 	if err != nil {
 		return err
 	}
 
-	// database
-	mongoDB, err := database2.NewDBInstance(appConfig.Database)
+	repos, err := loadRepositories(context.Background(), appConfig)
 	if err != nil {
 		return err
 	}
 
-	// bucket
-	s3Client, err := bucket2.NewS3Bucket(context.Background(), appConfig.AttachmentsBucket)
+	// services
+	userService := application.NewUserService(repos.userRepository)
+	err = userService.EnsurePlatformAdmin(
+		domain.ContextWithAllTenants(context.Background()),
+		appConfig.PlatformAdmin.TenantID,
+		appConfig.PlatformAdmin.Email,
+		appConfig.PlatformAdmin.Password(),
+	)
 	if err != nil {
 		return err
 	}
-
-	attachmentBucket := bucket2.NewAttachmentBucket(s3Client, appConfig.AttachmentsBucket.Name)
-
-	// repositories
-	userRepository := database2.NewUserRepository(mongoDB)
-	leadRepository := database2.NewLeadRepository(mongoDB)
-	customerRepository := database2.NewCustomerRepository(mongoDB)
-	tenantRepository := database2.NewTenantRepository(mongoDB)
-	ticketRepository := database2.NewTicketRepository(mongoDB)
-	productRepository := database2.NewProductRepository(mongoDB)
-	commentRepository := database2.NewCommentRepository(mongoDB)
-	transactionRepository := database2.NewTransactionRepository(mongoDB)
-	attachmentRepository := database2.NewAttachmentRepository(mongoDB)
-
-	// services
-	userService := application.NewUserService(userRepository)
-	leadService := application.NewLeadService(leadRepository)
-	customerService := application.NewCustomerService(customerRepository)
-	tenantService := application.NewTenantService(tenantRepository)
-	authService := application.NewAuthService(userRepository, appConfig.SecretKey())
-	productService := application.NewProductService(productRepository)
-	ticketService := application.NewTicketService(customerService, ticketRepository, productService, userService)
-	commentService := application.NewCommentService(commentRepository, attachmentRepository, attachmentBucket)
-	transactionService := application.NewTransactionService(transactionRepository)
+	leadService := application.NewLeadService(repos.leadRepository)
+	customerService := application.NewCustomerService(repos.customerRepository)
+	tenantService := application.NewTenantService(repos.tenantRepository)
+	lockoutService := application.NewLockoutService(
+		repos.userRepository,
+		repos.loginAttemptRepository,
+		repos.userLockEventRepository,
+		domain.LockoutPolicy{
+			MaxAttempts:  appConfig.Lockout.MaxAttempts,
+			BaseLockTime: appConfig.Lockout.BaseLockTime,
+			MaxLockTime:  appConfig.Lockout.MaxLockTime,
+		},
+		domain.LockoutPolicy{
+			MaxAttempts:  appConfig.Lockout.IPMaxAttempts,
+			BaseLockTime: appConfig.Lockout.BaseLockTime,
+			MaxLockTime:  appConfig.Lockout.MaxLockTime,
+		},
+	)
+	authService := application.NewAuthService(
+		repos.userRepository,
+		repos.sessionRepository,
+		lockoutService,
+		appConfig.SecretKey(),
+		appConfig.Session.AccessTokenTTL,
+		appConfig.Session.RefreshTokenTTL,
+	)
+	userNotifier, err := loadUserNotifier(appConfig)
+	if err != nil {
+		return err
+	}
+	passwordService := application.NewPasswordService(
+		repos.userRepository,
+		repos.passwordResetRepository,
+		repos.sessionRepository,
+		userNotifier,
+		appConfig.PasswordReset.TokenTTL,
+		appConfig.PasswordReset.LinkURL,
+	)
+	productService := application.NewProductService(repos.productRepository)
+	ticketHistoryService := application.NewTicketHistoryService(repos.ticketHistoryRepository)
+	notificationService := application.NewNotificationService(repos.notificationRepository, repos.ticketRepository, appConfig.Notifications.SLACheckInterval)
+	notificationService.Start(context.Background())
+	ticketService := application.NewTicketService(customerService, repos.ticketRepository, productService, userService, ticketHistoryService, notificationService)
+	malwareScanner, err := loadMalwareScanner(appConfig)
+	if err != nil {
+		return err
+	}
+	attachmentValidator := application.NewAttachmentValidator(
+		repos.tenantRepository,
+		repos.commentRepository,
+		repos.attachmentRepository,
+		repos.attachmentBucket,
+		malwareScanner,
+		domain.AttachmentPolicy{
+			AllowedContentTypes: appConfig.Attachments.AllowedContentTypes,
+			MaxFileSize:         appConfig.Attachments.MaxFileSize,
+			MaxTicketSize:       appConfig.Attachments.MaxTicketSize,
+		},
+	)
+	attachmentImageProcessor := application.NewAttachmentImageProcessor(repos.attachmentBucket, appConfig.Attachments.ThumbnailSize)
+	commentService := application.NewCommentService(
+		repos.ticketRepository,
+		repos.userRepository,
+		repos.commentRepository,
+		repos.commentRevisionRepository,
+		repos.attachmentRepository,
+		repos.attachmentBucket,
+		attachmentValidator,
+		attachmentImageProcessor,
+		userNotifier,
+		notificationService,
+		appConfig.AttachmentsBucket.DownloadURLTTL,
+	)
+	transactionService := application.NewTransactionService(repos.transactionRepository, repos.ticketRepository)
+	reportTemplateService := application.NewReportTemplateService(repos.reportRepository, repos.attachmentBucket)
 	reportService := application.NewReportService(
-		appConfig.ReportFolder,
+		reportTemplateService,
 		ticketService,
 		productService,
 		customerService,
 		commentService,
 		leadService,
 		tenantService,
-		attachmentBucket,
+		transactionService,
+		repos.attachmentBucket,
+	)
+	ticketActionService := application.NewTicketActionService(repos.ticketRepository, commentService, reportService, ticketHistoryService, notificationService)
+	reportJobService := application.NewReportJobService(
+		repos.reportJobRepository,
+		repos.ticketRepository,
+		reportService,
+		repos.attachmentBucket,
+		appConfig.ReportJobs.Workers,
+		appConfig.ReportJobs.QueueSize,
+		appConfig.ReportJobs.Timeout,
+	)
+	reportJobService.Start(context.Background())
+	uploadSessionService := application.NewUploadSessionService(
+		repos.uploadSessionRepository,
+		repos.commentRepository,
+		repos.attachmentRepository,
+		repos.attachmentBucket,
+		attachmentValidator,
+		attachmentImageProcessor,
+		appConfig.AttachmentsBucket.UploadURLTTL,
+		appConfig.AttachmentsBucket.DownloadURLTTL,
+	)
+	attachmentArchiveService := application.NewAttachmentArchiveService(
+		repos.ticketRepository,
+		repos.userRepository,
+		commentService,
+		repos.attachmentBucket,
+	)
+	reportExportService := application.NewReportExportService(
+		repos.ticketRepository,
+		reportService,
+		appConfig.ReportExport.Concurrency,
+		appConfig.ReportExport.MaxTickets,
 	)
-	ticketActionService := application.NewTicketActionService(ticketRepository, commentService, reportService)
 
 	// controllers
 	pingController := rest2.NewPingController()
-	userController := rest2.NewUserController(userService)
+	userController := rest2.NewUserController(userService, lockoutService)
 	leadController := rest2.NewLeadController(leadService)
 	customerController := rest2.NewCustomerController(customerService)
 	tenantController := rest2.NewTenantController(tenantService)
 	webMessageController := rest2.NewWebMessageController()
-	authController := rest2.NewAuthController(authService)
+	authController := rest2.NewAuthController(authService, passwordService)
 	ticketController := rest2.NewTicketController(ticketService)
 	productController := rest2.NewProductController(productService)
 	commentController := rest2.NewCommentController(commentService)
 	transactionController := rest2.NewTransactionController(transactionService)
 	ticketActionController := rest2.NewTicketActionController(ticketActionService)
+	ticketHistoryController := rest2.NewTicketHistoryController(ticketHistoryService)
+	reportTemplateController := rest2.NewReportTemplateController(reportTemplateService, reportService)
+	reportJobController := rest2.NewReportJobController(reportJobService)
+	reportExportController := rest2.NewReportExportController(reportExportService)
+	uploadSessionController := rest2.NewUploadSessionController(uploadSessionService)
+	attachmentArchiveController := rest2.NewAttachmentArchiveController(attachmentArchiveService)
+	notificationController := rest2.NewNotificationController(notificationService)
 
 	// middlewares
 	authMiddleware := middleware.NewAuthenticationMiddleware(authService)
+	authorizationMiddleware := middleware.NewAuthorizationMiddleware()
 
 	router := gin.Default()
+	if err = router.SetTrustedProxies(appConfig.Server.TrustedProxies); err != nil {
+		return err
+	}
 	router.Use(entrypoint2.CustomErrorEncoder())
 
 	entrypoint2.LoadRoutes(
@@ -98,12 +195,21 @@ func RunApp() error {
 		tenantController,
 		authController,
 		authMiddleware,
+		authorizationMiddleware,
 		ticketController,
 		productController,
 		commentController,
 		transactionController,
 		ticketActionController,
+		ticketHistoryController,
+		reportTemplateController,
+		reportJobController,
+		reportExportController,
+		uploadSessionController,
+		attachmentArchiveController,
+		notificationController,
 	)
+	loadLocalBucketRoutes(router, repos.attachmentBucket)
 
 	return router.Run()
 }